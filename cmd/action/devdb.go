@@ -0,0 +1,457 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+)
+
+// devUnmarshal loads a schema by creating it on a dev database and inspecting the result.
+// The dev database must be empty, and it is cleaned up after the schema was loaded. Since
+// the loaded schema is normalized by the database, it can be compared with inspected schemas
+// without reporting changes for equivalent types, expressions and attributes (e.g. int4 and
+// integer).
+type devUnmarshal struct {
+	// url of the dev database.
+	url string
+	// name of the loaded schema. Tables that are created in the dev
+	// database are loaded to the schema with this name.
+	name string
+	// spec unmarshals the schema files. If nil, the
+	// files are executed as SQL on the dev database.
+	spec schemaUnmarshaler
+	// dialect of the dev database (e.g. "postgres").
+	dialect string
+}
+
+// newDevUnmarshal returns a devUnmarshal that loads SQL files to the schema of the target
+// database. The dev database must be of the same dialect as the target database.
+func newDevUnmarshal(targetURL, devURL string) (*devUnmarshal, error) {
+	if devURL == "" {
+		return nil, fmt.Errorf("flag --dev-url is required for loading SQL schema files")
+	}
+	key, _, err := parseDSN(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	devKey, _, err := parseDSN(devURL)
+	if err != nil {
+		return nil, err
+	}
+	if key != devKey {
+		return nil, fmt.Errorf("dev database must be a %s database, got %q", key, devKey)
+	}
+	name, err := schemaNameFromDSN(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	return &devUnmarshal{url: devURL, name: name, dialect: devKey}, nil
+}
+
+// isSQLFiles reports if the given paths contain SQL files, either
+// directly or in the directories they point to.
+func isSQLFiles(paths []string) bool {
+	for _, p := range paths {
+		if filepath.Ext(p) == ".sql" {
+			return true
+		}
+		if matches, _ := filepath.Glob(filepath.Join(p, "*.sql")); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlFiles returns the SQL files in the given paths. Files in directories are
+// sorted by their names. An error is returned if a path is not an SQL file.
+func sqlFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			if filepath.Ext(p) != ".sql" {
+				return nil, fmt.Errorf("schema file %q cannot be mixed with SQL files", p)
+			}
+			files = append(files, p)
+			continue
+		}
+		entries, err := ioutil.ReadDir(p)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			if filepath.Ext(e.Name()) != ".sql" {
+				return nil, fmt.Errorf("schema file %q cannot be mixed with SQL files", filepath.Join(p, e.Name()))
+			}
+			names = append(names, filepath.Join(p, e.Name()))
+		}
+		sort.Strings(names)
+		files = append(files, names...)
+	}
+	return files, nil
+}
+
+// unmarshal creates the schema in the given paths on the dev database, and inspects it.
+func (u *devUnmarshal) unmarshal(paths []string, v interface{}) (err error) {
+	s, ok := v.(*schema.Schema)
+	if !ok {
+		return fmt.Errorf("unexpected schema type: %T", v)
+	}
+	ctx := context.Background()
+	d, err := defaultMux.OpenAtlas(u.url)
+	if err != nil {
+		return err
+	}
+	name, err := schemaNameFromDSN(u.url)
+	if err != nil {
+		return err
+	}
+	dev, err := d.InspectSchema(ctx, name, nil)
+	if err != nil {
+		return err
+	}
+	if len(dev.Tables) > 0 {
+		return fmt.Errorf("dev database schema %q is not empty, found %d table(s)", name, len(dev.Tables))
+	}
+	n, err := u.objects(ctx, d, dev.Name)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("dev database schema %q is not empty, found %d view(s), type(s) or routine(s)", name, n)
+	}
+	// The dev database is cleaned up even if the schema failed
+	// to load, as some of its objects may have been created.
+	defer func() {
+		if cerr := u.clean(ctx, d, dev); cerr != nil && err == nil {
+			err = fmt.Errorf("cleaning up dev database: %w", cerr)
+		}
+	}()
+	var desired *schema.Schema
+	if u.spec != nil {
+		desired, err = u.create(ctx, d, dev, paths)
+	} else {
+		err = u.exec(ctx, d, paths)
+	}
+	if err != nil {
+		return err
+	}
+	if dev, err = d.InspectSchema(ctx, name, nil); err != nil {
+		return err
+	}
+	if desired != nil {
+		copyAccess(desired, dev)
+	}
+	*s = *dev
+	if u.name != "" {
+		s.Name = u.name
+	}
+	for _, t := range s.Tables {
+		t.Schema = s
+	}
+	return nil
+}
+
+// create unmarshals the schema files, creates their tables in the dev database schema,
+// and returns the unmarshaled schema.
+func (u *devUnmarshal) create(ctx context.Context, d *Driver, dev *schema.Schema, paths []string) (*schema.Schema, error) {
+	var desired schema.Schema
+	if err := u.spec.unmarshal(paths, &desired); err != nil {
+		return nil, err
+	}
+	// Tables are created in the schema of the dev database.
+	desired.Name = dev.Name
+	changes := make([]schema.Change, 0, len(desired.Tables)+1)
+	// Extensions are installed before the tables that may use their types.
+	if exts := devExtensions(dev, &desired); len(exts) > 0 {
+		changes = append(changes, &schema.ModifySchema{S: &desired, Changes: exts})
+	}
+	// Privileges are not granted in the dev database, and policies are created for
+	// PUBLIC, as their roles may not exist there. Instead, they are copied to the
+	// inspected schema by copyAccess.
+	attrs := make(map[*schema.Table][]schema.Attr, len(desired.Tables))
+	for _, t := range desired.Tables {
+		t.Schema = &desired
+		attrs[t] = t.Attrs
+		t.Attrs = devAttrs(t.Attrs)
+		changes = append(changes, &schema.AddTable{T: t})
+	}
+	err := d.Exec(ctx, changes)
+	for t := range attrs {
+		t.Attrs = attrs[t]
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating schema on dev database: %w", err)
+	}
+	return &desired, nil
+}
+
+// Queries for counting the objects of the dev database schema that are not part of the inspected
+// schema (e.g. views, types and routines). Objects that belong to extensions are not counted, as
+// extensions may be installed in the dev database before it is used.
+const (
+	mysqlObjectsQuery = "SELECT (SELECT COUNT(*) FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` = ?) + (SELECT COUNT(*) FROM `INFORMATION_SCHEMA`.`ROUTINES` WHERE `ROUTINE_SCHEMA` = ?)"
+
+	postgresObjectsQuery = `
+SELECT
+	COUNT(*)
+FROM (
+	SELECT 'pg_catalog.pg_class'::regclass AS classid, oid FROM pg_catalog.pg_class WHERE relnamespace = to_regnamespace($1) AND relkind IN ('v', 'm', 'S', 'c')
+	UNION ALL
+	SELECT 'pg_catalog.pg_type'::regclass, oid FROM pg_catalog.pg_type WHERE typnamespace = to_regnamespace($1) AND typtype IN ('d', 'e', 'r')
+	UNION ALL
+	SELECT 'pg_catalog.pg_proc'::regclass, oid FROM pg_catalog.pg_proc WHERE pronamespace = to_regnamespace($1)
+) AS o
+WHERE
+	NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = o.classid AND d.objid = o.oid AND d.deptype = 'e')
+`
+
+	sqliteObjectsQuery = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'view'"
+)
+
+// objects returns the number of objects in the dev database schema that are
+// not part of the inspected schema, like views, types and routines.
+func (u *devUnmarshal) objects(ctx context.Context, d *Driver, name string) (int, error) {
+	var (
+		query string
+		args  []interface{}
+	)
+	switch u.dialect {
+	case "mysql":
+		query, args = mysqlObjectsQuery, []interface{}{name, name}
+	case "postgres":
+		query, args = postgresObjectsQuery, []interface{}{name}
+	case "sqlite":
+		query = sqliteObjectsQuery
+	default:
+		return 0, nil
+	}
+	rows, err := d.interceptor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("querying dev database objects: %w", err)
+	}
+	defer rows.Close()
+	var n int
+	for rows.Next() {
+		if err := rows.Scan(&n); err != nil {
+			return 0, err
+		}
+	}
+	return n, rows.Err()
+}
+
+// clean removes everything that was created in the dev database schema since it was inspected
+// as empty. On MySQL and PostgreSQL, the schema is dropped and created again, as the executed
+// SQL files may create objects that are not inspected (e.g. views, functions or sequences).
+// Extensions that were installed in the empty schema are installed again.
+func (u *devUnmarshal) clean(ctx context.Context, d *Driver, empty *schema.Schema) error {
+	switch u.dialect {
+	case "mysql":
+		s := &schema.Schema{Name: empty.Name}
+		for _, a := range empty.Attrs {
+			switch a.(type) {
+			case *schema.Charset, *schema.Collation:
+				s.Attrs = append(s.Attrs, a)
+			}
+		}
+		return d.Exec(ctx, []schema.Change{&schema.DropSchema{S: empty}, &schema.AddSchema{S: s}})
+	case "postgres":
+		if _, err := d.interceptor.ExecContext(ctx, postgres.Build("DROP SCHEMA").Ident(empty.Name).P("CASCADE").String()); err != nil {
+			return err
+		}
+		s := &schema.Schema{Name: empty.Name}
+		for _, a := range empty.Attrs {
+			switch a.(type) {
+			case *postgres.Extension, *schema.Grant:
+				s.Attrs = append(s.Attrs, a)
+			}
+		}
+		return d.Exec(ctx, []schema.Change{&schema.AddSchema{S: s}})
+	case "sqlite":
+		// The main database cannot be dropped.
+		if err := dropViews(ctx, d); err != nil {
+			return err
+		}
+	}
+	return dropTables(ctx, d, empty.Name)
+}
+
+// dropViews drops the views of an SQLite dev database.
+func dropViews(ctx context.Context, d *Driver) error {
+	rows, err := d.interceptor.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'view'")
+	if err != nil {
+		return err
+	}
+	var views []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		views = append(views, name)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for _, v := range views {
+		if _, err := d.interceptor.ExecContext(ctx, sqlite.Build("DROP VIEW").Ident(v).String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropTables drops the tables of the dev database schema.
+func dropTables(ctx context.Context, d *Driver, name string) error {
+	dev, err := d.InspectSchema(ctx, name, nil)
+	if err != nil {
+		return err
+	}
+	var changes []schema.Change
+	// Foreign keys are dropped first, as tables may reference each other.
+	for _, t := range dev.Tables {
+		if len(t.ForeignKeys) == 0 {
+			continue
+		}
+		drop := make([]schema.Change, 0, len(t.ForeignKeys))
+		for _, fk := range t.ForeignKeys {
+			drop = append(drop, &schema.DropForeignKey{F: fk})
+		}
+		t.ForeignKeys = nil
+		changes = append(changes, &schema.ModifyTable{T: t, Changes: drop})
+	}
+	for _, t := range dev.Tables {
+		changes = append(changes, &schema.DropTable{T: t})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return d.Exec(ctx, changes)
+}
+
+// devAttrs returns the table attributes that are created in the dev database.
+// Grants are removed, and the roles of policies are cleared.
+func devAttrs(attrs []schema.Attr) []schema.Attr {
+	dev := make([]schema.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		switch a := a.(type) {
+		case *schema.Grant:
+		case *postgres.Policy:
+			p := *a
+			p.To = nil
+			dev = append(dev, &p)
+		default:
+			dev = append(dev, a)
+		}
+	}
+	return dev
+}
+
+// copyAccess copies the roles and grants of the desired schema and its tables, and
+// the roles of the table policies, to the schema that was inspected from the dev database.
+func copyAccess(desired, dev *schema.Schema) {
+	for _, a := range desired.Attrs {
+		switch a.(type) {
+		case *schema.Role, *schema.Grant:
+			dev.Attrs = append(dev.Attrs, a)
+		}
+	}
+	for _, t := range desired.Tables {
+		dt, ok := dev.Table(t.Name)
+		if !ok {
+			continue
+		}
+		for _, a := range dt.Attrs {
+			if p, ok := a.(*postgres.Policy); ok {
+				p.To = policyRoles(t, p.Name)
+			}
+		}
+		for _, a := range t.Attrs {
+			g, ok := a.(*schema.Grant)
+			if !ok {
+				continue
+			}
+			ng := &schema.Grant{Grantee: g.Grantee, Privileges: g.Privileges, GrantOption: g.GrantOption}
+			for _, c := range g.Columns {
+				if dc, ok := dt.Column(c.Name); ok {
+					ng.Columns = append(ng.Columns, dc)
+				}
+			}
+			dt.Attrs = append(dt.Attrs, ng)
+		}
+	}
+}
+
+// policyRoles returns the roles of the named policy of the table.
+func policyRoles(t *schema.Table, name string) []string {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*postgres.Policy); ok && p.Name == name {
+			return p.To
+		}
+	}
+	return nil
+}
+
+// devExtensions returns the changes for installing the extensions
+// of the desired schema that are missing in the dev database.
+func devExtensions(dev, desired *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, e := range missingExtensions(dev, desired) {
+		changes = append(changes, &schema.AddAttr{A: e})
+	}
+	return changes
+}
+
+// missingExtensions returns the extensions of s2 that are missing in s1.
+func missingExtensions(s1, s2 *schema.Schema) []*postgres.Extension {
+	installed := make(map[string]bool)
+	for _, a := range s1.Attrs {
+		if e, ok := a.(*postgres.Extension); ok {
+			installed[e.Name] = true
+		}
+	}
+	var exts []*postgres.Extension
+	for _, a := range s2.Attrs {
+		if e, ok := a.(*postgres.Extension); ok && !installed[e.Name] {
+			exts = append(exts, e)
+		}
+	}
+	return exts
+}
+
+// exec executes the SQL files in the given paths on the dev database.
+func (u *devUnmarshal) exec(ctx context.Context, d *Driver, paths []string) error {
+	files, err := sqlFiles(paths)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(b)) == "" {
+			continue
+		}
+		if _, err := d.interceptor.ExecContext(ctx, string(b)); err != nil {
+			return fmt.Errorf("executing %q on dev database: %w", f, err)
+		}
+	}
+	return nil
+}
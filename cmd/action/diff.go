@@ -3,6 +3,7 @@ package action
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"ariga.io/atlas/sql/schema"
 )
@@ -15,6 +16,9 @@ type changeDesc struct {
 func changeDescriptor(ctx context.Context, c schema.Change, d *Driver) (*changeDesc, error) {
 	desc := &changeDesc{}
 	switch c := c.(type) {
+	case *schema.ModifySchema:
+		desc.typ = "Modify Schema"
+		desc.subject = c.S.Name
 	case *schema.AddTable:
 		desc.typ = "Add Table"
 		desc.subject = c.T.Name
@@ -67,3 +71,31 @@ func changeDescriptor(ctx context.Context, c schema.Change, d *Driver) (*changeD
 	desc.queries = d.interceptor.history
 	return desc, nil
 }
+
+// planSQL returns the statements for creating the given schema and its tables in the
+// database of the driver, without executing them. The database is used only for planning.
+func planSQL(ctx context.Context, d *Driver, s *schema.Schema) ([]string, error) {
+	// The statements are planned for an empty database, and therefore, objects
+	// that exist in the inspected database (e.g. types) are created as well.
+	changes := []schema.Change{&schema.AddSchema{S: s, Extra: []schema.Clause{&schema.IfNotExists{}, &schema.Empty{}}}}
+	for _, t := range s.Tables {
+		changes = append(changes, &schema.AddTable{T: t})
+	}
+	d.interceptor.on()
+	defer d.interceptor.clear()
+	defer d.interceptor.off()
+	if err := d.Exec(ctx, changes); err != nil {
+		return nil, fmt.Errorf("atlas: failed getting planned sql: %w", err)
+	}
+	return d.interceptor.history, nil
+}
+
+// sqlScript joins the given statements into an SQL script.
+func sqlScript(stmts []string) string {
+	var b strings.Builder
+	for _, s := range stmts {
+		b.WriteString(s)
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
@@ -96,3 +96,55 @@ func mockDriver(t *testing.T) *Driver {
 		interceptor: i,
 	}
 }
+
+func TestPlanSQL(t *testing.T) {
+	var s schema.Schema
+	err := mysql.UnmarshalSpec([]byte(`
+schema "test" {
+}
+table "users" {
+	schema = schema.test
+	column "id" {
+		type = "int"
+	}
+	column "group_id" {
+		type = "int"
+	}
+	primary_key {
+		columns = [table.users.column.id]
+	}
+	foreign_key "users_group" {
+		columns     = [table.users.column.group_id]
+		ref_columns = [table.groups.column.id]
+	}
+}
+table "groups" {
+	schema = schema.test
+	column "id" {
+		type = "int"
+	}
+	column "owner_id" {
+		type = "int"
+	}
+	primary_key {
+		columns = [table.groups.column.id]
+	}
+	foreign_key "groups_owner" {
+		columns     = [table.groups.column.owner_id]
+		ref_columns = [table.users.column.id]
+	}
+}
+`), schemahcl.Unmarshal, &s)
+	require.NoError(t, err)
+	stmts, err := planSQL(context.Background(), mockDriver(t), &s)
+	require.NoError(t, err)
+	// Circular references are detached from the tables and added after they are created.
+	require.Equal(t, []string{
+		"CREATE DATABASE IF NOT EXISTS `test`",
+		"CREATE TABLE `test`.`users` (`id` int NOT NULL, `group_id` int NOT NULL, PRIMARY KEY (`id`))",
+		"CREATE TABLE `test`.`groups` (`id` int NOT NULL, `owner_id` int NOT NULL, PRIMARY KEY (`id`))",
+		"ALTER TABLE `test`.`users` ADD CONSTRAINT `users_group` FOREIGN KEY (`group_id`) REFERENCES `test`.`groups` (`id`)",
+		"ALTER TABLE `test`.`groups` ADD CONSTRAINT `groups_owner` FOREIGN KEY (`owner_id`) REFERENCES `test`.`users` (`id`)",
+	}, stmts)
+	require.Equal(t, "CREATE DATABASE IF NOT EXISTS `test`;\n", sqlScript(stmts[:1]))
+}
@@ -0,0 +1,96 @@
+package action
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"ariga.io/atlas/schema/schemaspec/schemahcl"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// FmtFlags are the flags used in Fmt command.
+	FmtFlags struct {
+		Check  bool
+		Sort   bool
+		Driver string
+	}
+	// FmtCmd represents the fmt command.
+	FmtCmd = &cobra.Command{
+		Use:   "fmt [path ...]",
+		Short: "Format Atlas HCL files",
+		Long: `Rewrites the Atlas HCL files in the given paths (files or directories) in their canonical form,
+and prints the names of the files that were changed. If no path is given, the current directory is used.`,
+		Run: CmdFmtRun,
+		Example: `
+atlas schema fmt
+atlas schema fmt atlas.hcl schema/
+atlas schema fmt --driver mysql --sort schema/
+atlas schema fmt --check schema/`,
+	}
+)
+
+func init() {
+	schemaCmd.AddCommand(FmtCmd)
+	FmtCmd.Flags().BoolVar(&FmtFlags.Check, "check", false, "fail if any of the files is not formatted, instead of rewriting it")
+	FmtCmd.Flags().BoolVar(&FmtFlags.Sort, "sort", false, "sort blocks by their type and name")
+	FmtCmd.Flags().StringVar(&FmtFlags.Driver, "driver", "", "[mysql|postgres|sqlite] normalize the column types of the given driver")
+}
+
+// CmdFmtRun is the command used when running CLI.
+func CmdFmtRun(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	types, ok := specTypes[FmtFlags.Driver]
+	if !ok && FmtFlags.Driver != "" {
+		cobra.CheckErr(fmt.Errorf("unknown driver: %q", FmtFlags.Driver))
+	}
+	opts := []schemahcl.Option{schemahcl.WithTypes(types)}
+	if FmtFlags.Sort {
+		opts = append(opts, schemahcl.WithSortedBlocks())
+	}
+	changed, err := fmtRun(schemahcl.New(opts...), args, FmtFlags.Check)
+	cobra.CheckErr(err)
+	for _, f := range changed {
+		schemaCmd.Println(f)
+	}
+	if FmtFlags.Check && len(changed) > 0 {
+		cobra.CheckErr(fmt.Errorf("%d file(s) are not formatted", len(changed)))
+	}
+}
+
+// fmtRun formats the Atlas HCL files in the given paths and returns the names of the files
+// that were changed. In check mode, the files are not rewritten.
+func fmtRun(f interface {
+	Format([]byte, string) ([]byte, error)
+}, paths []string, check bool) ([]string, error) {
+	var changed []string
+	for _, p := range paths {
+		files, err := schemahcl.Files(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			src, err := ioutil.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+			out, err := f.Format(src, name)
+			if err != nil {
+				return nil, err
+			}
+			if string(src) == string(out) {
+				continue
+			}
+			changed = append(changed, name)
+			if check {
+				continue
+			}
+			if err := ioutil.WriteFile(name, out, 0644); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return changed, nil
+}
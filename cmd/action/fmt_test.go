@@ -0,0 +1,55 @@
+package action
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec/schemahcl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFmt(t *testing.T) {
+	dir := t.TempDir()
+	unformatted := filepath.Join(dir, "users.hcl")
+	err := ioutil.WriteFile(unformatted, []byte(`table "users" {
+  column "id" {
+      type = "INT"
+      null = false
+  }
+  schema = schema.public
+}
+`), 0644)
+	require.NoError(t, err)
+	formatted := filepath.Join(dir, "public.hcl")
+	err = ioutil.WriteFile(formatted, []byte("schema \"public\" {\n}\n"), 0644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# Schema"), 0644)
+	require.NoError(t, err)
+
+	f := schemahcl.New(schemahcl.WithTypes(specTypes["mysql"]))
+	changed, err := fmtRun(f, []string{dir}, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{unformatted}, changed)
+	b, err := ioutil.ReadFile(unformatted)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"INT"`, "check mode should not rewrite files")
+
+	changed, err = fmtRun(f, []string{dir}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{unformatted}, changed)
+	b, err = ioutil.ReadFile(unformatted)
+	require.NoError(t, err)
+	require.Equal(t, `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = "int"
+  }
+}
+`, string(b))
+
+	changed, err = fmtRun(f, []string{dir, formatted}, true)
+	require.NoError(t, err)
+	require.Empty(t, changed)
+}
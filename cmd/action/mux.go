@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/schema/schemaspec/schemahcl"
+	"ariga.io/atlas/schema/schemaspec/schemajson"
+	"ariga.io/atlas/schema/schemaspec/schemayaml"
 	"ariga.io/atlas/sql/schema"
 	"github.com/go-sql-driver/mysql"
 )
@@ -34,11 +39,23 @@ type (
 
 	schemaUnmarshal struct {
 		unmarshalSpec func(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{}) error
-		unmarshaler   schemaspec.Unmarshaler
+		evaluator     interface {
+			EvalFiles(paths []string, v interface{}) error
+		}
 	}
 
 	schemaUnmarshaler interface {
-		unmarshal([]byte, interface{}) error
+		unmarshal(paths []string, v interface{}) error
+	}
+
+	// specEvaluator evaluates schema files using the syntax that matches their
+	// extension: JSON (.json), YAML (.yaml, .yml) or Atlas HCL (otherwise).
+	specEvaluator struct {
+		hcl interface {
+			EvalFiles(paths []string, v interface{}) error
+		}
+		// vars holds the input variables. They are supported only by Atlas HCL.
+		vars map[string]string
 	}
 
 	schemaMarshal struct {
@@ -103,6 +120,8 @@ func schemaNameFromDSN(url string) (string, error) {
 		return cfg.DBName, err
 	case "postgres":
 		return "public", nil
+	case "sqlite":
+		return "main", nil
 	default:
 		return "", fmt.Errorf("unknown database type: %q", key)
 	}
@@ -112,8 +131,60 @@ func (p *schemaMarshal) marshal(s *schema.Schema) ([]byte, error) {
 	return p.marshalSpec(s, p.marshaler)
 }
 
-func (p *schemaUnmarshal) unmarshal(b []byte, v interface{}) error {
-	return p.unmarshalSpec(b, p.unmarshaler, v)
+// unmarshal evaluates the schema files in the given paths, and
+// converts them to the driver schema using its spec unmarshaler.
+func (p *schemaUnmarshal) unmarshal(paths []string, v interface{}) error {
+	return p.unmarshalSpec(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		return p.evaluator.EvalFiles(paths, v)
+	}), v)
+}
+
+// newSpecEvaluator returns a specEvaluator that evaluates Atlas HCL files with
+// the given input variables. Schemas and tables can be named by expressions
+// using their "name" attribute.
+func newSpecEvaluator(vars map[string]string) *specEvaluator {
+	return &specEvaluator{
+		hcl: schemahcl.New(
+			schemahcl.WithInputValues(vars),
+			schemahcl.WithComputedNames("schema", "table"),
+		),
+		vars: vars,
+	}
+}
+
+// EvalFiles evaluates the schema files in the given paths. Atlas HCL documents can be
+// split into multiple files and directories, while JSON and YAML documents are read
+// from a single file. Mixing syntaxes in one schema is not supported.
+func (e *specEvaluator) EvalFiles(paths []string, v interface{}) error {
+	var (
+		unmarshal schemaspec.Unmarshaler
+		ext       string
+	)
+	for _, p := range paths {
+		switch pe := strings.ToLower(filepath.Ext(p)); pe {
+		case ".json", ".yaml", ".yml":
+			if len(paths) > 1 {
+				return fmt.Errorf("schema file %q cannot be mixed with other files, %s documents are read from a single file", p, strings.TrimPrefix(pe, "."))
+			}
+			ext = pe
+		}
+	}
+	switch ext {
+	case ".json":
+		unmarshal = schemajson.Unmarshal
+	case ".yaml", ".yml":
+		unmarshal = schemayaml.Unmarshal
+	default:
+		return e.hcl.EvalFiles(paths, v)
+	}
+	if len(e.vars) > 0 {
+		return fmt.Errorf("input variables are not supported by %s documents, found in %q", strings.TrimPrefix(ext, "."), paths[0])
+	}
+	data, err := ioutil.ReadFile(paths[0])
+	if err != nil {
+		return err
+	}
+	return unmarshal.UnmarshalSpec(data, v)
 }
 
 // interceptor is an ExecQuerier that when activated can intercept and store queries initiated by Exec
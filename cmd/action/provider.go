@@ -3,13 +3,43 @@ package action
 import (
 	"database/sql"
 
+	"ariga.io/atlas/schema/schemaspec"
 	"ariga.io/atlas/sql/mysql"
 	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/sqlite"
+)
+
+var (
+	// specTypes holds the column types of the supported drivers, keyed by their names.
+	specTypes = map[string][]*schemaspec.TypeSpec{
+		"mysql":    mysql.TypeRegistry.Specs(),
+		"postgres": postgres.TypeRegistry.Specs(),
+		"sqlite":   sqlite.TypeRegistry.Specs(),
+	}
+	// specValidators holds the offline schema validators of the supported drivers, keyed by their names.
+	specValidators = map[string]func([]byte, schemaspec.Unmarshaler) error{
+		"mysql":    mysql.ValidateSpec,
+		"postgres": postgres.ValidateSpec,
+		"sqlite":   sqlite.ValidateSpec,
+	}
+	// specMarshalers holds the offline schema marshalers of the supported dialects, keyed by their names.
+	specMarshalers = map[string]func(interface{}, schemaspec.Marshaler) ([]byte, error){
+		"mysql":    mysql.MarshalSpec,
+		"postgres": postgres.MarshalSpec,
+		"sqlite":   sqlite.MarshalSpec,
+	}
+	// specUnmarshalers holds the offline schema unmarshalers of the supported dialects, keyed by their names.
+	specUnmarshalers = map[string]func([]byte, schemaspec.Unmarshaler, interface{}) error{
+		"mysql":    mysql.UnmarshalSpec,
+		"postgres": postgres.UnmarshalSpec,
+		"sqlite":   sqlite.UnmarshalSpec,
+	}
 )
 
 func init() {
 	defaultMux.RegisterProvider("mysql", mysqlProvider)
 	defaultMux.RegisterProvider("postgres", postgresProvider)
+	defaultMux.RegisterProvider("sqlite", sqliteProvider)
 }
 
 func mysqlProvider(dsn string) (*Driver, error) {
@@ -47,3 +77,21 @@ func postgresProvider(dsn string) (*Driver, error) {
 		UnmarshalSpec: postgres.UnmarshalSpec,
 	}, nil
 }
+
+func sqliteProvider(dsn string) (*Driver, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	i := &interceptor{ExecQuerier: db}
+	drv, err := sqlite.Open(i)
+	if err != nil {
+		return nil, err
+	}
+	return &Driver{
+		driver:        drv,
+		interceptor:   i,
+		MarshalSpec:   sqlite.MarshalSpec,
+		UnmarshalSpec: sqlite.UnmarshalSpec,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// ValidateFlags are the flags used in Validate command.
+	ValidateFlags struct {
+		Driver string
+		Files  []string
+		Vars   map[string]string
+	}
+	// ValidateCmd represents the validate command.
+	ValidateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate an atlas schema without a database connection",
+		Long: `Validates the schema files (Atlas HCL, JSON or YAML) without connecting to a database, and reports all
+errors that were found in them, such as references to missing tables or columns, duplicate names and unknown types.`,
+		Run: CmdValidateRun,
+		Example: `
+atlas schema validate --driver mysql -f atlas.hcl
+atlas schema validate --driver postgres -f schema/ --var tenant=acme
+atlas schema validate --driver mysql -f schema.json`,
+	}
+)
+
+func init() {
+	schemaCmd.AddCommand(ValidateCmd)
+	ValidateCmd.Flags().StringVar(&ValidateFlags.Driver, "driver", "", "[mysql|postgres|sqlite] the driver of the schema")
+	ValidateCmd.Flags().StringSliceVarP(&ValidateFlags.Files, "file", "f", nil, "[/path/to/file] files or directories containing the schema")
+	ValidateCmd.Flags().StringToStringVar(&ValidateFlags.Vars, "var", nil, "[key=value] input variables for the schema file")
+	cobra.CheckErr(ValidateCmd.MarkFlagRequired("driver"))
+	cobra.CheckErr(ValidateCmd.MarkFlagRequired("file"))
+}
+
+// CmdValidateRun is the command used when running CLI.
+func CmdValidateRun(cmd *cobra.Command, args []string) {
+	validate, ok := specValidators[ValidateFlags.Driver]
+	if !ok {
+		cobra.CheckErr(fmt.Errorf("unknown driver: %q", ValidateFlags.Driver))
+	}
+	evaluator := newSpecEvaluator(ValidateFlags.Vars)
+	errs := validateRun(validate, evaluator, ValidateFlags.Files)
+	if len(errs) == 0 {
+		schemaCmd.Println("Schema is valid")
+		return
+	}
+	for _, err := range errs {
+		schemaCmd.PrintErrln(err)
+	}
+	cobra.CheckErr(fmt.Errorf("found %d error(s) in schema", len(errs)))
+}
+
+// validateRun validates the schema files in the given paths, and returns the list of errors
+// that were found in them. Evaluation diagnostics are returned as separate errors.
+func validateRun(validate func([]byte, schemaspec.Unmarshaler) error, evaluator interface {
+	EvalFiles(paths []string, v interface{}) error
+}, paths []string) []string {
+	err := validate(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		return evaluator.EvalFiles(paths, v)
+	}))
+	if err == nil {
+		return nil
+	}
+	var diags hcl.Diagnostics
+	if !errors.As(err, &diags) {
+		return strings.Split(err.Error(), "\n")
+	}
+	errs := make([]string, 0, len(diags))
+	for _, d := range diags {
+		errs = append(errs, d.Error())
+	}
+	return errs
+}
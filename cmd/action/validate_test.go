@@ -0,0 +1,99 @@
+package action
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec/schemahcl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "schema.hcl"), []byte(`schema "public" {}
+table "users" {
+  schema = schema.public
+  column "id" {
+    type = "int"
+  }
+  column "age" {
+    type = "unknown"
+  }
+  index "name" {
+    columns = [table.users.column.name]
+  }
+  foreign_key "group" {
+    columns     = [table.users.column.id]
+    ref_columns = [table.groups.column.id]
+  }
+}
+`), 0644)
+	require.NoError(t, err)
+	errs := validateRun(specValidators["mysql"], schemahcl.New(), []string{dir})
+	// Evaluation diagnostics do not stop the validation of the document.
+	require.Len(t, errs, 4)
+	require.Contains(t, errs[0], "schema.hcl:11,34-39: Missing map element")
+	require.Contains(t, errs[1], "schema.hcl:15,25-32: Missing map element")
+	require.Contains(t, errs[2], `schema.hcl:8:12: mysql: unknown type "unknown" for column users.age`)
+	require.Contains(t, errs[3], `schema.hcl:13:3: missing reference (parent) columns for foreign key "group"`)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "schema.hcl"), []byte(`schema "public" {}
+table "users" {
+  schema = schema.public
+  column "id" {
+    type = "int"
+    null = true
+  }
+  primary_key {
+    columns = [table.users.column.id]
+  }
+}
+`), 0644)
+	require.NoError(t, err)
+	errs = validateRun(specValidators["mysql"], schemahcl.New(), []string{dir})
+	require.Equal(t, []string{filepath.Join(dir, "schema.hcl") + `:9:15: primary key of table "users" contains the nullable column "id"`}, errs)
+	errs = validateRun(specValidators["sqlite"], schemahcl.New(), []string{dir})
+	require.Equal(t, []string{filepath.Join(dir, "schema.hcl") + `:9:15: primary key of table "users" contains the nullable column "id"`}, errs)
+}
+
+func TestValidate_Syntaxes(t *testing.T) {
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{
+  "schema": [{"name": "public"}],
+  "table": [
+    {
+      "name": "users",
+      "schema": {"$ref": "$schema.public"},
+      "column": [{"name": "id", "type": "int"}],
+      "primary_key": [{"columns": [{"$ref": "table.users.column.id"}]}]
+    }
+  ]
+}`), 0644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "schema.yml"), []byte(`schema:
+  - name: public
+table:
+  - name: users
+    schema:
+      $ref: $schema.public
+    column:
+      - name: id
+        type: int
+    index:
+      - name: name
+        columns:
+          - $ref: $table.users.$column.name
+`), 0644)
+	require.NoError(t, err)
+	e := newSpecEvaluator(nil)
+	require.Empty(t, validateRun(specValidators["mysql"], e, []string{filepath.Join(dir, "schema.json")}))
+	errs := validateRun(specValidators["mysql"], e, []string{filepath.Join(dir, "schema.yml")})
+	require.Equal(t, []string{`index "name" of table "users" references unknown column "name"`}, errs)
+	errs = validateRun(specValidators["mysql"], e, []string{filepath.Join(dir, "schema.json"), filepath.Join(dir, "schema.yml")})
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0], "cannot be mixed with other files")
+	errs = validateRun(specValidators["mysql"], newSpecEvaluator(map[string]string{"tenant": "a"}), []string{filepath.Join(dir, "schema.json")})
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0], `input variables are not supported by json documents`)
+}
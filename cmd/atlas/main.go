@@ -6,6 +6,7 @@ import (
 	"ariga.io/atlas/cmd/action"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 )
 
@@ -19,4 +19,8 @@ require (
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 )
 
-require github.com/go-openapi/inflect v0.19.0
+require (
+	github.com/go-openapi/inflect v0.19.0
+	github.com/mattn/go-sqlite3 v1.14.9
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+)
@@ -507,6 +507,7 @@ func TestMySQL_CLI(t *testing.T) {
 			}
 			table "users" {
 				schema = schema.test
+				engine = "InnoDB"
 				column "id" {
 					type = "int"
 				}
@@ -589,6 +590,7 @@ create table atlas_types_sanity
 				Attrs: []schema.Attr{
 					&schema.Charset{V: "latin1"},
 					&schema.Collation{V: "latin1_swedish_ci"},
+					&mysql.Engine{V: "InnoDB"},
 				},
 				Schema: realm.Schemas[0],
 				Columns: []*schema.Column{
@@ -868,12 +870,14 @@ create table atlas_types_sanity
 						return []schema.Attr{
 							&schema.Charset{V: "latin1"},
 							&schema.Collation{V: "latin1_swedish_ci"},
+							&mysql.Engine{V: "InnoDB"},
 							&mysql.Check{Name: "tJSON", Clause: "json_valid(`tJSON`)", Enforced: true},
 						}
 					}
 					return []schema.Attr{
 						&schema.Charset{V: "latin1"},
 						&schema.Collation{V: "latin1_swedish_ci"},
+						&mysql.Engine{V: "InnoDB"},
 					}
 				}(),
 				Schema: realm.Schemas[0],
@@ -259,6 +259,36 @@ func TestPostgres_Enums(t *testing.T) {
 		err = t.drv.Exec(ctx, []schema.Change{&schema.ModifyTable{T: usersT, Changes: changes}})
 		require.NoError(t, err, "append multiple values to existing enum")
 		ensureNoChange(t, usersT)
+
+		// Rename a value of an existing enum.
+		e = usersT.Columns[1].Type.Type.(*schema.EnumType)
+		e.Values[0] = "sun"
+		changes = t.diff(t.loadUsers(), usersT)
+		require.Len(t, changes, 1)
+		err = t.drv.Exec(ctx, []schema.Change{&schema.ModifyTable{T: usersT, Changes: changes}})
+		require.NoError(t, err, "rename a value of existing enum")
+		ensureNoChange(t, usersT)
+
+		// Remove a value from an existing enum.
+		e = usersT.Columns[1].Type.Type.(*schema.EnumType)
+		e.Values = e.Values[:len(e.Values)-1]
+		changes = t.diff(t.loadUsers(), usersT)
+		require.Len(t, changes, 1)
+		err = t.drv.Exec(ctx, []schema.Change{&schema.ModifyTable{T: usersT, Changes: changes}})
+		require.NoError(t, err, "remove a value from existing enum")
+		ensureNoChange(t, usersT)
+
+		// Drop an enum column, and its unused type.
+		usersT.Columns = usersT.Columns[:1]
+		changes = t.diff(t.loadUsers(), usersT)
+		require.Len(t, changes, 1)
+		err = t.drv.Exec(ctx, []schema.Change{&schema.ModifyTable{T: usersT, Changes: changes}})
+		require.NoError(t, err, "drop an enum column")
+		ensureNoChange(t, usersT)
+		rows, err := t.db.QueryContext(ctx, "SELECT 1 FROM pg_type WHERE typname = 'day'")
+		require.NoError(t, err)
+		require.False(t, rows.Next(), "unused enum type was dropped")
+		require.NoError(t, rows.Close())
 	})
 }
 
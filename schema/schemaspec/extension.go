@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"unsafe"
+
+	"github.com/hashicorp/hcl/v2"
 )
 
 // Remainer is the interface that is implemented by types that can store
@@ -35,6 +39,78 @@ func (d *DefaultExtension) Attr(name string) (*Attr, bool) {
 	return d.Extra.Attr(name)
 }
 
+// Range returns the position of the resource in the source document, if it is known.
+func (d *DefaultExtension) Range() *hcl.Range {
+	if p, ok := positions.get(&d.Extra); ok {
+		return p.r
+	}
+	return nil
+}
+
+// AttrRange returns the position of the attribute with the given name in the source
+// document. If it is unknown, the position of the resource is returned.
+func (d *DefaultExtension) AttrRange(name string) *hcl.Range {
+	p, ok := positions.get(&d.Extra)
+	if !ok {
+		return nil
+	}
+	if r, ok := p.attrs[name]; ok {
+		return r
+	}
+	return p.r
+}
+
+type (
+	// posTable is a side table that holds the positions of the decoded resources in
+	// their source documents. Positions are kept outside the decoded values in order
+	// to not affect their comparison, and are keyed by the address of their Remainer
+	// resource. Entries are removed when the decoded values are garbage collected.
+	posTable struct {
+		sync.RWMutex
+		m map[uintptr]*resourcePos
+	}
+	// resourcePos holds the position of a resource and its attributes.
+	resourcePos struct {
+		r     *hcl.Range
+		attrs map[string]*hcl.Range
+	}
+)
+
+var positions = &posTable{m: make(map[uintptr]*resourcePos)}
+
+// track records the positions of r for the value v, which was allocated by As.
+func (t *posTable) track(v interface{}, r *Resource) {
+	rem, ok := v.(Remainer)
+	if !ok || r.Range == nil {
+		return
+	}
+	p := &resourcePos{r: r.Range, attrs: make(map[string]*hcl.Range)}
+	for _, a := range r.Attrs {
+		if a.Range != nil {
+			p.attrs[a.K] = a.Range
+		}
+	}
+	t.Lock()
+	t.m[t.key(rem.Remain())] = p
+	t.Unlock()
+	runtime.SetFinalizer(v, func(v interface{}) {
+		t.Lock()
+		delete(t.m, t.key(v.(Remainer).Remain()))
+		t.Unlock()
+	})
+}
+
+func (t *posTable) get(r *Resource) (*resourcePos, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	p, ok := t.m[t.key(r)]
+	return p, ok
+}
+
+func (*posTable) key(r *Resource) uintptr {
+	return uintptr(unsafe.Pointer(r))
+}
+
 type registry map[string]interface{}
 
 var (
@@ -82,6 +158,24 @@ func Register(name string, ext interface{}) {
 	extensions[name] = ext
 }
 
+// ExtensionKeys returns the keys of the attributes and children of the extension
+// that was registered with the given name, in the order of their declaration.
+func ExtensionKeys(name string) ([]string, bool) {
+	extensionsMu.RLock()
+	ext, ok := extensions[name]
+	extensionsMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	var keys []string
+	for _, f := range specFields(ext) {
+		if k := strings.Split(f.tag, ",")[0]; k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys, true
+}
+
 // As reads the attributes and children resources of the resource into the target struct.
 func (r *Resource) As(target interface{}) error {
 	if err := validateStructPtr(target); err != nil {
@@ -105,7 +199,8 @@ func (r *Resource) As(target interface{}) error {
 		case hasAttr(r, ft.tag):
 			attr, _ := r.Attr(ft.tag)
 			if err := setField(field, attr); err != nil {
-				return err
+				// Prefer the attribute position, if it is known.
+				return WithPos(r.Range, WithPos(attr.Range, err))
 			}
 			delete(existingAttrs, attr.K)
 		case ft.isInterfaceSlice():
@@ -124,8 +219,9 @@ func (r *Resource) As(target interface{}) error {
 				n := reflect.New(reflect.TypeOf(typ).Elem())
 				ext := n.Interface()
 				if err := c.As(ext); err != nil {
-					return err
+					return WithPos(c.Range, err)
 				}
+				positions.track(ext, c)
 				slc = reflect.Append(slc, reflect.ValueOf(ext))
 			}
 			field.Set(slc)
@@ -142,18 +238,19 @@ func (r *Resource) As(target interface{}) error {
 				continue
 			}
 			if len(children) > 1 {
-				return fmt.Errorf("more than one blocks implement %q", ft.Type)
+				return WithPos(children[1].Range, fmt.Errorf("more than one blocks implement %q", ft.Type))
 			}
 			c := children[0]
 			typ, ok := extensions[c.Type]
 			if !ok {
-				return fmt.Errorf("extension %q not registered", c.Type)
+				return WithPos(c.Range, fmt.Errorf("extension %q not registered", c.Type))
 			}
 			n := reflect.New(reflect.TypeOf(typ).Elem())
 			ext := n.Interface()
 			if err := c.As(ext); err != nil {
-				return err
+				return WithPos(c.Range, err)
 			}
+			positions.track(ext, c)
 			field.Set(n)
 		case isResourceSlice(field.Type()):
 			if err := setChildSlice(field, childrenOfType(r, ft.tag)); err != nil {
@@ -169,8 +266,9 @@ func (r *Resource) As(target interface{}) error {
 			n := reflect.New(field.Type().Elem())
 			ext := n.Interface()
 			if err := res.As(ext); err != nil {
-				return err
+				return WithPos(res.Range, err)
 			}
+			positions.track(ext, res)
 			field.Set(n)
 			delete(existingChildren, ft.tag)
 		}
@@ -185,7 +283,8 @@ func (r *Resource) As(target interface{}) error {
 		if !ok {
 			return fmt.Errorf("schemaspec: expected attr %q to exist", attrName)
 		}
-		extras.SetAttr(attr)
+		// Positions are tracked separately.
+		extras.SetAttr(&Attr{K: attr.K, V: attr.V})
 	}
 	for childType := range existingChildren {
 		children := childrenOfType(r, childType)
@@ -226,8 +325,9 @@ func setChildSlice(field reflect.Value, children []*Resource) error {
 		n := reflect.New(typ.Elem())
 		ext := n.Interface()
 		if err := c.As(ext); err != nil {
-			return err
+			return WithPos(c.Range, err)
 		}
+		positions.track(ext, c)
 		slc = reflect.Append(slc, reflect.ValueOf(ext))
 	}
 	field.Set(slc)
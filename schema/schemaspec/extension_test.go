@@ -68,6 +68,9 @@ func TestExtension(t *testing.T) {
 	eb, err := attr.Bool()
 	require.NoError(t, err)
 	require.True(t, eb)
+	keys, ok := schemaspec.ExtensionKeys("owner")
+	require.True(t, ok)
+	require.Equal(t, []string{"first_name", "born", "active", "lit"}, keys)
 
 	scan := &schemaspec.Resource{}
 	err = scan.Scan(&owner)
@@ -0,0 +1,349 @@
+// Package schematree maps *schemaspec.Resource trees to and from generic trees of objects,
+// lists and scalars, that are shared by the data-serialization syntaxes of the Atlas DDL
+// (i.e. JSON and YAML).
+//
+// A resource is represented as an object. Its name is stored under the "name" key, its
+// attributes are stored under their keys, and its children are stored as lists of objects
+// under their type. References and typed values are stored as objects with a "$ref" or a
+// "$type" key. For example:
+//
+//	{
+//	  "table": [
+//	    {
+//	      "name": "users",
+//	      "schema": {"$ref": "$schema.public"},
+//	      "column": [
+//	        {"name": "id", "type": "int"}
+//	      ]
+//	    }
+//	  ]
+//	}
+package schematree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/schema/schemaspec"
+)
+
+const (
+	// KeyName is the key that holds the name of a resource.
+	KeyName = "name"
+	// KeyRef is the key of objects that represent a schemaspec.Ref.
+	KeyRef = "$ref"
+	// KeyType is the key of objects that represent a schemaspec.Type.
+	KeyType = "$type"
+)
+
+// Object is an object with ordered keys. The values of an object are one
+// of: string, bool, json.Number, []interface{} or *Object.
+type Object struct {
+	Keys   []string
+	Values []interface{}
+}
+
+// Set sets the value of the given key.
+func (o *Object) Set(k string, v interface{}) {
+	for i := range o.Keys {
+		if o.Keys[i] == k {
+			o.Values[i] = v
+			return
+		}
+	}
+	o.Keys = append(o.Keys, k)
+	o.Values = append(o.Values, v)
+}
+
+// Get returns the value of the given key.
+func (o *Object) Get(k string) (interface{}, bool) {
+	for i := range o.Keys {
+		if o.Keys[i] == k {
+			return o.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON implements json.Marshaler and writes the object keys in their order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.Keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		vb, err := json.Marshal(o.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Encode encodes the given resource into an object.
+func Encode(r *schemaspec.Resource) (*Object, error) {
+	o := &Object{}
+	if r.Name != "" {
+		o.Set(KeyName, r.Name)
+	}
+	for _, a := range r.Attrs {
+		v, err := encodeValue(a.V)
+		if err != nil {
+			return nil, fmt.Errorf("schematree: failed encoding attribute %q: %w", a.K, err)
+		}
+		o.Set(a.K, v)
+	}
+	types := make(map[string][]interface{})
+	for _, c := range r.Children {
+		if _, ok := o.Get(c.Type); ok && types[c.Type] == nil {
+			return nil, fmt.Errorf("schematree: children of type %q conflict with an attribute of resource %q", c.Type, r.Name)
+		}
+		v, err := Encode(c)
+		if err != nil {
+			return nil, err
+		}
+		types[c.Type] = append(types[c.Type], v)
+		o.Set(c.Type, types[c.Type])
+	}
+	return o, nil
+}
+
+func encodeValue(v schemaspec.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case *schemaspec.LiteralValue:
+		return encodeLiteral(v.V), nil
+	case *schemaspec.ListValue:
+		l := make([]interface{}, 0, len(v.V))
+		for _, e := range v.V {
+			ev, err := encodeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, ev)
+		}
+		return l, nil
+	case *schemaspec.Ref:
+		return &Object{Keys: []string{KeyRef}, Values: []interface{}{v.V}}, nil
+	case *schemaspec.Type:
+		o := &Object{Keys: []string{KeyType}, Values: []interface{}{v.T}}
+		for _, a := range v.Attrs {
+			av, err := encodeValue(a.V)
+			if err != nil {
+				return nil, err
+			}
+			o.Set(a.K, av)
+		}
+		return o, nil
+	default:
+		return nil, fmt.Errorf("unexpected value type %T", v)
+	}
+}
+
+// encodeLiteral encodes the given literal, as it was read from the
+// Atlas HCL syntax, into a string, a number or a boolean value.
+func encodeLiteral(lit string) interface{} {
+	if s, err := strconv.Unquote(lit); err == nil {
+		return s
+	}
+	if b, err := strconv.ParseBool(lit); err == nil {
+		return b
+	}
+	if _, err := strconv.ParseFloat(lit, 64); err == nil {
+		return json.Number(lit)
+	}
+	return lit
+}
+
+// Decode decodes the given generic object, as it was read by a JSON or YAML decoder, into
+// a resource. Maps that were decoded by encoding/json or by gopkg.in/yaml.v3 are sorted by
+// their keys, as their original order is not kept.
+func Decode(v interface{}) (*schemaspec.Resource, error) {
+	o, err := toObject(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResource(o, "")
+}
+
+func decodeResource(o *Object, typ string) (*schemaspec.Resource, error) {
+	r := &schemaspec.Resource{Type: typ}
+	for i, k := range o.Keys {
+		v := o.Values[i]
+		if k == KeyName {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("schematree: expect %q of %q to be a string, got %T", KeyName, typ, v)
+			}
+			r.Name = s
+			continue
+		}
+		if children, ok := blocks(v); ok {
+			for _, c := range children {
+				child, err := decodeResource(c, k)
+				if err != nil {
+					return nil, err
+				}
+				r.Children = append(r.Children, child)
+			}
+			continue
+		}
+		av, err := decodeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("schematree: failed decoding attribute %q: %w", k, err)
+		}
+		r.Attrs = append(r.Attrs, &schemaspec.Attr{K: k, V: av})
+	}
+	return r, nil
+}
+
+// blocks reports if the given value represents a list of
+// resources (rather than a list value), and returns them.
+func blocks(v interface{}) ([]*Object, bool) {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return nil, false
+	}
+	objs := make([]*Object, 0, len(l))
+	for _, e := range l {
+		o, err := toObject(e)
+		if err != nil || isValue(o) {
+			return nil, false
+		}
+		objs = append(objs, o)
+	}
+	return objs, true
+}
+
+func decodeValue(v interface{}) (schemaspec.Value, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		l := &schemaspec.ListValue{}
+		for _, e := range v {
+			ev, err := decodeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			l.V = append(l.V, ev)
+		}
+		return l, nil
+	case *Object, map[string]interface{}:
+		o, err := toObject(v)
+		if err != nil {
+			return nil, err
+		}
+		return decodeObject(o)
+	default:
+		lit, err := literal(v)
+		if err != nil {
+			return nil, err
+		}
+		return &schemaspec.LiteralValue{V: lit}, nil
+	}
+}
+
+// decodeObject decodes an object that represents a reference or a typed value.
+func decodeObject(o *Object) (schemaspec.Value, error) {
+	if v, ok := o.Get(KeyRef); ok {
+		s, ok := v.(string)
+		if !ok || len(o.Keys) != 1 {
+			return nil, fmt.Errorf("expect reference object to hold a single %q string", KeyRef)
+		}
+		return &schemaspec.Ref{V: refPath(s)}, nil
+	}
+	v, ok := o.Get(KeyType)
+	if !ok {
+		return nil, fmt.Errorf("expect object value to hold a %q or a %q key", KeyRef, KeyType)
+	}
+	t, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expect %q to be a string, got %T", KeyType, v)
+	}
+	typ := &schemaspec.Type{T: t}
+	for i, k := range o.Keys {
+		if k == KeyType {
+			continue
+		}
+		av, err := decodeValue(o.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		typ.Attrs = append(typ.Attrs, &schemaspec.Attr{K: k, V: av})
+	}
+	return typ, nil
+}
+
+// refPath returns the schemaspec.Ref path of the given reference. References can be written
+// in their canonical form (e.g. "$table.users.$column.id"), or as a list of type and name
+// pairs (e.g. "table.users.column.id").
+func refPath(s string) string {
+	if strings.HasPrefix(s, "$") {
+		return s
+	}
+	parts := strings.Split(s, ".")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = "$" + parts[i]
+	}
+	return strings.Join(parts, ".")
+}
+
+// literal returns the Atlas HCL literal representation of the given scalar.
+func literal(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unexpected value type %T", v)
+	}
+}
+
+func isValue(o *Object) bool {
+	_, isRef := o.Get(KeyRef)
+	_, isType := o.Get(KeyType)
+	return isRef || isType
+}
+
+// toObject converts the given value to an *Object.
+func toObject(v interface{}) (*Object, error) {
+	switch v := v.(type) {
+	case *Object:
+		return v, nil
+	case map[string]interface{}:
+		o := &Object{}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o.Set(k, v[k])
+		}
+		return o, nil
+	default:
+		return nil, fmt.Errorf("schematree: expect object, got %T", v)
+	}
+}
@@ -0,0 +1,99 @@
+package schematree
+
+import (
+	"encoding/json"
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	r := &schemaspec.Resource{
+		Children: []*schemaspec.Resource{
+			{
+				Type: "table",
+				Name: "users",
+				Attrs: []*schemaspec.Attr{
+					{K: "schema", V: &schemaspec.Ref{V: "$schema.public"}},
+					{K: "comment", V: &schemaspec.LiteralValue{V: `"the users table"`}},
+				},
+				Children: []*schemaspec.Resource{
+					{
+						Type: "column",
+						Name: "id",
+						Attrs: []*schemaspec.Attr{
+							{K: "type", V: &schemaspec.Type{T: "varchar", Attrs: []*schemaspec.Attr{{K: "size", V: &schemaspec.LiteralValue{V: "255"}}}}},
+							{K: "null", V: &schemaspec.LiteralValue{V: "false"}},
+						},
+					},
+					{
+						Type: "primary_key",
+						Attrs: []*schemaspec.Attr{
+							{K: "columns", V: &schemaspec.ListValue{V: []schemaspec.Value{&schemaspec.Ref{V: "$table.users.$column.id"}}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	o, err := Encode(r)
+	require.NoError(t, err)
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "table": [
+    {
+      "name": "users",
+      "schema": {"$ref": "$schema.public"},
+      "comment": "the users table",
+      "column": [
+        {"name": "id", "type": {"$type": "varchar", "size": 255}, "null": false}
+      ],
+      "primary_key": [
+        {"columns": [{"$ref": "$table.users.$column.id"}]}
+      ]
+    }
+  ]
+}`, string(b))
+	// Keys are kept in the order of the resource.
+	require.Equal(t, []string{"name", "schema", "comment", "column", "primary_key"}, o.Values[0].([]interface{})[0].(*Object).Keys)
+
+	got, err := Decode(o)
+	require.NoError(t, err)
+	require.Equal(t, r, got)
+}
+
+func TestDecode(t *testing.T) {
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "table": [
+    {
+      "name": "users",
+      "tags": ["a", "b"],
+      "empty": [],
+      "primary_key": [
+        {"columns": [{"$ref": "table.users.column.id"}]}
+      ]
+    }
+  ]
+}`), &doc))
+	r, err := Decode(doc)
+	require.NoError(t, err)
+	require.Len(t, r.Children, 1)
+	users := r.Children[0]
+	require.Equal(t, "users", users.Name)
+	require.Equal(t, []*schemaspec.Attr{
+		{K: "empty", V: &schemaspec.ListValue{}},
+		{K: "tags", V: &schemaspec.ListValue{V: []schemaspec.Value{&schemaspec.LiteralValue{V: `"a"`}, &schemaspec.LiteralValue{V: `"b"`}}}},
+	}, users.Attrs)
+	require.Len(t, users.Children, 1)
+	pk := users.Children[0]
+	require.Equal(t, "primary_key", pk.Type)
+	require.Equal(t, &schemaspec.ListValue{V: []schemaspec.Value{&schemaspec.Ref{V: "$table.users.$column.id"}}}, pk.Attrs[0].V)
+
+	_, err = Decode(map[string]interface{}{"table": []interface{}{map[string]interface{}{"name": 1}}})
+	require.EqualError(t, err, `schematree: expect "name" of "table" to be a string, got int`)
+	_, err = Decode(map[string]interface{}{"schema": map[string]interface{}{"name": "public"}})
+	require.EqualError(t, err, `schematree: failed decoding attribute "schema": expect object value to hold a "$ref" or a "$type" key`)
+}
@@ -3,13 +3,14 @@ package schemahcl
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 
 	"ariga.io/atlas/schema/schemaspec"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // evalCtx constructs an *hcl.EvalContext with the Variables field populated with per
@@ -27,15 +28,7 @@ import (
 //		title = "{greeting.hebrew.word}, world!"
 //	}
 //
-func evalCtx(ctx *hcl.EvalContext, f *hcl.File) (*hcl.EvalContext, error) {
-	c := &container{}
-	if diag := gohcl.DecodeBody(f.Body, &hcl.EvalContext{}, c); diag.HasErrors() {
-		return nil, diag
-	}
-	b, ok := c.Body.(*hclsyntax.Body)
-	if !ok {
-		return nil, fmt.Errorf("schemahcl: expected an hcl body")
-	}
+func evalCtx(ctx *hcl.EvalContext, b *hclsyntax.Body, input map[string]string) (*hcl.EvalContext, error) {
 	defs := defRegistry(b)
 	vars, err := blockVars(b, "", defs)
 	if err != nil {
@@ -44,9 +37,104 @@ func evalCtx(ctx *hcl.EvalContext, f *hcl.File) (*hcl.EvalContext, error) {
 	for k, v := range vars {
 		ctx.Variables[k] = v
 	}
+	if err := inputVars(ctx, b, input); err != nil {
+		return nil, err
+	}
 	return ctx, nil
 }
 
+// varBlock is the block type for declaring input variables.
+const varBlock = "variable"
+
+// inputVars evaluates the input variables declared in the body, and
+// registers them in the context under the "var" namespace. Values are
+// taken from the given input, or from the variable defaults.
+func inputVars(ctx *hcl.EvalContext, b *hclsyntax.Body, input map[string]string) error {
+	var (
+		diags hcl.Diagnostics
+		vars  = make(map[string]cty.Value)
+	)
+	for _, blk := range blocksOfType(b.Blocks, varBlock) {
+		name := blockName(blk)
+		if name == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing variable name",
+				Detail:   "Variable blocks must have a name label.",
+				Subject:  blk.DefRange().Ptr(),
+			})
+			continue
+		}
+		typ := cty.String
+		if attr, ok := blk.Body.Attributes["type"]; ok {
+			switch k := hcl.ExprAsKeyword(attr.Expr); k {
+			case "string":
+			case "number":
+				typ = cty.Number
+			case "bool":
+				typ = cty.Bool
+			default:
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid variable type",
+					Detail:   fmt.Sprintf("Variable %q must be of type string, number or bool.", name),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+				continue
+			}
+		}
+		v, ok := cty.NilVal, false
+		if x, exists := input[name]; exists {
+			v, ok = cty.StringVal(x), true
+		} else if attr, exists := blk.Body.Attributes["default"]; exists {
+			dv, diag := attr.Expr.Value(nil)
+			if diags = append(diags, diag...); diag.HasErrors() {
+				continue
+			}
+			v, ok = dv, true
+		}
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing input variable",
+				Detail:   fmt.Sprintf("No value was given for variable %q, and it has no default value.", name),
+				Subject:  blk.DefRange().Ptr(),
+			})
+			continue
+		}
+		cv, err := convert.Convert(v, typ)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for input variable",
+				Detail:   fmt.Sprintf("Value of variable %q is not a valid %s: %v.", name, typ.FriendlyName(), err),
+				Subject:  blk.DefRange().Ptr(),
+			})
+			continue
+		}
+		vars[name] = cv
+	}
+	undeclared := make([]string, 0, len(input))
+	for name := range input {
+		if _, ok := vars[name]; !ok {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Undeclared input variable",
+			Detail:   fmt.Sprintf("A value was given for variable %q, but the document does not declare it.", name),
+		})
+	}
+	if diags.HasErrors() {
+		return diags
+	}
+	ctx.Variables["var"] = cty.ObjectVal(vars)
+	return nil
+}
+
 func blockVars(b *hclsyntax.Body, parentAddr string, defs *blockDef) (map[string]cty.Value, error) {
 	vars := make(map[string]cty.Value)
 	for name, def := range defs.children {
@@ -83,12 +171,27 @@ func blockVars(b *hclsyntax.Body, parentAddr string, defs *blockDef) (map[string
 			v[blkName] = cty.ObjectVal(attrs)
 		}
 		if len(v) > 0 {
-			vars[name] = cty.MapVal(v)
+			vars[name] = blocksVal(v)
 		}
 	}
 	return vars, nil
 }
 
+// blocksVal returns the cty value of sibling blocks. Blocks with attributes
+// of different types (e.g. a reference in one block and a literal in another)
+// cannot be held in a map, and are returned as an object instead.
+func blocksVal(v map[string]cty.Value) cty.Value {
+	var t cty.Type
+	for _, bv := range v {
+		if t == cty.NilType {
+			t = bv.Type()
+		} else if !t.Equals(bv.Type()) {
+			return cty.ObjectVal(v)
+		}
+	}
+	return cty.MapVal(v)
+}
+
 func addr(parentAddr, typeName, blkName string) string {
 	var prefixDot string
 	if len(parentAddr) > 0 {
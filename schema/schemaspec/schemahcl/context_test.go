@@ -351,6 +351,115 @@ x = from_ctx
 	require.EqualValues(t, 42, test.X)
 }
 
+func TestInputValues(t *testing.T) {
+	f := `
+variable "tenant" {
+  type = string
+}
+
+variable "replicas" {
+  type    = number
+  default = 1
+}
+
+variable "active" {
+  type    = bool
+  default = false
+}
+
+schema "tenant" {
+  name = var.tenant
+}
+
+table "users" {
+  schema   = schema.tenant
+  comment  = "users of ${var.tenant}"
+  replicas = var.replicas
+  active   = var.active
+}
+`
+	type (
+		Schema struct {
+			Name string `spec:",name"`
+		}
+		Table struct {
+			Name     string          `spec:",name"`
+			Schema   *schemaspec.Ref `spec:"schema"`
+			Comment  string          `spec:"comment"`
+			Replicas int             `spec:"replicas"`
+			Active   bool            `spec:"active"`
+		}
+		Doc struct {
+			Schemas []*Schema `spec:"schema"`
+			Tables  []*Table  `spec:"table"`
+		}
+	)
+	var doc Doc
+	err := New(WithInputValues(map[string]string{"tenant": "acme", "active": "true"}), WithComputedNames("schema")).UnmarshalSpec([]byte(f), &doc)
+	require.NoError(t, err)
+	require.Equal(t, "acme", doc.Schemas[0].Name)
+	require.Equal(t, "$schema.tenant", doc.Tables[0].Schema.V)
+	require.Equal(t, "users of acme", doc.Tables[0].Comment)
+	require.Equal(t, 1, doc.Tables[0].Replicas)
+	require.True(t, doc.Tables[0].Active)
+
+	err = New().UnmarshalSpec([]byte(f), &doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `No value was given for variable "tenant"`)
+
+	err = New(WithInputValues(map[string]string{"tenant": "acme", "replicas": "many"})).UnmarshalSpec([]byte(f), &doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value of variable "replicas" is not a valid number`)
+
+	err = New(WithInputValues(map[string]string{"tenant": "acme", "region": "eu"})).UnmarshalSpec([]byte(f), &doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `A value was given for variable "region", but the document does not declare it`)
+}
+
+func TestComputedNames(t *testing.T) {
+	f := `
+schema "tenant" {
+  name = "acme"
+}
+
+table "users" {
+  name = 1
+}
+
+index "users" {
+  name = "idx"
+}
+`
+	type (
+		Named struct {
+			Name string `spec:",name"`
+		}
+		Index struct {
+			Name    string `spec:",name"`
+			Comment string `spec:"name"`
+		}
+		Doc struct {
+			Schemas []*Named `spec:"schema"`
+			Tables  []*Named `spec:"table"`
+			Indexes []*Index `spec:"index"`
+		}
+	)
+	var doc Doc
+	err := New(WithComputedNames("schema", "table")).UnmarshalSpec([]byte(f), &doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `The name of table "users" must be a string.`)
+	require.Equal(t, "acme", doc.Schemas[0].Name)
+	require.Equal(t, "users", doc.Tables[0].Name)
+	// Resource types that were not configured keep their name attribute.
+	require.Equal(t, "users", doc.Indexes[0].Name)
+	require.Equal(t, "idx", doc.Indexes[0].Comment)
+
+	doc = Doc{}
+	err = New().UnmarshalSpec([]byte(f), &doc)
+	require.NoError(t, err)
+	require.Equal(t, "tenant", doc.Schemas[0].Name)
+}
+
 func TestWithTypes(t *testing.T) {
 	f := `first    = int
 second   = bool
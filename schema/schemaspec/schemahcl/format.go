@@ -0,0 +1,233 @@
+package schemahcl
+
+import (
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/schema/schemaspec"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Format returns the canonical form of the given Atlas HCL document. In the canonical form:
+//
+//   - Attributes are written before the child blocks, ordered by their declaration in the
+//     registered extension of the block (see schemaspec.Register), followed by the rest of
+//     the attributes in alphabetical order.
+//   - The types of columns (type expressions, and string types that can be parsed as such)
+//     are normalized using the types that were configured with the WithTypes option.
+//   - Top-level blocks are separated by a single blank line, and `=` signs are aligned.
+//
+// Blocks keep their original order, unless the WithSortedBlocks option is used. Comments
+// are kept and moved along with the attribute or the block that follows them.
+func (s *state) Format(src []byte, filename string) ([]byte, error) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	s.formatBody(f.Body(), "", true)
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+// bodyItem is an attribute or a block in a body, with the tokens representing it.
+type bodyItem struct {
+	pos    int
+	block  *hclwrite.Block
+	name   string
+	tokens hclwrite.Tokens
+}
+
+// formatBody rewrites the given body of a block of type typ in its canonical form.
+func (s *state) formatBody(body *hclwrite.Body, typ string, top bool) {
+	// Only the types of columns are normalized, as other attributes (e.g. comments
+	// or default values) may hold strings that look like types.
+	if attr := body.GetAttribute("type"); attr != nil && typ == "column" {
+		s.formatType(body, "type", attr)
+	}
+	for _, blk := range body.Blocks() {
+		s.formatBody(blk.Body(), blk.Type(), false)
+	}
+	var (
+		items []*bodyItem
+		all   = body.BuildTokens(nil)
+		pos   = make(map[*hclwrite.Token]int, len(all))
+	)
+	for i, t := range all {
+		pos[t] = i
+	}
+	for name, attr := range body.Attributes() {
+		items = append(items, &bodyItem{name: name, tokens: attr.BuildTokens(nil)})
+	}
+	for _, blk := range body.Blocks() {
+		items = append(items, &bodyItem{block: blk, name: blk.Type(), tokens: blk.BuildTokens(nil)})
+	}
+	if len(items) == 0 {
+		return
+	}
+	for _, it := range items {
+		it.pos = pos[it.tokens[0]]
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].pos < items[j].pos
+	})
+	// Comments that are detached from the item that follows them (i.e. separated
+	// by an empty line) are moved along with it, except for the file header.
+	var last int
+	header := comments(all[:items[0].pos])
+	if top {
+		last = items[0].pos
+	}
+	for _, it := range items {
+		n := it.pos + len(it.tokens)
+		it.tokens = append(comments(all[last:it.pos]), it.tokens...)
+		last = n
+		if !endsWithNewline(it.tokens) {
+			it.tokens = append(it.tokens, newline())
+		}
+	}
+	trailing := comments(all[last:])
+	s.sortItems(items, typ)
+	body.Clear()
+	switch {
+	case !top:
+		body.AppendNewline()
+	case len(header) > 0:
+		body.AppendUnstructuredTokens(header)
+		body.AppendNewline()
+	}
+	for i, it := range items {
+		if top && i > 0 {
+			body.AppendNewline()
+		}
+		body.AppendUnstructuredTokens(it.tokens)
+	}
+	body.AppendUnstructuredTokens(trailing)
+}
+
+// sortItems sorts the items of a body of a block of type typ in their canonical order.
+func (s *state) sortItems(items []*bodyItem, typ string) {
+	keys, _ := schemaspec.ExtensionKeys(typ)
+	rank := make(map[string]int, len(keys))
+	for i, k := range keys {
+		rank[k] = i
+	}
+	// The name attribute overrides the block label and therefore comes first.
+	rank["name"] = -1
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch {
+		case (a.block == nil) != (b.block == nil):
+			return a.block == nil
+		case a.block == nil || s.config.sortBlocks && a.name != b.name:
+			ra, oka := rank[a.name]
+			rb, okb := rank[b.name]
+			switch {
+			case oka && okb:
+				return ra < rb
+			case oka != okb:
+				return oka
+			default:
+				return a.name < b.name
+			}
+		case s.config.sortBlocks:
+			return strings.Join(a.block.Labels(), ".") < strings.Join(b.block.Labels(), ".")
+		default:
+			return false
+		}
+	})
+}
+
+// formatType normalizes the expression of the given attribute if it describes one of the
+// configured types. For example, `VARCHAR(255)` is written as `varchar(255)`.
+func (s *state) formatType(body *hclwrite.Body, name string, attr *hclwrite.Attribute) {
+	src := attr.Expr().BuildTokens(nil).Bytes()
+	expr, diags := hclsyntax.ParseExpression(src, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return
+	}
+	// String types are normalized only if they can be parsed as type expressions.
+	if t, ok := expr.(*hclsyntax.TemplateExpr); ok && t.IsStringLiteral() {
+		v, diags := t.Value(nil)
+		if diags.HasErrors() {
+			return
+		}
+		if typ, ok := s.typeExpr([]byte(v.AsString())); ok {
+			body.SetAttributeRaw(name, hclwrite.TokensForValue(cty.StringVal(typ)))
+		}
+		return
+	}
+	if typ, ok := s.typeExpr(src); ok {
+		body.SetAttributeRaw(name, hclRawTokens(typ))
+	}
+}
+
+// typeExpr returns the canonical form of the given type expression,
+// if it describes a call or a reference to one of the configured types.
+func (s *state) typeExpr(src []byte) (string, bool) {
+	expr, diags := hclsyntax.ParseExpression(src, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", false
+	}
+	var spec *schemaspec.TypeSpec
+	switch e := expr.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		if spec = s.findTypeSpecName(e.Name); spec != nil {
+			e.Name = spec.Name
+		}
+	case *hclsyntax.ScopeTraversalExpr:
+		if len(e.Traversal) == 1 {
+			if spec = s.findTypeSpecName(e.Traversal.RootName()); spec != nil {
+				e.Traversal = hcl.Traversal{hcl.TraverseRoot{Name: spec.Name}}
+			}
+		}
+	}
+	if spec == nil {
+		return "", false
+	}
+	v, diags := expr.Value(s.config.ctx)
+	if diags.HasErrors() || v.Type() != ctyTypeSpec {
+		return "", false
+	}
+	typ, err := hclType(spec, v.EncapsulatedValue().(*schemaspec.Type))
+	if err != nil {
+		return "", false
+	}
+	return typ, true
+}
+
+// findTypeSpecName returns the configured type with the given name, ignoring case.
+func (s *state) findTypeSpecName(name string) *schemaspec.TypeSpec {
+	for _, v := range s.config.types {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func endsWithNewline(tokens hclwrite.Tokens) bool {
+	return strings.HasSuffix(string(tokens[len(tokens)-1].Bytes), "\n")
+}
+
+func newline() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")}
+}
+
+// comments returns the comment tokens from the given tokens.
+func comments(tokens hclwrite.Tokens) hclwrite.Tokens {
+	var out hclwrite.Tokens
+	for _, t := range tokens {
+		if t.Type != hclsyntax.TokenComment {
+			continue
+		}
+		out = append(out, t)
+		// Block comments do not end with a newline.
+		if !endsWithNewline(out) {
+			out = append(out, newline())
+		}
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package schemahcl
+
+import (
+	"reflect"
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"github.com/stretchr/testify/require"
+)
+
+type fmtColumn struct {
+	Name string           `spec:",name"`
+	Null bool             `spec:"null"`
+	Type *schemaspec.Type `spec:"type"`
+	schemaspec.DefaultExtension
+}
+
+func init() {
+	schemaspec.Register("column", &fmtColumn{})
+}
+
+func TestFormat(t *testing.T) {
+	f := `# Header.
+
+table "users" {
+  column "name" {
+      type = VARCHAR( 255 ) # Line comment.
+      comment = "name"
+      null = true
+  }
+  schema = schema.public
+  // Lead comment.
+  column "id" {
+    type = "INT"
+  }
+}
+/* Detached comment. */
+
+schema "public" {
+}
+`
+	s := New(WithTypes([]*schemaspec.TypeSpec{
+		{Name: "int", T: "int"},
+		{Name: "varchar", T: "varchar", Attributes: []*schemaspec.TypeAttr{{Name: "size", Kind: reflect.Int}}},
+	}))
+	out, err := s.Format([]byte(f), "")
+	require.NoError(t, err)
+	expected := `# Header.
+
+table "users" {
+  schema = schema.public
+  column "name" {
+    null    = true
+    type    = varchar(255) # Line comment.
+    comment = "name"
+  }
+  // Lead comment.
+  column "id" {
+    type = "int"
+  }
+}
+
+/* Detached comment. */
+schema "public" {
+}
+`
+	require.Equal(t, expected, string(out))
+	again, err := s.Format(out, "")
+	require.NoError(t, err)
+	require.Equal(t, expected, string(again), "format should be idempotent")
+
+	s = New(WithSortedBlocks())
+	out, err = s.Format([]byte(f), "")
+	require.NoError(t, err)
+	require.Equal(t, `# Header.
+
+/* Detached comment. */
+schema "public" {
+}
+
+table "users" {
+  schema = schema.public
+  // Lead comment.
+  column "id" {
+    type = "INT"
+  }
+  column "name" {
+    null    = true
+    type    = VARCHAR(255) # Line comment.
+    comment = "name"
+  }
+}
+`, string(out))
+
+	_, err = s.Format([]byte(`table "users" {`), "schema.hcl")
+	require.Error(t, err)
+
+	// Only the types of columns are normalized.
+	s = New(WithTypes([]*schemaspec.TypeSpec{{Name: "text", T: "text"}, {Name: "date", T: "date"}}))
+	out, err = s.Format([]byte(`table "users" {
+  comment = "Text"
+  column "created" {
+    type    = DATE
+    default = "Date"
+    comment = "Text"
+  }
+}
+`), "")
+	require.NoError(t, err)
+	require.Equal(t, `table "users" {
+  comment = "Text"
+  column "created" {
+    type    = date
+    comment = "Text"
+    default = "Date"
+  }
+}
+`, string(out))
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -11,7 +14,6 @@ import (
 	"ariga.io/atlas/schema/schemaspec"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -29,10 +31,6 @@ var (
 )
 
 type (
-	container struct {
-		Body hcl.Body `hcl:",remain"`
-	}
-
 	// state implements schemaspec.Unmarshaler and schemaspec.Marshaler for Atlas HCL syntax
 	// and stores a configuration for these operations.
 	state struct {
@@ -51,66 +49,211 @@ func (s *state) MarshalSpec(v interface{}) ([]byte, error) {
 
 // UnmarshalSpec implements schemaspec.Unmarshaler.
 func (s *state) UnmarshalSpec(data []byte, v interface{}) error {
-	spec, err := decode(s.config.ctx, data)
-	if err != nil {
+	parser := hclparse.NewParser()
+	srcHCL, diag := parser.ParseHCL(data, "")
+	if diag.HasErrors() {
+		return fmt.Errorf("schemahcl: failed decoding: %w", diag)
+	}
+	if srcHCL == nil {
+		return fmt.Errorf("schemahcl: failed decoding: %w", errors.New("schemahcl: no HCL syntax found in body"))
+	}
+	return s.eval(v, srcHCL)
+}
+
+// EvalFiles evaluates the Atlas HCL documents in the given paths as one document,
+// and stores the result in the target. Paths can be files or directories, in which
+// case all files with the ".hcl" extension in the directory are evaluated. Blocks
+// and attributes that are defined in one file can be referenced from another.
+func (s *state) EvalFiles(paths []string, v interface{}) error {
+	var (
+		files  []*hcl.File
+		diags  hcl.Diagnostics
+		parser = hclparse.NewParser()
+	)
+	for _, p := range paths {
+		names, err := Files(p)
+		if err != nil {
+			return fmt.Errorf("schemahcl: %w", err)
+		}
+		for _, name := range names {
+			f, diag := parser.ParseHCLFile(name)
+			if diags = append(diags, diag...); f != nil {
+				files = append(files, f)
+			}
+		}
+	}
+	if diags.HasErrors() {
+		return fmt.Errorf("schemahcl: failed decoding: %w", diags)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("schemahcl: no HCL files found in %q", paths)
+	}
+	return s.eval(v, files...)
+}
+
+// eval evaluates the parsed files and stores the result in the target. If the evaluation
+// failed with diagnostics, the resources that were evaluated are still stored in the
+// target, to allow validating the entire document, and the diagnostics are returned.
+func (s *state) eval(v interface{}, files ...*hcl.File) error {
+	spec, err := decode(s.config, files...)
+	if spec == nil {
 		return fmt.Errorf("schemahcl: failed decoding: %w", err)
 	}
 	if err := spec.As(v); err != nil {
 		return fmt.Errorf("schemahcl: failed reading spec as %T: %w", v, err)
 	}
+	if err != nil {
+		return fmt.Errorf("schemahcl: failed decoding: %w", err)
+	}
 	return nil
 }
 
-// decode decodes the input Atlas HCL document and returns a *schemaspec.Resource representing it.
-func decode(ctx *hcl.EvalContext, body []byte) (*schemaspec.Resource, error) {
-	parser := hclparse.NewParser()
-	srcHCL, diag := parser.ParseHCL(body, "")
-	if diag.HasErrors() {
-		return nil, diag
+// Files returns the HCL files in the given path. If the path is
+// a directory, its files with the ".hcl" extension are returned.
+func Files(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
 	}
-	if srcHCL == nil {
-		return nil, fmt.Errorf("schemahcl: no HCL syntax found in body")
+	if !fi.IsDir() {
+		return []string{path}, nil
 	}
-	c := &container{}
-	ctx, err := evalCtx(ctx, srcHCL)
+	entries, err := ioutil.ReadDir(path)
 	if err != nil {
 		return nil, err
 	}
-	if diag := gohcl.DecodeBody(srcHCL.Body, ctx, c); diag.HasErrors() {
-		return nil, diag
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".hcl" {
+			names = append(names, filepath.Join(path, e.Name()))
+		}
 	}
-	return extract(ctx, c.Body)
+	return names, nil
 }
 
-func extract(ctx *hcl.EvalContext, remain hcl.Body) (*schemaspec.Resource, error) {
-	body, ok := remain.(*hclsyntax.Body)
-	if !ok {
-		return nil, fmt.Errorf("schemahcl: expected remainder to be of type *hclsyntax.Body")
+// decode decodes the input Atlas HCL documents and returns a *schemaspec.Resource representing
+// them. Multiple documents are merged into one body, and evaluated in the same context. On
+// evaluation diagnostics, the partially evaluated resource is returned along with them.
+func decode(cfg *Config, files ...*hcl.File) (*schemaspec.Resource, error) {
+	body, err := mergeBodies(files)
+	if err != nil {
+		return nil, err
 	}
-	attrs, err := toAttrs(ctx, body.Attributes)
+	ctx, err := evalCtx(cfg.ctx, body, cfg.vars)
 	if err != nil {
 		return nil, err
 	}
+	return extract(ctx, cfg.names, body)
+}
+
+// mergeBodies merges the bodies of the given files into one body. An error
+// is returned if an attribute or a labeled block is defined more than once.
+func mergeBodies(files []*hcl.File) (*hclsyntax.Body, error) {
+	var (
+		diags  hcl.Diagnostics
+		blocks = make(map[string]*hclsyntax.Block)
+		merged = &hclsyntax.Body{Attributes: make(hclsyntax.Attributes)}
+	)
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("schemahcl: expected an hcl body")
+		}
+		for name, attr := range body.Attributes {
+			if prev, ok := merged.Attributes[name]; ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate attribute",
+					Detail:   fmt.Sprintf("Attribute %q was already defined at %s.", name, prev.NameRange),
+					Subject:  attr.NameRange.Ptr(),
+				})
+				continue
+			}
+			merged.Attributes[name] = attr
+		}
+		for _, blk := range body.Blocks {
+			if name := blockName(blk); name != "" {
+				key := blk.Type + "." + name
+				if prev, ok := blocks[key]; ok {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Duplicate block",
+						Detail:   fmt.Sprintf("%s %q was already defined at %s.", blk.Type, name, prev.DefRange()),
+						Subject:  blk.DefRange().Ptr(),
+					})
+					continue
+				}
+				blocks[key] = blk
+			}
+			merged.Blocks = append(merged.Blocks, blk)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return merged, nil
+}
+
+// extract evaluates the given body into a *schemaspec.Resource. Evaluation errors
+// are collected from the entire body, instead of stopping on the first one, and the
+// attributes that failed to evaluate are omitted from the returned resource. The names
+// argument holds the resource types whose name can be set by a "name" attribute.
+func extract(ctx *hcl.EvalContext, names map[string]bool, body *hclsyntax.Body) (*schemaspec.Resource, error) {
+	var diags hcl.Diagnostics
+	attrs, err := toAttrs(ctx, body.Attributes)
+	if !collect(&diags, err) {
+		return nil, err
+	}
 	res := &schemaspec.Resource{
 		Attrs: attrs,
 	}
 	for _, blk := range body.Blocks {
-		resource, err := toResource(ctx, blk)
-		if err != nil {
+		// Input variables are not part of the document resources.
+		if blk.Type == varBlock {
+			continue
+		}
+		resource, err := toResource(ctx, names, blk)
+		if !collect(&diags, err) {
 			return nil, err
 		}
 		res.Children = append(res.Children, resource)
 	}
+	if diags.HasErrors() {
+		return res, diags
+	}
 	return res, nil
 }
 
+// diagPos returns the byte offset of the diagnostic subject, if it exists.
+func diagPos(d *hcl.Diagnostic) int {
+	if d.Subject == nil {
+		return 0
+	}
+	return d.Subject.Start.Byte
+}
+
+// collect appends the given error to the diagnostics if it holds evaluation
+// diagnostics, and reports whether the evaluation can proceed.
+func collect(diags *hcl.Diagnostics, err error) bool {
+	var d hcl.Diagnostics
+	if errors.As(err, &d) {
+		*diags = append(*diags, d...)
+		return true
+	}
+	return err == nil
+}
+
 func toAttrs(ctx *hcl.EvalContext, hclAttrs hclsyntax.Attributes) ([]*schemaspec.Attr, error) {
-	var attrs []*schemaspec.Attr
+	var (
+		attrs []*schemaspec.Attr
+		diags hcl.Diagnostics
+	)
 	for _, hclAttr := range hclAttrs {
-		at := &schemaspec.Attr{K: hclAttr.Name}
+		at := &schemaspec.Attr{K: hclAttr.Name, Range: hclAttr.Expr.Range().Ptr()}
 		value, diag := hclAttr.Expr.Value(ctx)
 		if diag.HasErrors() {
-			return nil, diag
+			diags = append(diags, diag...)
+			continue
 		}
 		var err error
 		switch {
@@ -124,7 +267,13 @@ func toAttrs(ctx *hcl.EvalContext, hclAttrs hclsyntax.Attributes) ([]*schemaspec
 			at.V, err = extractLiteralValue(value)
 		}
 		if err != nil {
-			return nil, err
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported value",
+				Detail:   err.Error(),
+				Subject:  hclAttr.Expr.Range().Ptr(),
+			})
+			continue
 		}
 		attrs = append(attrs, at)
 	}
@@ -132,6 +281,13 @@ func toAttrs(ctx *hcl.EvalContext, hclAttrs hclsyntax.Attributes) ([]*schemaspec
 	sort.Slice(attrs, func(i, j int) bool {
 		return attrs[i].K < attrs[j].K
 	})
+	if diags.HasErrors() {
+		// Sort the diagnostics by their position, as attributes are stored in a map.
+		sort.SliceStable(diags, func(i, j int) bool {
+			return diagPos(diags[i]) < diagPos(diags[j])
+		})
+		return attrs, diags
+	}
 	return attrs, nil
 }
 
@@ -174,28 +330,63 @@ func extractLiteralValue(value cty.Value) (*schemaspec.LiteralValue, error) {
 	}
 }
 
-func toResource(ctx *hcl.EvalContext, block *hclsyntax.Block) (*schemaspec.Resource, error) {
+func toResource(ctx *hcl.EvalContext, names map[string]bool, block *hclsyntax.Block) (*schemaspec.Resource, error) {
 	spec := &schemaspec.Resource{
-		Type: block.Type,
+		Type:  block.Type,
+		Range: block.DefRange().Ptr(),
 	}
 	if len(block.Labels) > 0 {
 		spec.Name = block.Labels[0]
 	}
+	var diags hcl.Diagnostics
 	attrs, err := toAttrs(ctx, block.Body.Attributes)
-	if err != nil {
+	if !collect(&diags, err) {
 		return nil, err
 	}
 	spec.Attrs = attrs
+	// In the configured resource types, a "name" attribute overrides the block label.
+	if names[block.Type] {
+		if d := computeName(block, spec); d != nil {
+			diags = append(diags, d)
+		}
+	}
 	for _, blk := range block.Body.Blocks {
-		res, err := toResource(ctx, blk)
-		if err != nil {
+		res, err := toResource(ctx, names, blk)
+		if !collect(&diags, err) {
 			return nil, err
 		}
 		spec.Children = append(spec.Children, res)
 	}
+	if diags.HasErrors() {
+		return spec, diags
+	}
 	return spec, nil
 }
 
+// computeName sets the name of the resource from its "name" attribute, if it exists.
+// Unlike labels, the attribute can be computed from expressions (e.g. input variables).
+// References to the resource still use the block label.
+func computeName(block *hclsyntax.Block, spec *schemaspec.Resource) *hcl.Diagnostic {
+	for i, at := range spec.Attrs {
+		if at.K != "name" {
+			continue
+		}
+		spec.Attrs = append(spec.Attrs[:i], spec.Attrs[i+1:]...)
+		name, err := at.String()
+		if err != nil {
+			return &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid name attribute",
+				Detail:   fmt.Sprintf("The name of %s %q must be a string.", block.Type, spec.Name),
+				Subject:  at.Range,
+			}
+		}
+		spec.Name = name
+		return nil
+	}
+	return nil
+}
+
 // encode encodes the give *schemaspec.Resource into a byte slice containing an Atlas HCL
 // document representing it.
 func (s *state) encode(r *schemaspec.Resource) ([]byte, error) {
@@ -1,11 +1,15 @@
 package schemahcl
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"path/filepath"
 	"testing"
 
 	"ariga.io/atlas/schema/schemaspec"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -220,3 +224,95 @@ zoo "ramat_gan" {
 	})
 
 }
+
+func TestEvalFiles(t *testing.T) {
+	type (
+		Column struct {
+			Name string `spec:",name"`
+			Type string `spec:"type"`
+		}
+		Table struct {
+			Name    string            `spec:",name"`
+			Columns []*Column         `spec:"column"`
+			Refs    []*schemaspec.Ref `spec:"refs"`
+		}
+		Doc struct {
+			Tables []*Table `spec:"table"`
+		}
+	)
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "users.hcl"), []byte(`
+table "users" {
+	column "id" {
+		type = "int"
+	}
+}
+`), 0644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "posts.hcl"), []byte(`
+table "posts" {
+	column "author_id" {
+		type = table.users.column.id.type
+	}
+	refs = [table.users.column.id]
+}
+`), 0644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not an hcl file"), 0644)
+	require.NoError(t, err)
+
+	var doc Doc
+	err = New().EvalFiles([]string{dir}, &doc)
+	require.NoError(t, err)
+	require.Len(t, doc.Tables, 2)
+	require.Equal(t, "posts", doc.Tables[0].Name)
+	require.Equal(t, "int", doc.Tables[0].Columns[0].Type)
+	require.Equal(t, "$table.users.$column.id", doc.Tables[0].Refs[0].V)
+	require.Equal(t, "users", doc.Tables[1].Name)
+
+	// Files and directories can be mixed.
+	dup := filepath.Join(t.TempDir(), "dup.hcl")
+	err = ioutil.WriteFile(dup, []byte(`
+table "users" {}
+`), 0644)
+	require.NoError(t, err)
+	err = New().EvalFiles([]string{dir, dup}, &doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), dup+`:2,1-16: Duplicate block; table "users" was already defined at `+filepath.Join(dir, "users.hcl")+":2,1-16.")
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	var doc struct {
+		Tables []*struct {
+			Name string            `spec:",name"`
+			Refs []*schemaspec.Ref `spec:"refs"`
+		} `spec:"table"`
+	}
+	err := New().UnmarshalSpec([]byte(`
+table "users" {
+	refs = [table.groups]
+}
+table "tags" {
+	refs = [table.users]
+}
+table "posts" {
+	refs = [table.users, table.authors]
+	nested {
+		ref = table.comments
+	}
+}
+`), &doc)
+	var diags hcl.Diagnostics
+	require.True(t, errors.As(err, &diags))
+	require.Len(t, diags, 3)
+	require.Equal(t, 3, diags[0].Subject.Start.Line)
+	require.Equal(t, 9, diags[1].Subject.Start.Line)
+	require.Equal(t, 11, diags[2].Subject.Start.Line)
+	// Resources are decoded even if some of their attributes failed to evaluate.
+	require.Len(t, doc.Tables, 3)
+	require.Equal(t, "users", doc.Tables[0].Name)
+	require.Empty(t, doc.Tables[0].Refs)
+	require.Equal(t, "tags", doc.Tables[1].Name)
+	require.Equal(t, []*schemaspec.Ref{{V: "$table.users"}}, doc.Tables[1].Refs)
+	require.Equal(t, "posts", doc.Tables[2].Name)
+}
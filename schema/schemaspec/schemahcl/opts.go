@@ -12,8 +12,11 @@ import (
 type (
 	// Config configures an unmarshaling.
 	Config struct {
-		ctx   *hcl.EvalContext
-		types []*schemaspec.TypeSpec
+		ctx        *hcl.EvalContext
+		types      []*schemaspec.TypeSpec
+		vars       map[string]string
+		names      map[string]bool
+		sortBlocks bool
 	}
 
 	// Option configures a Config.
@@ -41,6 +44,50 @@ func EvalContext(ctx *hcl.EvalContext) Option {
 	}
 }
 
+// WithInputValues configures the values of the input variables that are
+// declared in the document using `variable` blocks. For example:
+//
+//	variable "tenant" {
+//		type    = string
+//		default = "public"
+//	}
+//
+// Input variables are referenced in the document as `var.tenant`.
+func WithInputValues(vals map[string]string) Option {
+	return func(config *Config) {
+		config.vars = vals
+	}
+}
+
+// WithComputedNames configures the resource types whose name can be set using a
+// `name` attribute, instead of the block label. Unlike labels, the attribute can be
+// computed from expressions, such as input variables. For example:
+//
+//	schema "tenant" {
+//		name = var.tenant
+//	}
+//
+// Blocks are still referenced using their labels (e.g. `schema.tenant`). In resource
+// types that were not configured, `name` is decoded as a regular attribute.
+func WithComputedNames(types ...string) Option {
+	return func(config *Config) {
+		if config.names == nil {
+			config.names = make(map[string]bool)
+		}
+		for _, t := range types {
+			config.names[t] = true
+		}
+	}
+}
+
+// WithSortedBlocks configures the Format method to sort the blocks of the document by
+// their type and labels, instead of keeping their original order.
+func WithSortedBlocks() Option {
+	return func(config *Config) {
+		config.sortBlocks = true
+	}
+}
+
 // WithTypes configures the list of given types as identifiers in the unmarshaling context.
 func WithTypes(typeSpecs []*schemaspec.TypeSpec) Option {
 	return func(config *Config) {
@@ -0,0 +1,73 @@
+// Package schemajson implements the schemaspec.Marshaler and schemaspec.Unmarshaler
+// interfaces for the JSON syntax of the Atlas DDL. For example:
+//
+//	{
+//	  "schema": [{"name": "public"}],
+//	  "table": [
+//	    {
+//	      "name": "users",
+//	      "schema": {"$ref": "$schema.public"},
+//	      "column": [
+//	        {"name": "id", "type": "int"}
+//	      ],
+//	      "primary_key": [
+//	        {"columns": [{"$ref": "$table.users.$column.id"}]}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Resources are represented as objects, and their children are stored as lists of
+// objects under their type. References and typed values are represented as objects
+// with a "$ref" or a "$type" key.
+package schemajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/schema/schemaspec/internal/schematree"
+)
+
+var (
+	// Marshal returns the Atlas JSON encoding of v.
+	Marshal = schemaspec.MarshalerFunc(marshal)
+	// Unmarshal parses the Atlas JSON-encoded data and stores the result in the target.
+	Unmarshal = schemaspec.UnmarshalerFunc(unmarshal)
+)
+
+func marshal(v interface{}) ([]byte, error) {
+	r := &schemaspec.Resource{}
+	if err := r.Scan(v); err != nil {
+		return nil, fmt.Errorf("schemajson: failed scanning %T to resource: %w", v, err)
+	}
+	o, err := schematree.Encode(r)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Numbers are kept as is, instead of being converted to float64.
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("schemajson: failed decoding: %w", err)
+	}
+	r, err := schematree.Decode(doc)
+	if err != nil {
+		return fmt.Errorf("schemajson: failed decoding: %w", err)
+	}
+	if err := r.As(v); err != nil {
+		return fmt.Errorf("schemajson: failed reading spec as %T: %w", v, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package schemayaml implements the schemaspec.Marshaler and schemaspec.Unmarshaler
+// interfaces for the YAML syntax of the Atlas DDL. For example:
+//
+//	schema:
+//	  - name: public
+//	table:
+//	  - name: users
+//	    schema:
+//	      $ref: $schema.public
+//	    column:
+//	      - name: id
+//	        type: int
+//	    primary_key:
+//	      - columns:
+//	          - $ref: $table.users.$column.id
+//
+// Resources are represented as mappings, and their children are stored as sequences of
+// mappings under their type. References and typed values are represented as mappings
+// with a "$ref" or a "$type" key.
+package schemayaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/schema/schemaspec/internal/schematree"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Marshal returns the Atlas YAML encoding of v.
+	Marshal = schemaspec.MarshalerFunc(marshal)
+	// Unmarshal parses the Atlas YAML-encoded data and stores the result in the target.
+	Unmarshal = schemaspec.UnmarshalerFunc(unmarshal)
+)
+
+func marshal(v interface{}) ([]byte, error) {
+	r := &schemaspec.Resource{}
+	if err := r.Scan(v); err != nil {
+		return nil, fmt.Errorf("schemayaml: failed scanning %T to resource: %w", v, err)
+	}
+	o, err := schematree.Encode(r)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node(o)); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("schemayaml: failed decoding: %w", err)
+	}
+	r, err := schematree.Decode(doc)
+	if err != nil {
+		return fmt.Errorf("schemayaml: failed decoding: %w", err)
+	}
+	if err := r.As(v); err != nil {
+		return fmt.Errorf("schemayaml: failed reading spec as %T: %w", v, err)
+	}
+	return nil
+}
+
+// node returns the YAML node of the given schematree value.
+func node(v interface{}) *yaml.Node {
+	switch v := v.(type) {
+	case *schematree.Object:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		for i, k := range v.Keys {
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, node(v.Values[i]))
+		}
+		return n
+	case []interface{}:
+		n := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, e := range v {
+			n.Content = append(n.Content, node(e))
+		}
+		return n
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(v)}
+	}
+}
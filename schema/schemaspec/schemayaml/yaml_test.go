@@ -0,0 +1,74 @@
+package schemayaml
+
+import (
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	column struct {
+		Name string           `spec:",name"`
+		Type *schemaspec.Type `spec:"type"`
+		Null bool             `spec:"null"`
+	}
+	table struct {
+		Name    string            `spec:",name"`
+		Schema  *schemaspec.Ref   `spec:"schema"`
+		Size    int               `spec:"size"`
+		Comment string            `spec:"comment"`
+		Tags    []string          `spec:"tags"`
+		Columns []*column         `spec:"column"`
+		PK      []*schemaspec.Ref `spec:"pk"`
+	}
+	doc struct {
+		Tables []*table `spec:"table"`
+	}
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	f := `table:
+  - name: users
+    schema:
+      $ref: $schema.public
+    size: 10
+    comment: "true"
+    tags:
+      - a
+      - b
+    pk:
+      - $ref: $table.users.$column.id
+    column:
+      - name: id
+        type:
+          $type: varchar
+          size: 255
+        "null": true
+`
+	var d doc
+	require.NoError(t, Unmarshal([]byte(f), &d))
+	require.Len(t, d.Tables, 1)
+	users := d.Tables[0]
+	require.Equal(t, "users", users.Name)
+	require.Equal(t, 10, users.Size)
+	require.Equal(t, "true", users.Comment)
+	require.Equal(t, []string{"a", "b"}, users.Tags)
+	require.Equal(t, "$schema.public", users.Schema.V)
+	require.Equal(t, []*schemaspec.Ref{{V: "$table.users.$column.id"}}, users.PK)
+	require.Equal(t, []*column{
+		{Name: "id", Null: true, Type: &schemaspec.Type{T: "varchar", Attrs: []*schemaspec.Attr{{K: "size", V: &schemaspec.LiteralValue{V: "255"}}}}},
+	}, users.Columns)
+
+	b, err := Marshal(&d)
+	require.NoError(t, err)
+	require.Equal(t, f, string(b))
+}
+
+func TestUnmarshal_Error(t *testing.T) {
+	var d doc
+	err := Unmarshal([]byte("table: ["), &d)
+	require.Error(t, err)
+	err = Unmarshal([]byte("table:\n  - name: [1]\n"), &d)
+	require.EqualError(t, err, `schemayaml: failed decoding: schematree: expect "name" of "table" to be a string, got []interface {}`)
+}
@@ -1,9 +1,12 @@
 package schemaspec
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+
+	"github.com/hashicorp/hcl/v2"
 )
 
 type (
@@ -13,12 +16,14 @@ type (
 		Type     string
 		Attrs    []*Attr
 		Children []*Resource
+		Range    *hcl.Range // Optional position in the source document.
 	}
 
 	// Attr is an attribute of a Resource.
 	Attr struct {
-		K string
-		V Value
+		K     string
+		V     Value
+		Range *hcl.Range // Optional position of the value in the source document.
 	}
 
 	// Value represents the value of an Attr.
@@ -65,6 +70,13 @@ type (
 	// method of the Unmarshaler interface.
 	UnmarshalerFunc func([]byte, interface{}) error
 
+	// PosError is an error that is annotated with the
+	// position of its cause in the source document.
+	PosError struct {
+		Range *hcl.Range
+		Err   error
+	}
+
 	// TypeSpec represents a specification for defining a Type.
 	TypeSpec struct {
 		// Name is the identifier for the type in an Atlas DDL document.
@@ -181,6 +193,31 @@ func (r *Resource) SetAttr(attr *Attr) {
 	r.Attrs = replaceOrAppendAttr(r.Attrs, attr)
 }
 
+// Error implements the error interface.
+func (e *PosError) Error() string {
+	pos := fmt.Sprintf("%d:%d", e.Range.Start.Line, e.Range.Start.Column)
+	if e.Range.Filename != "" {
+		pos = e.Range.Filename + ":" + pos
+	}
+	return fmt.Sprintf("%s: %v", pos, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PosError) Unwrap() error {
+	return e.Err
+}
+
+// WithPos annotates the error with the given position. The error is returned as is
+// if the position is unknown, or if the error is already annotated with a position,
+// as the inner position is the more accurate one.
+func WithPos(r *hcl.Range, err error) error {
+	var pe *PosError
+	if err == nil || r == nil || errors.As(err, &pe) {
+		return err
+	}
+	return &PosError{Range: r, Err: err}
+}
+
 // MarshalSpec implements Marshaler.
 func (f MarshalerFunc) MarshalSpec(v interface{}) ([]byte, error) {
 	return f(v)
@@ -1,8 +1,10 @@
 package schemaspec
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,3 +29,65 @@ func TestBools(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, []bool{true, false, true}, bls)
 }
+
+func TestWithPos(t *testing.T) {
+	err := errors.New("unknown type")
+	require.Equal(t, err, WithPos(nil, err))
+	require.Nil(t, WithPos(&hcl.Range{}, nil))
+
+	inner := WithPos(&hcl.Range{Filename: "a.hcl", Start: hcl.Pos{Line: 3, Column: 5}}, err)
+	require.EqualError(t, inner, "a.hcl:3:5: unknown type")
+	outer := WithPos(&hcl.Range{Start: hcl.Pos{Line: 1, Column: 1}}, inner)
+	require.Equal(t, inner, outer)
+
+	var pe *PosError
+	require.True(t, errors.As(outer, &pe))
+	require.Equal(t, 3, pe.Range.Start.Line)
+	require.True(t, errors.Is(outer, err))
+	require.EqualError(t, WithPos(&hcl.Range{Start: hcl.Pos{Line: 1, Column: 2}}, err), "1:2: unknown type")
+}
+
+func TestAttrRange(t *testing.T) {
+	type column struct {
+		Type string `spec:"type"`
+		DefaultExtension
+	}
+	var (
+		block = &hcl.Range{Start: hcl.Pos{Line: 1, Column: 1}}
+		typ   = &hcl.Range{Start: hcl.Pos{Line: 2, Column: 10}}
+		extra = &hcl.Range{Start: hcl.Pos{Line: 3, Column: 10}}
+		doc   struct {
+			Columns []*column `spec:"column"`
+		}
+	)
+	r := &Resource{
+		Type:  "column",
+		Range: block,
+		Attrs: []*Attr{
+			{K: "type", V: &LiteralValue{V: `"int"`}, Range: typ},
+			{K: "comment", V: &LiteralValue{V: `"id"`}, Range: extra},
+		},
+	}
+	require.NoError(t, (&Resource{Children: []*Resource{r}}).As(&doc))
+	col := doc.Columns[0]
+	require.Equal(t, block, col.Range())
+	require.Equal(t, typ, col.AttrRange("type"))
+	require.Equal(t, extra, col.AttrRange("comment"))
+	require.Equal(t, block, col.AttrRange("unknown"))
+	// Positions are not part of the decoded value.
+	require.Equal(t, &column{
+		Type: "int",
+		DefaultExtension: DefaultExtension{
+			Extra: Resource{Attrs: []*Attr{{K: "comment", V: &LiteralValue{V: `"id"`}}}},
+		},
+	}, col)
+	require.Nil(t, (&column{}).Range())
+
+	var invalid struct {
+		Type int `spec:"type"`
+	}
+	err := r.As(&invalid)
+	var pe *PosError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, typ, pe.Range)
+}
@@ -0,0 +1,693 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package convert converts schemas between the SQL dialects that are supported by Atlas
+// (mysql, postgres and sqlite), and reports the elements that cannot be mapped to the
+// target dialect.
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+)
+
+// converter converts schema elements from one SQL dialect to another, and
+// records the elements that cannot be mapped to the target dialect.
+type converter struct {
+	from, to string
+	issues   []string
+	// dropped counts the attributes that were dropped, because they
+	// have no meaning in the target dialect, grouped by their kind.
+	dropped map[string]int
+	// autoStart holds the initial AUTO_INCREMENT value of the converted table.
+	autoStart int64
+}
+
+// Schema converts a schema that was inspected or unmarshaled by the driver of the
+// "from" dialect to the schema model of the "to" dialect. Elements that cannot be mapped
+// to the target dialect are kept as-is (or dropped), and reported in the returned list.
+func Schema(s *schema.Schema, from, to string) (*schema.Schema, []string, error) {
+	for _, d := range []string{from, to} {
+		switch d {
+		case "mysql", "postgres", "sqlite":
+		default:
+			return nil, nil, fmt.Errorf("unknown dialect: %q", d)
+		}
+	}
+	if from == to {
+		return nil, nil, fmt.Errorf("source and target dialects are the same: %q", from)
+	}
+	c := &converter{from: from, to: to, dropped: make(map[string]int)}
+	ns := &schema.Schema{Name: s.Name}
+	for _, a := range s.Attrs {
+		c.dropAttr(a, "schema "+s.Name)
+	}
+	for _, t := range s.Tables {
+		ns.Tables = append(ns.Tables, c.table(t, ns))
+	}
+	// Foreign keys are converted after all tables were
+	// created, as they may reference tables defined later.
+	for i, t := range s.Tables {
+		c.foreignKeys(t, ns.Tables[i], ns)
+	}
+	issues := c.issues
+	kinds := make([]string, 0, len(c.dropped))
+	for k := range c.dropped {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		issues = append(issues, fmt.Sprintf("%s attributes cannot be mapped to %s and were dropped from %d element(s)", k, to, c.dropped[k]))
+	}
+	return ns, issues, nil
+}
+
+// report records an element that cannot be mapped to the target dialect.
+func (c *converter) report(elem, format string, args ...interface{}) {
+	c.issues = append(c.issues, elem+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *converter) table(t *schema.Table, ns *schema.Schema) *schema.Table {
+	elem := "table " + t.Name
+	nt := &schema.Table{Name: t.Name, Schema: ns}
+	c.autoStart = 0
+	for _, a := range t.Attrs {
+		if a, ok := a.(*mysql.AutoIncrement); ok {
+			c.autoStart = a.V
+		}
+	}
+	for _, a := range t.Attrs {
+		if a, ok := c.tableAttr(a, elem); ok {
+			nt.Attrs = append(nt.Attrs, a)
+		}
+	}
+	for _, col := range t.Columns {
+		nt.Columns = append(nt.Columns, c.column(col, t, nt))
+	}
+	if t.PrimaryKey != nil {
+		nt.PrimaryKey = c.index(t.PrimaryKey, nt)
+	}
+	// SQLite supports AUTOINCREMENT only on INTEGER PRIMARY KEY columns.
+	if c.to == "sqlite" {
+		for _, col := range nt.Columns {
+			if !hasAutoIncrement(col) || nt.PrimaryKey != nil && len(nt.PrimaryKey.Parts) == 1 && nt.PrimaryKey.Parts[0].C == col {
+				continue
+			}
+			attrs := col.Attrs[:0]
+			for _, a := range col.Attrs {
+				if _, ok := a.(*sqlite.AutoIncrement); !ok {
+					attrs = append(attrs, a)
+				}
+			}
+			col.Attrs = attrs
+			c.report("column "+t.Name+"."+col.Name, "auto-increment is supported by sqlite only on single-column primary keys")
+		}
+	}
+	for _, idx := range t.Indexes {
+		nt.Indexes = append(nt.Indexes, c.index(idx, nt))
+	}
+	if c.to == "mysql" && c.autoStart > 1 {
+		nt.Attrs = append(nt.Attrs, &mysql.AutoIncrement{V: c.autoStart})
+	}
+	return nt
+}
+
+func (c *converter) tableAttr(a schema.Attr, elem string) (schema.Attr, bool) {
+	switch a := a.(type) {
+	case *mysql.AutoIncrement:
+		// Handled by the auto-increment column.
+	case *mysql.Check:
+		switch c.to {
+		case "postgres":
+			if !a.Enforced {
+				c.report(elem, "check constraint %q is not enforced, but postgres does not support unenforced checks", a.Name)
+			}
+			c.report(elem, "check constraint %q was copied as is, verify its clause is valid in postgres", a.Name)
+			return &postgres.Check{Name: a.Name, Clause: a.Clause}, true
+		default:
+			c.report(elem, "check constraint %q is not supported by %s", a.Name, c.to)
+		}
+	case *postgres.Check:
+		switch c.to {
+		case "mysql":
+			c.report(elem, "check constraint %q was copied as is, verify its clause is valid in mysql", a.Name)
+			return &mysql.Check{Name: a.Name, Clause: a.Clause, Enforced: true}, true
+		default:
+			c.report(elem, "check constraint %q is not supported by %s", a.Name, c.to)
+		}
+	case *sqlite.WithoutRowID:
+		c.report(elem, "WITHOUT ROWID tables are not supported by %s", c.to)
+	case *sqlite.CreateStmt, *sqlite.File:
+		// Inspection metadata.
+	default:
+		return c.commonAttr(a, elem)
+	}
+	return nil, false
+}
+
+// commonAttr converts the attributes that are shared by all schema elements.
+func (c *converter) commonAttr(a schema.Attr, elem string) (schema.Attr, bool) {
+	switch a := a.(type) {
+	case *schema.Comment:
+		if c.to == "sqlite" {
+			c.dropped["comment"]++
+			return nil, false
+		}
+		return a, true
+	default:
+		c.dropAttr(a, elem)
+		return nil, false
+	}
+}
+
+// dropAttr drops the given attribute, and records it.
+func (c *converter) dropAttr(a schema.Attr, elem string) {
+	switch a := a.(type) {
+	case *schema.Charset:
+		c.dropped["charset"]++
+	case *schema.Collation:
+		c.dropped["collation"]++
+	case *postgres.CType:
+		c.dropped["ctype"]++
+	case *mysql.Engine, *mysql.RowFormat, *mysql.KeyBlockSize, *mysql.StatsPersistent:
+		c.dropped["table option"]++
+	case *mysql.Partition, *postgres.Partition:
+		c.dropped["partitioning"]++
+	case *postgres.RowSecurity, *postgres.Policy:
+		c.dropped["row-level security"]++
+	// Account names and privilege types are not portable between dialects.
+	case *schema.Grant, *schema.Role:
+		c.dropped["grant"]++
+	case *postgres.Deferrable, *postgres.MatchType:
+		c.dropped["constraint option"]++
+	case *postgres.Exclude:
+		c.report(elem, "exclusion constraint %q is not supported by %s", a.Name, c.to)
+	case *postgres.Extension:
+		c.report(elem, "extension %q is not supported by %s", a.Name, c.to)
+	default:
+		c.report(elem, "attribute %T is not supported by %s", a, c.to)
+	}
+}
+
+func (c *converter) column(col *schema.Column, t, nt *schema.Table) *schema.Column {
+	elem := "column " + t.Name + "." + col.Name
+	nc := &schema.Column{
+		Name: col.Name,
+		Type: &schema.ColumnType{Type: c.typ(col.Type.Type, elem, t, col), Null: col.Type.Null},
+	}
+	nc.Default = c.defaultExpr(col.Default, nc.Type.Type, elem)
+	auto := false
+	for _, a := range col.Attrs {
+		switch a := a.(type) {
+		case *mysql.AutoIncrement, *sqlite.AutoIncrement, sqlite.AutoIncrement:
+			auto = true
+		case *postgres.Identity:
+			auto = true
+			if a.Start > 1 {
+				c.autoStart = a.Start
+			}
+		case *mysql.OnUpdate:
+			c.report(elem, "ON UPDATE %s is not supported by %s", a.A, c.to)
+		case *schema.GeneratedExpr:
+			x := *a
+			if c.to == "postgres" && !strings.EqualFold(x.Type, "STORED") {
+				if x.Type != "" {
+					c.report(elem, "%s generated columns are not supported by postgres, converted to STORED", strings.ToUpper(x.Type))
+				}
+				x.Type = "STORED"
+			}
+			nc.Attrs = append(nc.Attrs, &x)
+		default:
+			if a, ok := c.commonAttr(a, elem); ok {
+				nc.Attrs = append(nc.Attrs, a)
+			}
+		}
+	}
+	// Serial types are converted to auto-increment integers.
+	if _, ok := col.Type.Type.(*postgres.SerialType); ok {
+		auto = true
+	}
+	if auto {
+		switch c.to {
+		case "mysql":
+			nc.Attrs = append(nc.Attrs, &mysql.AutoIncrement{})
+		case "postgres":
+			nc.Attrs = append(nc.Attrs, &postgres.Identity{Generation: "BY DEFAULT", Start: c.autoStart})
+		case "sqlite":
+			nc.Attrs = append(nc.Attrs, &sqlite.AutoIncrement{})
+		}
+	}
+	return nc
+}
+
+// castExpr matches PostgreSQL literals with type casts. e.g. 'a'::character varying.
+var castExpr = regexp.MustCompile(`^('(?:[^']|'')*')::[\w\s."\[\]]+$`)
+
+// defaultExpr converts the default value of a column of type t.
+func (c *converter) defaultExpr(x schema.Expr, t schema.Type, elem string) schema.Expr {
+	var v string
+	switch x := x.(type) {
+	case nil:
+		return nil
+	case *postgres.SeqFuncExpr:
+		// Sequence functions are replaced by auto-increment.
+		if !strings.HasPrefix(x.X, "nextval") {
+			c.report(elem, "default value %s is not supported by %s", x.X, c.to)
+		}
+		return nil
+	case *schema.Literal:
+		v = x.V
+	case *schema.RawExpr:
+		v = x.X
+	default:
+		c.report(elem, "default value of type %T is not supported by %s", x, c.to)
+		return nil
+	}
+	if c.from == "postgres" {
+		v = castExpr.ReplaceAllString(v, "$1")
+	}
+	// Integer booleans are not accepted by PostgreSQL.
+	if _, ok := t.(*schema.BoolType); ok && c.to == "postgres" {
+		switch strings.Trim(v, "'") {
+		case "0", "b'0'":
+			v = "false"
+		case "1", "b'1'":
+			v = "true"
+		}
+	}
+	if _, ok := x.(*schema.Literal); ok {
+		return &schema.Literal{V: v}
+	}
+	return &schema.RawExpr{X: v}
+}
+
+func (c *converter) index(idx *schema.Index, nt *schema.Table) *schema.Index {
+	elem := "index " + nt.Name + "." + idx.Name
+	ni := &schema.Index{Name: idx.Name, Unique: idx.Unique, Table: nt}
+	for _, a := range idx.Attrs {
+		if a, ok := c.indexAttr(a, elem); ok {
+			ni.Attrs = append(ni.Attrs, a)
+		}
+	}
+	for _, p := range idx.Parts {
+		np := &schema.IndexPart{SeqNo: p.SeqNo, X: p.X}
+		if p.C != nil {
+			np.C, _ = nt.Column(p.C.Name)
+			np.C.Indexes = append(np.C.Indexes, ni)
+		}
+		for _, a := range p.Attrs {
+			switch a := a.(type) {
+			case *mysql.SubPart:
+				c.report(elem, "prefix length (%d) is not supported by %s", a.Len, c.to)
+			case *postgres.IndexColumnProperty:
+				if a.Desc || a.NullsFirst || a.NullsLast {
+					c.report(elem, "index column ordering is not supported by %s", c.to)
+				}
+			default:
+				c.report(elem, "index part attribute %T is not supported by %s", a, c.to)
+			}
+		}
+		ni.Parts = append(ni.Parts, np)
+	}
+	return ni
+}
+
+func (c *converter) indexAttr(a schema.Attr, elem string) (schema.Attr, bool) {
+	switch a := a.(type) {
+	case *mysql.IndexType:
+		switch t := strings.ToUpper(a.T); {
+		case t == "BTREE":
+		case t == "HASH" && c.to == "postgres":
+			return &postgres.IndexType{T: t}, true
+		default:
+			c.report(elem, "%s indexes are not supported by %s", t, c.to)
+		}
+	case *postgres.IndexType:
+		switch t := strings.ToUpper(a.T); {
+		case t == "BTREE":
+		case t == "HASH" && c.to == "mysql":
+			return &mysql.IndexType{T: t}, true
+		default:
+			c.report(elem, "%s indexes are not supported by %s", t, c.to)
+		}
+	case *postgres.IndexPredicate:
+		if c.to == "sqlite" {
+			return &sqlite.IndexPredicate{P: a.P}, true
+		}
+		c.report(elem, "partial indexes are not supported by %s", c.to)
+	case *sqlite.IndexPredicate:
+		if c.to == "postgres" {
+			return &postgres.IndexPredicate{P: a.P}, true
+		}
+		c.report(elem, "partial indexes are not supported by %s", c.to)
+	case *postgres.IndexInclude:
+		c.report(elem, "included columns are not supported by %s", c.to)
+	case *postgres.IndexNullsDistinct:
+		if !a.V {
+			c.report(elem, "NULLS NOT DISTINCT is not supported by %s", c.to)
+		}
+	case *sqlite.AutoIncrement, sqlite.AutoIncrement, *sqlite.IndexOrigin, *postgres.ConType:
+		// Handled by the column, or inspection metadata.
+	default:
+		return c.commonAttr(a, elem)
+	}
+	return nil, false
+}
+
+func (c *converter) foreignKeys(t, nt *schema.Table, ns *schema.Schema) {
+	for _, fk := range t.ForeignKeys {
+		nfk := &schema.ForeignKey{
+			Symbol:   fk.Symbol,
+			Table:    nt,
+			OnUpdate: fk.OnUpdate,
+			OnDelete: fk.OnDelete,
+		}
+		nfk.RefTable, _ = ns.Table(fk.RefTable.Name)
+		// References to tables in other schemas are kept as is.
+		if nfk.RefTable == nil {
+			nfk.RefTable = fk.RefTable
+			c.report("foreign key "+t.Name+"."+fk.Symbol, "references table %q that is not part of the converted schema", fk.RefTable.Name)
+		}
+		for _, col := range fk.Columns {
+			nc, _ := nt.Column(col.Name)
+			nc.ForeignKeys = append(nc.ForeignKeys, nfk)
+			nfk.Columns = append(nfk.Columns, nc)
+		}
+		for _, col := range fk.RefColumns {
+			nc, ok := nfk.RefTable.Column(col.Name)
+			if !ok {
+				nc = col
+			}
+			nfk.RefColumns = append(nfk.RefColumns, nc)
+		}
+		for _, a := range fk.Attrs {
+			c.dropAttr(a, "foreign key "+t.Name+"."+fk.Symbol)
+		}
+		nt.ForeignKeys = append(nt.ForeignKeys, nfk)
+	}
+}
+
+// typ converts the type of the given column to the target dialect.
+func (c *converter) typ(t schema.Type, elem string, tb *schema.Table, col *schema.Column) schema.Type {
+	switch t := t.(type) {
+	case *schema.IntegerType:
+		return c.integer(intSize(t.T), t.Unsigned, elem)
+	case *postgres.SerialType:
+		return c.integer(serialSize(t.T), false, elem)
+	case *schema.BoolType:
+		return &schema.BoolType{T: map[string]string{"mysql": "bool", "postgres": "boolean", "sqlite": "boolean"}[c.to]}
+	case *schema.StringType:
+		return c.str(t)
+	case *schema.BinaryType:
+		switch {
+		case c.to == "postgres":
+			return &schema.BinaryType{T: "bytea"}
+		case c.to == "sqlite":
+			return &schema.BinaryType{T: "blob"}
+		case c.from == "mysql":
+			return t
+		default:
+			return &schema.BinaryType{T: "longblob"}
+		}
+	case *schema.DecimalType:
+		return &schema.DecimalType{T: "decimal", Precision: t.Precision, Scale: t.Scale}
+	case *schema.FloatType:
+		return c.float(t)
+	case *schema.TimeType:
+		return c.time(t, elem)
+	case *schema.JSONType:
+		return &schema.JSONType{T: map[string]string{"mysql": "json", "postgres": "jsonb", "sqlite": "json"}[c.to]}
+	case *schema.EnumType:
+		return c.enum(t.Values, elem, tb, col)
+	case *mysql.SetType:
+		if c.to == "postgres" {
+			c.report(elem, "SET values are not enforced by postgres, converted to text[]")
+			return &postgres.ArrayType{T: "text[]"}
+		}
+		c.report(elem, "SET values are not enforced by %s, converted to text", c.to)
+		return &schema.StringType{T: "text"}
+	case *mysql.BitType:
+		switch c.to {
+		case "postgres":
+			return &postgres.BitType{T: "bit varying"}
+		default:
+			return &schema.IntegerType{T: "integer"}
+		}
+	case *postgres.BitType:
+		if c.to == "mysql" && t.Len <= 64 && !strings.Contains(t.T, "varying") {
+			return &mysql.BitType{T: "bit"}
+		}
+		if c.to == "mysql" {
+			c.report(elem, "%s(%d) exceeds the maximum size of BIT in mysql, converted to varbinary", t.T, t.Len)
+			return &schema.BinaryType{T: "varbinary", Size: int(t.Len+7) / 8}
+		}
+		return &schema.BinaryType{T: "blob"}
+	case *postgres.CurrencyType:
+		return &schema.DecimalType{T: "decimal", Precision: 19, Scale: 2}
+	case *postgres.NetworkType:
+		// Network addresses are stored in their textual form.
+		size := map[string]int{"macaddr": 17, "macaddr8": 23}[t.T]
+		if size == 0 {
+			size = 43
+		}
+		return c.str(&schema.StringType{T: "varchar", Size: size})
+	case *postgres.UUIDType, *sqlite.UUIDType:
+		switch c.to {
+		case "postgres":
+			return &postgres.UUIDType{T: "uuid"}
+		case "sqlite":
+			return &sqlite.UUIDType{T: "uuid"}
+		default:
+			return &schema.StringType{T: "char", Size: 36}
+		}
+	case *postgres.XMLType:
+		return c.str(&schema.StringType{T: "text"})
+	case *postgres.ArrayType:
+		c.report(elem, "array type %s is not supported by %s, converted to json", t.T, c.to)
+		return &schema.JSONType{T: "json"}
+	case *postgres.DomainType:
+		if t.Base != nil {
+			c.report(elem, "domain type %q is not supported by %s, converted to its base type", t.T, c.to)
+			return c.typ(t.Base, elem, tb, col)
+		}
+		c.report(elem, "domain type %q is not supported by %s", t.T, c.to)
+		return &schema.UnsupportedType{T: t.T}
+	case *schema.SpatialType:
+		c.report(elem, "spatial type %s has no equivalent in %s, kept as is", t.T, c.to)
+		return t
+	case *schema.UnsupportedType:
+		c.report(elem, "type %s is not supported by %s, kept as is", t.T, c.to)
+		return &schema.UnsupportedType{T: t.T}
+	default:
+		name := fmt.Sprintf("%T", t)
+		if f, err := formatType(c.from, t); err == nil {
+			name = f
+		}
+		c.report(elem, "type %s is not supported by %s, kept as is", name, c.to)
+		return &schema.UnsupportedType{T: name}
+	}
+}
+
+// intSize returns the byte-size of the given integer type.
+func intSize(t string) int {
+	switch strings.ToLower(t) {
+	case "tinyint":
+		return 1
+	case "smallint", "int2":
+		return 2
+	case "mediumint":
+		return 3
+	case "bigint", "int8", "unsigned big int":
+		return 8
+	default:
+		return 4
+	}
+}
+
+// serialSize returns the byte-size of the given serial type.
+func serialSize(t string) int {
+	switch strings.ToLower(t) {
+	case "smallserial", "serial2":
+		return 2
+	case "bigserial", "serial8":
+		return 8
+	default:
+		return 4
+	}
+}
+
+func (c *converter) integer(size int, unsigned bool, elem string) schema.Type {
+	switch c.to {
+	case "mysql":
+		t := map[int]string{1: "tinyint", 2: "smallint", 3: "mediumint", 4: "int", 8: "bigint"}[size]
+		return &schema.IntegerType{T: t, Unsigned: unsigned}
+	case "postgres":
+		// Unsigned integers are mapped to the next signed type that can hold their values.
+		if unsigned {
+			size *= 2
+		}
+		switch {
+		case size <= 2:
+			return &schema.IntegerType{T: "smallint"}
+		case size <= 4:
+			return &schema.IntegerType{T: "integer"}
+		case size <= 8:
+			return &schema.IntegerType{T: "bigint"}
+		default:
+			return &schema.DecimalType{T: "decimal", Precision: 20}
+		}
+	default:
+		if unsigned && size == 8 {
+			c.report(elem, "unsigned 64-bit integers may overflow the sqlite INTEGER type")
+		}
+		return &schema.IntegerType{T: "integer"}
+	}
+}
+
+func (c *converter) str(t *schema.StringType) schema.Type {
+	var kind string
+	switch strings.ToLower(t.T) {
+	case "char", "character", "nchar", "native character":
+		kind = "char"
+	case "varchar", "character varying", "varying character", "nvarchar":
+		kind = "varchar"
+	default:
+		kind = "text"
+	}
+	switch c.to {
+	case "mysql":
+		switch {
+		case kind == "char" && t.Size <= 255:
+			return &schema.StringType{T: "char", Size: t.Size}
+		case kind != "text" && t.Size > 0 && t.Size <= 16383:
+			// The maximum size of VARCHAR in utf8mb4 is 16,383 characters.
+			return &schema.StringType{T: "varchar", Size: t.Size}
+		case kind != "text" && t.Size > 0 && t.Size <= 1<<24-1:
+			return &schema.StringType{T: "mediumtext"}
+		case kind == "text" && c.from == "mysql":
+			return &schema.StringType{T: strings.ToLower(t.T)}
+		default:
+			return &schema.StringType{T: "longtext"}
+		}
+	case "postgres":
+		switch {
+		case kind == "char":
+			return &schema.StringType{T: "character", Size: t.Size}
+		case kind == "varchar" && t.Size > 0:
+			return &schema.StringType{T: "character varying", Size: t.Size}
+		default:
+			return &schema.StringType{T: "text"}
+		}
+	default:
+		return &schema.StringType{T: "text", Size: t.Size}
+	}
+}
+
+func (c *converter) float(t *schema.FloatType) schema.Type {
+	var double bool
+	switch strings.ToLower(t.T) {
+	case "double", "double precision", "float8":
+		double = true
+	case "real":
+		// REAL is a double-precision type in MySQL and SQLite.
+		double = c.from != "postgres"
+	default:
+		double = t.Precision > 24
+	}
+	switch {
+	case c.to == "sqlite":
+		return &schema.FloatType{T: "real"}
+	case c.to == "mysql" && double:
+		return &schema.FloatType{T: "double"}
+	case c.to == "mysql":
+		return &schema.FloatType{T: "float"}
+	case double:
+		return &schema.FloatType{T: "double precision"}
+	default:
+		return &schema.FloatType{T: "real"}
+	}
+}
+
+func (c *converter) time(t *schema.TimeType, elem string) schema.Type {
+	var kind string
+	switch f := strings.ToLower(t.T); f {
+	case "date":
+		kind = "date"
+	case "time", "time without time zone":
+		kind = "time"
+	case "time with time zone":
+		kind = "time"
+		if c.to != "postgres" {
+			c.report(elem, "%s is not supported by %s, converted to time", f, c.to)
+		}
+	case "datetime", "timestamp without time zone":
+		kind = "datetime"
+	case "timestamp":
+		// MySQL TIMESTAMP values are converted to UTC for
+		// storage, and PostgreSQL TIMESTAMP values are not.
+		kind = "datetime"
+		if c.from == "mysql" {
+			kind = "timestamptz"
+		}
+	case "timestamptz", "timestamp with time zone":
+		kind = "timestamptz"
+	case "year":
+		c.report(elem, "YEAR is not supported by %s, converted to an integer", c.to)
+		return c.integer(2, false, elem)
+	default:
+		c.report(elem, "type %s is not supported by %s, kept as is", f, c.to)
+		return &schema.TimeType{T: f}
+	}
+	switch c.to {
+	case "mysql":
+		return &schema.TimeType{T: map[string]string{"date": "date", "time": "time", "datetime": "datetime", "timestamptz": "timestamp"}[kind]}
+	case "postgres":
+		return &schema.TimeType{T: map[string]string{"date": "date", "time": "time", "datetime": "timestamp", "timestamptz": "timestamptz"}[kind]}
+	default:
+		return &schema.TimeType{T: map[string]string{"date": "date", "time": "time", "datetime": "datetime", "timestamptz": "datetime"}[kind]}
+	}
+}
+
+func (c *converter) enum(values []string, elem string, t *schema.Table, col *schema.Column) schema.Type {
+	switch c.to {
+	case "mysql":
+		return &schema.EnumType{Values: values}
+	case "postgres":
+		// PostgreSQL enums are named types that are
+		// created separately from the table.
+		return &schema.EnumType{T: t.Name + "_" + col.Name, Values: values}
+	default:
+		c.report(elem, "ENUM values are not enforced by sqlite, converted to text")
+		return &schema.StringType{T: "text"}
+	}
+}
+
+// formatType formats the type using the formatter of the given dialect.
+func formatType(dialect string, t schema.Type) (string, error) {
+	switch dialect {
+	case "mysql":
+		return mysql.FormatType(t)
+	case "postgres":
+		return postgres.FormatType(t)
+	default:
+		return sqlite.FormatType(t)
+	}
+}
+
+// hasAutoIncrement reports if the given SQLite column is an auto-increment column.
+func hasAutoIncrement(c *schema.Column) bool {
+	for _, a := range c.Attrs {
+		if _, ok := a.(*sqlite.AutoIncrement); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package convert
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSchema_MySQLToPostgres(t *testing.T) {
+	users := &schema.Table{
+		Name: "users",
+		Attrs: []schema.Attr{
+			&schema.Charset{V: "utf8mb4"},
+			&mysql.AutoIncrement{V: 100},
+			&mysql.Engine{V: "InnoDB"},
+			&mysql.Partition{T: "HASH"},
+			&schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}},
+		},
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int", Unsigned: true}}, Attrs: []schema.Attr{&mysql.AutoIncrement{}}},
+			{Name: "status", Type: &schema.ColumnType{Type: &schema.EnumType{Values: []string{"active", "inactive"}}}},
+			{Name: "tags", Type: &schema.ColumnType{Type: &mysql.SetType{Values: []string{"a", "b"}}}},
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&schema.Collation{V: "utf8mb4_bin"}}},
+		},
+	}
+	users.PrimaryKey = &schema.Index{Parts: []*schema.IndexPart{{C: users.Columns[0]}}}
+	s := &schema.Schema{Name: "test", Tables: []*schema.Table{users}}
+	users.Schema = s
+
+	cs, issues, err := Schema(s, "mysql", "postgres")
+	require.NoError(t, err)
+	require.Len(t, cs.Tables, 1)
+	nt := cs.Tables[0]
+	require.Empty(t, nt.Attrs)
+	require.Equal(t, &schema.IntegerType{T: "bigint"}, nt.Columns[0].Type.Type)
+	require.Equal(t, []schema.Attr{&postgres.Identity{Generation: "BY DEFAULT", Start: 100}}, nt.Columns[0].Attrs)
+	require.Equal(t, &schema.EnumType{T: "users_status", Values: []string{"active", "inactive"}}, nt.Columns[1].Type.Type)
+	require.Equal(t, &schema.TimeType{T: "timestamptz"}, nt.Columns[3].Type.Type)
+	require.Empty(t, nt.Columns[3].Attrs)
+	require.Equal(t, nt.Columns[0], nt.PrimaryKey.Parts[0].C)
+	require.Contains(t, issues, "column users.tags: SET values are not enforced by postgres, converted to text[]")
+	require.Contains(t, issues, "charset attributes cannot be mapped to postgres and were dropped from 1 element(s)")
+	require.Contains(t, issues, "collation attributes cannot be mapped to postgres and were dropped from 1 element(s)")
+	require.Contains(t, issues, "table option attributes cannot be mapped to postgres and were dropped from 1 element(s)")
+	require.Contains(t, issues, "partitioning attributes cannot be mapped to postgres and were dropped from 1 element(s)")
+	require.Contains(t, issues, "grant attributes cannot be mapped to postgres and were dropped from 1 element(s)")
+
+	_, _, err = Schema(s, "mysql", "mysql")
+	require.Error(t, err)
+	_, _, err = Schema(s, "mysql", "oracle")
+	require.Error(t, err)
+}
+
+func TestConvertSchema_PostgresToSQLite(t *testing.T) {
+	pets := &schema.Table{
+		Name: "pets",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &postgres.SerialType{T: "bigserial"}}},
+			{Name: "owner_id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+			{Name: "data", Type: &schema.ColumnType{Type: &schema.JSONType{T: "jsonb"}}},
+			{Name: "active", Type: &schema.ColumnType{Type: &schema.BoolType{T: "boolean"}}, Default: &schema.RawExpr{X: "true"}},
+		},
+	}
+	pets.PrimaryKey = &schema.Index{Parts: []*schema.IndexPart{{C: pets.Columns[0]}}}
+	pets.Indexes = []*schema.Index{
+		{Name: "idx_owner", Table: pets, Parts: []*schema.IndexPart{{C: pets.Columns[1]}}, Attrs: []schema.Attr{&postgres.IndexPredicate{P: "active"}}},
+		{Name: "pets_owner_key", Unique: true, Table: pets, Parts: []*schema.IndexPart{{C: pets.Columns[1]}}, Attrs: []schema.Attr{&postgres.ConType{T: "u"}, &postgres.IndexInclude{Columns: pets.Columns[:1]}}},
+	}
+	pets.Attrs = []schema.Attr{&postgres.Exclude{Name: "one_active", T: "gist", Elems: []*postgres.ExcludeElem{{C: pets.Columns[1], Op: "="}}, Where: "active"}}
+	s := &schema.Schema{Name: "public", Tables: []*schema.Table{pets}, Attrs: []schema.Attr{&postgres.Extension{Name: "pgcrypto"}}}
+	pets.Schema = s
+
+	cs, issues, err := Schema(s, "postgres", "sqlite")
+	require.NoError(t, err)
+	require.Contains(t, issues, `schema public: extension "pgcrypto" is not supported by sqlite`)
+	require.Contains(t, issues, `table pets: exclusion constraint "one_active" is not supported by sqlite`)
+	require.Contains(t, issues, `index pets.pets_owner_key: included columns are not supported by sqlite`)
+	nt := cs.Tables[0]
+	require.Equal(t, &schema.IntegerType{T: "integer"}, nt.Columns[0].Type.Type)
+	require.Equal(t, []schema.Attr{&sqlite.AutoIncrement{}}, nt.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&sqlite.IndexPredicate{P: "active"}}, nt.Indexes[0].Attrs)
+	require.Equal(t, nt, nt.Indexes[0].Table)
+}
@@ -7,9 +7,11 @@ package specutil
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlspec"
 )
@@ -38,14 +40,14 @@ func Schema(spec *sqlspec.Schema, tables []*sqlspec.Table, convertTable ConvertT
 	for _, ts := range tables {
 		table, err := convertTable(ts, sch)
 		if err != nil {
-			return nil, err
+			return nil, schemaspec.WithPos(ts.Range(), err)
 		}
 		sch.Tables = append(sch.Tables, table)
 		m[table] = ts
 	}
 	for _, tbl := range sch.Tables {
 		if err := linkForeignKeys(tbl, sch, m[tbl]); err != nil {
-			return nil, err
+			return nil, schemaspec.WithPos(m[tbl].Range(), err)
 		}
 	}
 	return sch, nil
@@ -63,21 +65,21 @@ func Table(spec *sqlspec.Table, parent *schema.Schema, convertColumn ConvertColu
 	for _, csp := range spec.Columns {
 		col, err := convertColumn(csp, tbl)
 		if err != nil {
-			return nil, err
+			return nil, schemaspec.WithPos(csp.Range(), err)
 		}
 		tbl.Columns = append(tbl.Columns, col)
 	}
 	if spec.PrimaryKey != nil {
 		pk, err := convertPk(spec.PrimaryKey, tbl)
 		if err != nil {
-			return nil, err
+			return nil, schemaspec.WithPos(spec.PrimaryKey.Range(), err)
 		}
 		tbl.PrimaryKey = pk
 	}
 	for _, idx := range spec.Indexes {
 		i, err := convertIndex(idx, tbl)
 		if err != nil {
-			return nil, err
+			return nil, schemaspec.WithPos(idx.Range(), err)
 		}
 		tbl.Indexes = append(tbl.Indexes, i)
 	}
@@ -95,9 +97,15 @@ func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error)
 	if spec.Default != nil {
 		out.Default = &schema.Literal{V: spec.Default.V}
 	}
+	if spec.As != nil {
+		out.Attrs = append(out.Attrs, &schema.GeneratedExpr{
+			Expr: spec.As.Expr,
+			Type: strings.ToUpper(spec.As.Type),
+		})
+	}
 	ct, err := conv(spec)
 	if err != nil {
-		return nil, err
+		return nil, schemaspec.WithPos(spec.AttrRange("type"), err)
 	}
 	out.Type.Type = ct
 	return out, err
@@ -109,11 +117,11 @@ func Index(spec *sqlspec.Index, parent *schema.Table) (*schema.Index, error) {
 	for seqno, c := range spec.Columns {
 		cn, err := columnName(c)
 		if err != nil {
-			return nil, fmt.Errorf("specutil: failed converting column to index: %w", err)
+			return nil, schemaspec.WithPos(spec.AttrRange("columns"), fmt.Errorf("specutil: failed converting column to index: %w", err))
 		}
 		col, ok := parent.Column(cn)
 		if !ok {
-			return nil, fmt.Errorf("specutil: unknown column %q in table %q", cn, parent.Name)
+			return nil, schemaspec.WithPos(spec.AttrRange("columns"), fmt.Errorf("specutil: unknown column %q in table %q", cn, parent.Name))
 		}
 		parts = append(parts, &schema.IndexPart{
 			SeqNo: seqno,
@@ -134,11 +142,11 @@ func PrimaryKey(spec *sqlspec.PrimaryKey, parent *schema.Table) (*schema.Index,
 	for seqno, c := range spec.Columns {
 		n, err := columnName(c)
 		if err != nil {
-			return nil, fmt.Errorf("sqlspec: cannot get column name %q as primary key for table %q", c.V, parent.Name)
+			return nil, schemaspec.WithPos(spec.AttrRange("columns"), fmt.Errorf("sqlspec: cannot get column name %q as primary key for table %q", c.V, parent.Name))
 		}
 		pkc, ok := parent.Column(n)
 		if !ok {
-			return nil, fmt.Errorf("sqlspec: cannot set column %q as primary key for table %q", n, parent.Name)
+			return nil, schemaspec.WithPos(spec.AttrRange("columns"), fmt.Errorf("sqlspec: cannot set column %q as primary key for table %q", n, parent.Name))
 		}
 		parts = append(parts, &schema.IndexPart{
 			SeqNo: seqno,
@@ -165,26 +173,26 @@ func linkForeignKeys(tbl *schema.Table, sch *schema.Schema, table *sqlspec.Table
 		for _, ref := range spec.Columns {
 			col, err := resolveCol(ref, sch)
 			if err != nil {
-				return err
+				return schemaspec.WithPos(spec.AttrRange("columns"), err)
 			}
 			fk.Columns = append(fk.Columns, col)
 		}
 		if len(spec.RefColumns) == 0 {
-			return fmt.Errorf("sqlspec: missing reference (parent) columns for foreign key: %q", spec.Symbol)
+			return schemaspec.WithPos(spec.Range(), fmt.Errorf("sqlspec: missing reference (parent) columns for foreign key: %q", spec.Symbol))
 		}
 		name, err := tableName(spec.RefColumns[0])
 		if err != nil {
-			return err
+			return schemaspec.WithPos(spec.AttrRange("ref_columns"), err)
 		}
 		t, ok := sch.Table(name)
 		if !ok {
-			return fmt.Errorf("sqlspec: undefined table %q for foreign key: %q", name, spec.Symbol)
+			return schemaspec.WithPos(spec.AttrRange("ref_columns"), fmt.Errorf("sqlspec: undefined table %q for foreign key: %q", name, spec.Symbol))
 		}
 		fk.RefTable = t
 		for _, ref := range spec.RefColumns {
 			col, err := resolveCol(ref, sch)
 			if err != nil {
-				return err
+				return schemaspec.WithPos(spec.AttrRange("ref_columns"), err)
 			}
 			fk.RefColumns = append(fk.RefColumns, col)
 		}
@@ -225,7 +233,7 @@ func FromSchema(s *schema.Schema, fn TableSpecFunc) (*sqlspec.Schema, []*sqlspec
 			return nil, nil, err
 		}
 		if s.Name != "" {
-			table.Schema = schemaRef(s.Name)
+			table.Schema = SchemaRef(s.Name)
 		}
 		tables = append(tables, table)
 	}
@@ -268,6 +276,24 @@ func FromTable(t *schema.Table, colFn ColumnSpecFunc, pkFn PrimaryKeySpecFunc, i
 	return spec, nil
 }
 
+// FromGeneratedExpr converts the schema.GeneratedExpr attribute of the
+// column (if exists) to a sqlspec.GeneratedExpr. The def argument holds
+// the default generation type of the database, and it is used in case
+// the type was not set on the attribute.
+func FromGeneratedExpr(c *schema.Column, def string) *sqlspec.GeneratedExpr {
+	var x schema.GeneratedExpr
+	if !sqlx.Has(c.Attrs, &x) {
+		return nil
+	}
+	if x.Type == "" {
+		x.Type = def
+	}
+	return &sqlspec.GeneratedExpr{
+		Expr: x.Expr,
+		Type: x.Type,
+	}
+}
+
 // FromPrimaryKey converts schema.Index to a sqlspec.PrimaryKey.
 func FromPrimaryKey(s *schema.Index) (*sqlspec.PrimaryKey, error) {
 	c := make([]*schemaspec.Ref, 0, len(s.Parts))
@@ -314,6 +340,24 @@ func FromForeignKey(s *schema.ForeignKey) (*sqlspec.ForeignKey, error) {
 	}, nil
 }
 
+// ColumnRef returns a reference to the given column of the table.
+func ColumnRef(c *schema.Column, t *schema.Table) *schemaspec.Ref {
+	return colRef(c.Name, t.Name)
+}
+
+// ColumnByRef returns the column of the table that is referenced by the given ref.
+func ColumnByRef(t *schema.Table, ref *schemaspec.Ref) (*schema.Column, error) {
+	cn, err := columnName(ref)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := t.Column(cn)
+	if !ok {
+		return nil, fmt.Errorf("specutil: unknown column %q in table %q", cn, t.Name)
+	}
+	return c, nil
+}
+
 func columnName(ref *schemaspec.Ref) (string, error) {
 	s := strings.Split(ref.V, "$column.")
 	if len(s) != 2 {
@@ -343,6 +387,135 @@ func colRef(cName string, tName string) *schemaspec.Ref {
 	}
 }
 
-func schemaRef(n string) *schemaspec.Ref {
+// SchemaRef returns a reference to the schema with the given name.
+func SchemaRef(n string) *schemaspec.Ref {
 	return &schemaspec.Ref{V: "$schema." + n}
 }
+
+// SchemaName returns the name of the schema that is referenced by the given ref.
+func SchemaName(ref *schemaspec.Ref) (string, error) {
+	s := strings.Split(ref.V, "$schema.")
+	if len(s) != 2 || s[0] != "" || s[1] == "" {
+		return "", fmt.Errorf("sqlspec: failed to extract schema name from %q", ref.V)
+	}
+	return s[1], nil
+}
+
+// Grants converts the "grant" resources of a schema or a table into schema.Grant attributes.
+// The t argument is nil for schema grants, as column-level privileges are defined only on tables.
+func Grants(r *schemaspec.Resource, t *schema.Table) ([]schema.Attr, error) {
+	var attrs []schema.Attr
+	for _, c := range r.Children {
+		if c.Type != "grant" {
+			continue
+		}
+		var spec sqlspec.Grant
+		if err := c.As(&spec); err != nil {
+			return nil, err
+		}
+		if len(spec.Privileges) == 0 {
+			return nil, fmt.Errorf("specutil: missing privileges for grantee %q", spec.Grantee)
+		}
+		g := &schema.Grant{Grantee: spec.Grantee, Privileges: spec.Privileges, GrantOption: spec.GrantOption}
+		for _, ref := range spec.Columns {
+			if t == nil {
+				return nil, fmt.Errorf("specutil: unexpected columns in schema grant of grantee %q", spec.Grantee)
+			}
+			col, err := ColumnByRef(t, ref)
+			if err != nil {
+				return nil, err
+			}
+			g.Columns = append(g.Columns, col)
+		}
+		attrs = append(attrs, g)
+	}
+	return attrs, nil
+}
+
+// FromGrants converts the schema.Grant attributes of a schema or
+// a table into "grant" resources. The t argument is nil for schemas.
+func FromGrants(attrs []schema.Attr, t *schema.Table) []*schemaspec.Resource {
+	var rs []*schemaspec.Resource
+	for _, a := range attrs {
+		g, ok := a.(*schema.Grant)
+		if !ok {
+			continue
+		}
+		r := &schemaspec.Resource{Type: "grant", Name: g.Grantee}
+		r.SetAttr(ListAttr("privileges", quote(g.Privileges)...))
+		if t != nil && len(g.Columns) > 0 {
+			columns := make([]schemaspec.Value, len(g.Columns))
+			for i, c := range g.Columns {
+				columns[i] = ColumnRef(c, t)
+			}
+			r.SetAttr(&schemaspec.Attr{K: "columns", V: &schemaspec.ListValue{V: columns}})
+		}
+		if g.GrantOption {
+			r.SetAttr(LitAttr("grant_option", "true"))
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// Roles converts the role specs of a document into schema.Role attributes.
+func Roles(specs []*sqlspec.Role) []schema.Attr {
+	attrs := make([]schema.Attr, 0, len(specs))
+	for _, s := range specs {
+		attrs = append(attrs, &schema.Role{Name: s.Name})
+	}
+	return attrs
+}
+
+// FromRoles returns the role specs of the given schema. Roles that were defined on the
+// schema are returned, as well as roles of its realm that privileges were granted to.
+func FromRoles(s *schema.Schema) []*sqlspec.Role {
+	var (
+		specs []*sqlspec.Role
+		names = make(map[string]bool)
+	)
+	add := func(name string) {
+		if !names[name] {
+			names[name] = true
+			specs = append(specs, &sqlspec.Role{Name: name})
+		}
+	}
+	for _, a := range s.Attrs {
+		if r, ok := a.(*schema.Role); ok {
+			add(r.Name)
+		}
+	}
+	if s.Realm == nil {
+		return specs
+	}
+	grantees := make(map[string]bool)
+	for _, attrs := range append([][]schema.Attr{s.Attrs}, tableAttrs(s)...) {
+		for _, a := range attrs {
+			if g, ok := a.(*schema.Grant); ok {
+				grantees[g.Grantee] = true
+			}
+		}
+	}
+	for _, a := range s.Realm.Attrs {
+		if r, ok := a.(*schema.Role); ok && grantees[r.Name] {
+			add(r.Name)
+		}
+	}
+	return specs
+}
+
+func tableAttrs(s *schema.Schema) [][]schema.Attr {
+	attrs := make([][]schema.Attr, len(s.Tables))
+	for i, t := range s.Tables {
+		attrs[i] = t.Attrs
+	}
+	return attrs
+}
+
+func quote(ss []string) []string {
+	q := make([]string, len(ss))
+	for i := range ss {
+		q[i] = strconv.Quote(ss[i])
+	}
+	return q
+}
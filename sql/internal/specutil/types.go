@@ -113,6 +113,36 @@ func (r *TypeRegistry) Find(t string) (*schemaspec.TypeSpec, bool) {
 	return nil, false
 }
 
+// Suggest returns the registered type name that is closest to the given (unknown)
+// name, if there is one that is close enough to be considered a typo.
+func (r *TypeRegistry) Suggest(name string) (string, bool) {
+	// Allow one edit, plus one for every three characters.
+	return r.closest(name, len(name)/3+1)
+}
+
+// Typo returns the registered type name that is a single edit away from the given
+// name. Unlike Suggest, it is strict enough to tell typos apart from types that are
+// not known to the registry (e.g. types that are installed by extensions).
+func (r *TypeRegistry) Typo(name string) (string, bool) {
+	return r.closest(name, 1)
+}
+
+// closest returns the registered type name that is closest to
+// the given name, and at most max edits away from it.
+func (r *TypeRegistry) closest(name string, max int) (string, bool) {
+	var (
+		best string
+		min  = max + 1
+	)
+	name = strings.ToLower(name)
+	for _, current := range r.r {
+		if d := distance(name, strings.ToLower(current.T)); d > 0 && d < min {
+			best, min = current.T, d
+		}
+	}
+	return best, best != ""
+}
+
 // Convert converts the schema.Type to a *schemaspec.Type.
 func (r *TypeRegistry) Convert(typ schema.Type) (*schemaspec.Type, error) {
 	s := &schemaspec.Type{}
@@ -262,3 +292,33 @@ func appendIfNotExist(base []*schemaspec.Attr, additional []*schemaspec.Attr) []
 	}
 	return base
 }
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func minInt(x int, xs ...int) int {
+	for _, y := range xs {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
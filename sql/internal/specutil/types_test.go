@@ -67,6 +67,30 @@ func TestRegistry(t *testing.T) {
 	require.EqualValues(t, spec, text)
 }
 
+func TestRegistry_Suggest(t *testing.T) {
+	r := NewRegistry(
+		&schemaspec.TypeSpec{Name: "varchar", T: "varchar"},
+		&schemaspec.TypeSpec{Name: "int", T: "int"},
+		&schemaspec.TypeSpec{Name: "text", T: "text"},
+	)
+	s, ok := r.Suggest("varchr")
+	require.True(t, ok)
+	require.Equal(t, "varchar", s)
+	s, ok = r.Suggest("INTT")
+	require.True(t, ok)
+	require.Equal(t, "int", s)
+	_, ok = r.Suggest("geometry")
+	require.False(t, ok)
+
+	s, ok = r.Typo("varchr")
+	require.True(t, ok)
+	require.Equal(t, "varchar", s)
+	_, ok = r.Typo("citext")
+	require.False(t, ok)
+	_, ok = r.Typo("text")
+	require.False(t, ok)
+}
+
 func TestRegistryConvert(t *testing.T) {
 	r := &TypeRegistry{}
 	err := r.Register(
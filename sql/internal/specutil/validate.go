@@ -0,0 +1,240 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package specutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlspec"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+type (
+	// ValidateOptions holds the driver functions that are used by Validate.
+	ValidateOptions struct {
+		// ConvertColumn converts a column spec using the driver conversion.
+		ConvertColumn ConvertColumnFunc
+		// Registry holds the column types of the driver. If nil,
+		// column types are not checked against the registry.
+		Registry *TypeRegistry
+		// Unknown reports if a converted column type was not recognized by the driver
+		// conversion, and therefore should be checked against the registry. If nil,
+		// only schema.UnsupportedType is considered as unknown.
+		Unknown func(schema.Type) bool
+		// Compatible reports if a foreign-key column of type t1 can reference
+		// a column of type t2. If nil, the types are not checked.
+		Compatible func(t1, t2 schema.Type) bool
+		// EvalErr holds the evaluation error of the document, if any. Its
+		// diagnostics are reported before the errors found by Validate.
+		EvalErr error
+	}
+
+	// ValidationErrors holds the errors found by Validate.
+	ValidationErrors []error
+)
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i := range e {
+		lines[i] = e[i].Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate validates the given schema and table specs without a database connection, and
+// returns all errors that were found in them as ValidationErrors, instead of failing on the
+// first one. Columns are converted using the driver conversion, and then checked for missing
+// references, duplicate names, unknown types and incompatible foreign keys.
+func Validate(schemas []*sqlspec.Schema, tables []*sqlspec.Table, opts ValidateOptions) error {
+	var (
+		errs   ValidationErrors
+		report = func(r *hcl.Range, format string, args ...interface{}) {
+			errs = append(errs, schemaspec.WithPos(r, fmt.Errorf(format, args...)))
+		}
+		names = make(map[string]bool)
+		sch   = &schema.Schema{}
+		specs = make(map[*schema.Table]*sqlspec.Table)
+		types = make(map[*schema.Column]string)
+	)
+	if diags, ok := evalDiags(opts.EvalErr); ok {
+		for _, d := range diags {
+			errs = append(errs, d)
+		}
+	} else if opts.EvalErr != nil {
+		errs = append(errs, opts.EvalErr)
+	}
+	for _, s := range schemas {
+		if names[s.Name] {
+			report(s.Range(), "duplicate schema %q", s.Name)
+		}
+		names[s.Name] = true
+	}
+	names = make(map[string]bool)
+	for _, ts := range tables {
+		if names[ts.Name] {
+			report(ts.Range(), "duplicate table %q", ts.Name)
+			continue
+		}
+		names[ts.Name] = true
+		t := &schema.Table{Name: ts.Name, Schema: sch}
+		for _, cs := range ts.Columns {
+			if _, ok := t.Column(cs.Name); ok {
+				report(cs.Range(), "duplicate column %q in table %q", cs.Name, ts.Name)
+				continue
+			}
+			c, err := opts.ConvertColumn(cs, t)
+			if err != nil {
+				report(cs.AttrRange("type"), "%v", err)
+				// Register the column to avoid reporting it as missing.
+				c = &schema.Column{Name: cs.Name, Type: &schema.ColumnType{}}
+			} else if !opts.knownType(c.Type.Type, cs.Type) {
+				msg := fmt.Sprintf("unknown type %q for column %s.%s", cs.Type, ts.Name, cs.Name)
+				if s, ok := opts.Registry.Suggest(typeName(cs.Type)); ok {
+					msg += fmt.Sprintf(", did you mean %q?", s)
+				}
+				report(cs.AttrRange("type"), "%s", msg)
+			}
+			t.Columns = append(t.Columns, c)
+			types[c] = cs.Type
+		}
+		if pk := ts.PrimaryKey; pk != nil {
+			for _, ref := range pk.Columns {
+				name, err := columnName(ref)
+				if err != nil {
+					report(pk.AttrRange("columns"), "invalid primary key column %q in table %q", ref.V, ts.Name)
+					continue
+				}
+				c, ok := t.Column(name)
+				switch {
+				case !ok:
+					report(pk.AttrRange("columns"), "primary key of table %q references unknown column %q", ts.Name, name)
+				case c.Type.Null:
+					report(pk.AttrRange("columns"), "primary key of table %q contains the nullable column %q", ts.Name, name)
+				}
+			}
+		}
+		indexes := make(map[string]bool)
+		for _, idx := range ts.Indexes {
+			if indexes[idx.Name] {
+				report(idx.Range(), "duplicate index %q in table %q", idx.Name, ts.Name)
+			}
+			indexes[idx.Name] = true
+			for _, ref := range idx.Columns {
+				name, err := columnName(ref)
+				if err != nil {
+					report(idx.AttrRange("columns"), "invalid column %q in index %q", ref.V, idx.Name)
+					continue
+				}
+				if _, ok := t.Column(name); !ok {
+					report(idx.AttrRange("columns"), "index %q of table %q references unknown column %q", idx.Name, ts.Name, name)
+				}
+			}
+		}
+		sch.Tables = append(sch.Tables, t)
+		specs[t] = ts
+	}
+	for _, t := range sch.Tables {
+		symbols := make(map[string]bool)
+		for _, fk := range specs[t].ForeignKeys {
+			if symbols[fk.Symbol] {
+				report(fk.Range(), "duplicate foreign key %q in table %q", fk.Symbol, t.Name)
+			}
+			symbols[fk.Symbol] = true
+			columns := validateRefs(fk.Columns, sch, func(err error) { report(fk.AttrRange("columns"), "foreign key %q: %v", fk.Symbol, err) })
+			refColumns := validateRefs(fk.RefColumns, sch, func(err error) { report(fk.AttrRange("ref_columns"), "foreign key %q: %v", fk.Symbol, err) })
+			switch {
+			case len(fk.RefColumns) == 0:
+				report(fk.Range(), "missing reference (parent) columns for foreign key %q", fk.Symbol)
+			case len(fk.Columns) != len(fk.RefColumns):
+				report(fk.AttrRange("ref_columns"), "foreign key %q has %d columns, but references %d columns", fk.Symbol, len(fk.Columns), len(fk.RefColumns))
+			case opts.Compatible != nil && len(columns) == len(refColumns):
+				for i := range columns {
+					c1, c2 := columns[i], refColumns[i]
+					if c1 == nil || c2 == nil || c1.Type.Type == nil || c2.Type.Type == nil {
+						continue
+					}
+					if !opts.Compatible(c1.Type.Type, c2.Type.Type) {
+						report(fk.AttrRange("ref_columns"), "foreign key %q: column %q of type %q is incompatible with the referenced column %q of type %q",
+							fk.Symbol, c1.Name, types[c1], c2.Name, types[c2])
+					}
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Partial reports if the given unmarshal error holds evaluation diagnostics of a document
+// that was partially decoded, and therefore, can still be validated with Validate.
+func Partial(err error, schemas []*sqlspec.Schema) bool {
+	_, ok := evalDiags(err)
+	return ok && len(schemas) > 0
+}
+
+// evalDiags returns the evaluation diagnostics of the given error, if it holds any.
+func evalDiags(err error) (hcl.Diagnostics, bool) {
+	var diags hcl.Diagnostics
+	if err == nil || !errors.As(err, &diags) {
+		return nil, false
+	}
+	return diags, true
+}
+
+// validateRefs resolves the given column references, and reports the references
+// that cannot be resolved. A nil column is returned for unresolved references.
+func validateRefs(refs []*schemaspec.Ref, sch *schema.Schema, report func(error)) []*schema.Column {
+	columns := make([]*schema.Column, len(refs))
+	for i, ref := range refs {
+		c, err := resolveCol(ref, sch)
+		if err != nil {
+			report(err)
+			continue
+		}
+		columns[i] = c
+	}
+	return columns
+}
+
+// knownType reports if the converted type of a column is a known type. Types that
+// were not recognized by the driver conversion are checked against the registry.
+func (o ValidateOptions) knownType(t schema.Type, typ string) bool {
+	if o.Registry == nil {
+		return true
+	}
+	unknown := o.Unknown
+	if unknown == nil {
+		unknown = func(t schema.Type) bool {
+			_, ok := t.(*schema.UnsupportedType)
+			return ok
+		}
+	}
+	if !unknown(t) {
+		return true
+	}
+	name := typeName(typ)
+	for _, s := range o.Registry.Specs() {
+		if strings.EqualFold(s.T, name) || strings.EqualFold(s.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the base name of the given type, without its arguments.
+func typeName(t string) string {
+	if i := strings.IndexAny(t, "( ["); i != -1 {
+		t = t[:i]
+	}
+	return t
+}
@@ -0,0 +1,83 @@
+package specutil
+
+import (
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlspec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	var (
+		users = &sqlspec.Table{
+			Name: "users",
+			Columns: []*sqlspec.Column{
+				NewCol("id", "int"),
+				NewCol("name", "varchr"),
+			},
+			Indexes: []*sqlspec.Index{
+				{Name: "name", Columns: []*schemaspec.Ref{{V: "$table.users.$column.nickname"}}},
+			},
+		}
+		posts = &sqlspec.Table{
+			Name: "posts",
+			Columns: []*sqlspec.Column{
+				NewCol("author_id", "text"),
+				NewCol("id", "int"),
+			},
+			PrimaryKey: &sqlspec.PrimaryKey{
+				Columns: []*schemaspec.Ref{{V: "$table.posts.$column.uid"}},
+			},
+			ForeignKeys: []*sqlspec.ForeignKey{
+				{
+					Symbol:     "author",
+					Columns:    []*schemaspec.Ref{{V: "$table.posts.$column.author_id"}},
+					RefColumns: []*schemaspec.Ref{{V: "$table.users.$column.id"}},
+				},
+				{
+					Symbol:     "owner",
+					Columns:    []*schemaspec.Ref{{V: "$table.posts.$column.id"}},
+					RefColumns: []*schemaspec.Ref{{V: "$table.groups.$column.id"}},
+				},
+			},
+		}
+		opts = ValidateOptions{
+			ConvertColumn: func(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
+				return Column(spec, func(spec *sqlspec.Column) (schema.Type, error) {
+					switch spec.Type {
+					case "int":
+						return &schema.IntegerType{T: "int"}, nil
+					case "text":
+						return &schema.StringType{T: "text"}, nil
+					default:
+						return &schema.UnsupportedType{T: spec.Type}, nil
+					}
+				})
+			},
+			Registry: NewRegistry(TypeSpec("int"), TypeSpec("text"), TypeSpec("varchar")),
+			Compatible: func(t1, t2 schema.Type) bool {
+				_, ok1 := t1.(*schema.IntegerType)
+				_, ok2 := t2.(*schema.IntegerType)
+				return ok1 == ok2
+			},
+		}
+	)
+	err := Validate([]*sqlspec.Schema{{Name: "public"}}, []*sqlspec.Table{users, posts, users}, opts)
+	require.EqualError(t, err, `unknown type "varchr" for column users.name, did you mean "varchar"?
+index "name" of table "users" references unknown column "nickname"
+primary key of table "posts" references unknown column "uid"
+duplicate table "users"
+foreign key "author": column "author_id" of type "text" is incompatible with the referenced column "id" of type "int"
+foreign key "owner": sqlspec: table "groups" not found`)
+	errs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 6)
+
+	users.Columns[1].Type = "varchar"
+	users.Indexes = nil
+	posts.PrimaryKey, posts.ForeignKeys = nil, nil
+	opts.Registry = nil
+	require.NoError(t, Validate([]*sqlspec.Schema{{Name: "public"}}, []*sqlspec.Table{users, posts}, opts))
+}
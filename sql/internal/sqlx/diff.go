@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"ariga.io/atlas/sql/schema"
 )
@@ -49,6 +50,14 @@ type (
 		ReferenceChanged(from, to schema.ReferenceOption) bool
 	}
 
+	// A ForeignKeyAttrChanger wraps the ForeignKeyAttrChanged method. It is implemented
+	// by drivers that support additional foreign-key attributes. For example, the
+	// DEFERRABLE and MATCH options of PostgreSQL foreign keys.
+	ForeignKeyAttrChanger interface {
+		// ForeignKeyAttrChanged reports if the foreign-key attributes were changed.
+		ForeignKeyAttrChanged(from, to []schema.Attr) bool
+	}
+
 	// A Normalizer wraps the Normalize method for normalizing the from and to tables before
 	// running diffing. The "from" usually represents the inspected database state (current),
 	// and the second represents the desired state.
@@ -62,16 +71,19 @@ type (
 
 // RealmDiff implements the schema.Differ for Realm objects and returns a list of changes
 // that need to be applied in order to move a database from the current state to the desired.
-func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
+func (d *Diff) RealmDiff(from, to *schema.Realm, opts ...schema.DiffOption) ([]schema.Change, error) {
+	o := newDiffOptions(opts)
 	var changes []schema.Change
 	// Drop or modify schema.
 	for _, s1 := range from.Schemas {
 		s2, ok := to.Schema(s1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropSchema{S: s1})
+			if !o.SkipDrops {
+				changes = append(changes, &schema.DropSchema{S: s1})
+			}
 			continue
 		}
-		change, err := d.SchemaDiff(s1, s2)
+		change, err := d.schemaDiff(s1, s2, o)
 		if err != nil {
 			return nil, err
 		}
@@ -82,6 +94,11 @@ func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
 		if _, ok := from.Schema(s1.Name); ok {
 			continue
 		}
+		// Roles are shared by all schemas in the realm, and therefore, they
+		// are created before the schema that grants privileges to them.
+		if roles := RoleChanges(&schema.Schema{Realm: from}, s1); len(roles) > 0 {
+			changes = append(changes, &schema.ModifySchema{S: s1, Changes: roles})
+		}
 		changes = append(changes, &schema.AddSchema{S: s1})
 		for _, t := range s1.Tables {
 			changes = append(changes, &schema.AddTable{T: t})
@@ -92,11 +109,16 @@ func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
 
 // SchemaDiff implements the schema.Differ interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
-func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
+func (d *Diff) SchemaDiff(from, to *schema.Schema, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return d.schemaDiff(from, to, newDiffOptions(opts))
+}
+
+func (d *Diff) schemaDiff(from, to *schema.Schema, o *schema.DiffOptions) ([]schema.Change, error) {
 	var changes []schema.Change
 	// Drop or modify attributes (collations, checks, etc).
-	if change := d.SchemaAttrDiff(from, to); len(change) > 0 {
+	if change := skipAttrs(d.SchemaAttrDiff(from, to), o); len(change) > 0 {
 		changes = append(changes, &schema.ModifySchema{
+			S:       from,
 			Changes: change,
 		})
 	}
@@ -105,10 +127,12 @@ func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
 	for _, t1 := range from.Tables {
 		t2, ok := to.Table(t1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropTable{T: t1})
+			if !o.SkipDrops {
+				changes = append(changes, &schema.DropTable{T: t1})
+			}
 			continue
 		}
-		change, err := d.TableDiff(t1, t2)
+		change, err := d.tableDiff(t1, t2, o)
 		if err != nil {
 			return nil, err
 		}
@@ -130,7 +154,11 @@ func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
 
 // TableDiff implements the schema.TableDiffer interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
-func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
+func (d *Diff) TableDiff(from, to *schema.Table, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return d.tableDiff(from, to, newDiffOptions(opts))
+}
+
+func (d *Diff) tableDiff(from, to *schema.Table, o *schema.DiffOptions) ([]schema.Change, error) {
 	// Normalizing tables before starting the diff process.
 	if n, ok := d.DiffDriver.(Normalizer); ok {
 		n.Normalize(from, to)
@@ -141,25 +169,27 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 		return nil, fmt.Errorf("mismatched table names: %q != %q", from.Name, to.Name)
 	}
 	// PK modification is not supported.
-	if pk1, pk2 := from.PrimaryKey, to.PrimaryKey; (pk1 != nil) != (pk2 != nil) || (pk1 != nil) && d.pkChange(pk1, pk2) != schema.NoChange {
+	if pk1, pk2 := from.PrimaryKey, to.PrimaryKey; (pk1 != nil) != (pk2 != nil) || (pk1 != nil) && d.pkChange(pk1, pk2, o) != schema.NoChange {
 		return nil, fmt.Errorf("changing %q table primary key is not supported", to.Name)
 	}
 
 	// Drop or modify attributes (collations, checks, etc).
-	changes = append(changes, d.TableAttrDiff(from, to)...)
+	changes = append(changes, skipAttrs(d.TableAttrDiff(from, to), o)...)
 
 	// Drop or modify columns.
 	for _, c1 := range from.Columns {
 		c2, ok := to.Column(c1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropColumn{C: c1})
+			if !o.SkipDrops {
+				changes = append(changes, &schema.DropColumn{C: c1})
+			}
 			continue
 		}
 		change, err := d.ColumnChange(c1, c2)
 		if err != nil {
 			return nil, err
 		}
-		if change != schema.NoChange {
+		if change = skipKinds(change, o); change != schema.NoChange {
 			changes = append(changes, &schema.ModifyColumn{
 				From:   c1,
 				To:     c2,
@@ -178,10 +208,12 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 	for _, idx1 := range from.Indexes {
 		idx2, ok := to.Index(idx1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropIndex{I: idx1})
+			if !o.SkipDrops {
+				changes = append(changes, &schema.DropIndex{I: idx1})
+			}
 			continue
 		}
-		if change := d.indexChange(idx1, idx2); change != schema.NoChange {
+		if change := d.indexChange(idx1, idx2, o); change != schema.NoChange {
 			changes = append(changes, &schema.ModifyIndex{
 				From:   idx1,
 				To:     idx2,
@@ -200,7 +232,9 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 	for _, fk1 := range from.ForeignKeys {
 		fk2, ok := to.ForeignKey(fk1.Symbol)
 		if !ok {
-			changes = append(changes, &schema.DropForeignKey{F: fk1})
+			if !o.SkipDrops {
+				changes = append(changes, &schema.DropForeignKey{F: fk1})
+			}
 			continue
 		}
 		if change := d.fkChange(fk1, fk2); change != schema.NoChange {
@@ -221,26 +255,26 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 }
 
 // pkChange returns the schema changes (if any) for migrating one primary key to the other.
-func (d *Diff) pkChange(from, to *schema.Index) schema.ChangeKind {
-	change := d.indexChange(from, to)
+func (d *Diff) pkChange(from, to *schema.Index, o *schema.DiffOptions) schema.ChangeKind {
+	change := d.indexChange(from, to, o)
 	return change & ^schema.ChangeUnique
 }
 
 // indexChange returns the schema changes (if any) for migrating one index to the other.
-func (d *Diff) indexChange(from, to *schema.Index) schema.ChangeKind {
+func (d *Diff) indexChange(from, to *schema.Index, o *schema.DiffOptions) schema.ChangeKind {
 	var change schema.ChangeKind
 	if from.Unique != to.Unique {
 		change |= schema.ChangeUnique
 	}
-	if d.IndexAttrChanged(from.Attrs, to.Attrs) {
+	if !o.SkipIndexAttrs && d.IndexAttrChanged(from.Attrs, to.Attrs) {
 		change |= schema.ChangeAttr
 	}
-	change |= d.partsChange(from.Parts, to.Parts)
+	change |= d.partsChange(from.Parts, to.Parts, o)
 	change |= CommentChange(from.Attrs, to.Attrs)
-	return change
+	return skipKinds(change, o)
 }
 
-func (d *Diff) partsChange(from, to []*schema.IndexPart) schema.ChangeKind {
+func (d *Diff) partsChange(from, to []*schema.IndexPart, o *schema.DiffOptions) schema.ChangeKind {
 	if len(from) != len(to) {
 		return schema.ChangeParts
 	}
@@ -248,7 +282,7 @@ func (d *Diff) partsChange(from, to []*schema.IndexPart) schema.ChangeKind {
 	sort.Slice(from, func(i, j int) bool { return from[i].SeqNo < from[j].SeqNo })
 	for i := range from {
 		switch {
-		case d.IndexPartAttrChanged(from[i].Attrs, to[i].Attrs):
+		case !o.SkipIndexAttrs && d.IndexPartAttrChanged(from[i].Attrs, to[i].Attrs):
 			return schema.ChangeParts
 		case from[i].C != nil && to[i].C != nil:
 			if from[i].C.Name != to[i].C.Name {
@@ -296,9 +330,69 @@ func (d *Diff) fkChange(from, to *schema.ForeignKey) schema.ChangeKind {
 	if d.ReferenceChanged(from.OnDelete, to.OnDelete) {
 		change |= schema.ChangeDeleteAction
 	}
+	if c, ok := d.DiffDriver.(ForeignKeyAttrChanger); ok && c.ForeignKeyAttrChanged(from.Attrs, to.Attrs) {
+		change |= schema.ChangeAttr
+	}
 	return change
 }
 
+// newDiffOptions returns the DiffOptions that are configured by the given options.
+func newDiffOptions(opts []schema.DiffOption) *schema.DiffOptions {
+	o := &schema.DiffOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// skipKinds clears the change kinds that are skipped by the options.
+func skipKinds(k schema.ChangeKind, o *schema.DiffOptions) schema.ChangeKind {
+	if o.SkipComments {
+		k &= ^schema.ChangeComment
+	}
+	if o.SkipCharsets {
+		k &= ^(schema.ChangeCharset | schema.ChangeCollation)
+	}
+	return k
+}
+
+// skipAttrs removes the attribute changes that are skipped by the options.
+func skipAttrs(changes []schema.Change, o *schema.DiffOptions) []schema.Change {
+	if !o.SkipDrops && !o.SkipComments && !o.SkipCharsets && !o.SkipGrants {
+		return changes
+	}
+	skipped := func(a schema.Attr) bool {
+		switch a.(type) {
+		case *schema.Comment:
+			return o.SkipComments
+		case *schema.Charset, *schema.Collation:
+			return o.SkipCharsets
+		case *schema.Grant:
+			return o.SkipGrants
+		}
+		return false
+	}
+	filtered := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			if skipped(c.A) {
+				continue
+			}
+		case *schema.DropAttr:
+			if o.SkipDrops || skipped(c.A) {
+				continue
+			}
+		case *schema.ModifyAttr:
+			if skipped(c.To) {
+				continue
+			}
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
 // CommentChange reports if the element comment was changed.
 func CommentChange(from, to []schema.Attr) schema.ChangeKind {
 	var c1, c2 schema.Comment
@@ -308,6 +402,29 @@ func CommentChange(from, to []schema.Attr) schema.ChangeKind {
 	return schema.NoChange
 }
 
+// GeneratedChange reports if the generated expression of a column was changed.
+// The def argument holds the default generation type of the database (e.g. VIRTUAL),
+// and it is used in case the type was not set explicitly on one of the elements.
+func GeneratedChange(from, to []schema.Attr, def string) schema.ChangeKind {
+	var x1, x2 schema.GeneratedExpr
+	switch has1, has2 := Has(from, &x1), Has(to, &x2); {
+	case has1 != has2:
+		return schema.ChangeGenerated
+	case !has1:
+		return schema.NoChange
+	}
+	if x1.Type == "" {
+		x1.Type = def
+	}
+	if x2.Type == "" {
+		x2.Type = def
+	}
+	if !strings.EqualFold(x1.Type, x2.Type) || UnwrapExpr(x1.Expr) != UnwrapExpr(x2.Expr) {
+		return schema.ChangeGenerated
+	}
+	return schema.NoChange
+}
+
 var (
 	attrsType   = reflect.TypeOf(([]schema.Attr)(nil))
 	clausesType = reflect.TypeOf(([]schema.Clause)(nil))
@@ -0,0 +1,276 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlx
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+)
+
+// ScanGrants scans the rows and adds the privileges they describe to the schema and its
+// tables as schema.Grant attributes. Each row holds the table name, the column name, the
+// grantee, the privilege type and whether it is grantable ("YES" or "NO"). Schema-level
+// privileges are returned with an empty (or NULL) table name, and table-level privileges
+// with an empty column name. Rows of tables that do not exist in the schema (e.g. views) are skipped.
+func ScanGrants(s *schema.Schema, rows *sql.Rows) error {
+	type key struct {
+		table   string
+		grantee string
+		option  bool
+	}
+	var (
+		keys   []key
+		privs  = make(map[key][]string)
+		cprivs = make(map[key][]string)
+		cols   = make(map[key]map[string][]*schema.Column)
+	)
+	for rows.Next() {
+		var (
+			table, column            sql.NullString
+			grantee, priv, grantable string
+		)
+		if err := rows.Scan(&table, &column, &grantee, &priv, &grantable); err != nil {
+			return err
+		}
+		var (
+			c *schema.Column
+			k = key{table: table.String, grantee: grantee, option: strings.EqualFold(grantable, "YES")}
+		)
+		if table.String != "" {
+			t, ok := s.Table(table.String)
+			if !ok {
+				continue
+			}
+			if column.String != "" {
+				if c, ok = t.Column(column.String); !ok {
+					continue
+				}
+			}
+		}
+		if _, ok := privs[k]; !ok && cols[k] == nil {
+			keys = append(keys, k)
+		}
+		priv = strings.ToUpper(priv)
+		switch {
+		case c == nil:
+			privs[k] = append(privs[k], priv)
+		case cols[k] == nil:
+			cols[k] = make(map[string][]*schema.Column)
+			fallthrough
+		default:
+			if cols[k][priv] == nil {
+				cprivs[k] = append(cprivs[k], priv)
+			}
+			cols[k][priv] = append(cols[k][priv], c)
+		}
+	}
+	for _, k := range keys {
+		attrs := &s.Attrs
+		if k.table != "" {
+			t, _ := s.Table(k.table)
+			attrs = &t.Attrs
+		}
+		if ps := privs[k]; len(ps) > 0 {
+			*attrs = append(*attrs, &schema.Grant{Grantee: k.grantee, Privileges: ps, GrantOption: k.option})
+		}
+		// Privileges that were granted on the same
+		// set of columns are grouped into one grant.
+		var grants []*schema.Grant
+		for _, p := range cprivs[k] {
+			var g *schema.Grant
+			for i := range grants {
+				if columnsKey(grants[i].Columns) == columnsKey(cols[k][p]) {
+					g = grants[i]
+				}
+			}
+			if g == nil {
+				g = &schema.Grant{Grantee: k.grantee, Columns: cols[k][p], GrantOption: k.option}
+				grants = append(grants, g)
+			}
+			g.Privileges = append(g.Privileges, p)
+		}
+		for _, g := range grants {
+			*attrs = append(*attrs, g)
+		}
+	}
+	return rows.Err()
+}
+
+// GrantChanges returns the changes needed for moving the grants of an element (i.e. a
+// schema or a table) from the given state to the desired one. Grants are matched by their
+// grantee, their grant option and the set of columns they apply to. Privileges of grants
+// that share the same key are merged.
+func GrantChanges(from, to []schema.Attr) []schema.Change {
+	var (
+		changes          []schema.Change
+		fromKeys, toKeys = grantKeys(from), grantKeys(to)
+		fromG, toG       = mergeGrants(from), mergeGrants(to)
+	)
+	for _, k := range fromKeys {
+		if _, ok := toG[k]; !ok {
+			changes = append(changes, &schema.DropAttr{A: fromG[k]})
+		}
+	}
+	for _, k := range toKeys {
+		g1, ok := fromG[k]
+		switch g2 := toG[k]; {
+		case !ok:
+			changes = append(changes, &schema.AddAttr{A: g2})
+		case !ValuesEqual(g1.Privileges, g2.Privileges):
+			changes = append(changes, &schema.ModifyAttr{From: g1, To: g2})
+		}
+	}
+	return changes
+}
+
+// ManagesGrants reports if the grants of the given (desired) schema are managed, i.e. the
+// schema declares at least one grant. Existing grants of schemas that do not declare any
+// are left untouched, as revoking them may lock out the application user. Grants of the
+// schema tables are decided separately by ManagesTableGrants.
+func ManagesGrants(s *schema.Schema) bool {
+	return s != nil && hasGrants(s.Attrs)
+}
+
+// ManagesTableGrants reports if the grants of the given (desired) table are managed,
+// i.e. the table declares at least one grant. See ManagesGrants for more info.
+func ManagesTableGrants(t *schema.Table) bool {
+	return t != nil && hasGrants(t.Attrs)
+}
+
+// hasGrants reports if attrs contain a grant.
+func hasGrants(attrs []schema.Attr) bool {
+	for _, a := range attrs {
+		if _, ok := a.(*schema.Grant); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PrivilegesChange returns the privileges that need to be granted
+// and revoked for moving a grant from the given state to the desired one.
+func PrivilegesChange(from, to *schema.Grant) (grant, revoke []string) {
+	fromP, toP := privileges(from.Privileges), privileges(to.Privileges)
+	for _, p := range toP {
+		if !contains(fromP, p) {
+			grant = append(grant, p)
+		}
+	}
+	for _, p := range fromP {
+		if !contains(toP, p) {
+			revoke = append(revoke, p)
+		}
+	}
+	return grant, revoke
+}
+
+// IsGrantChange reports if the given change is a change of a schema.Grant attribute.
+func IsGrantChange(c schema.Change) bool {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.DropAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	_, ok := a.(*schema.Grant)
+	return ok
+}
+
+// RoleChanges returns the changes needed for creating the roles that were defined on the
+// desired schema and do not exist in the current one or in its realm. Roles are shared by
+// all schemas in the database, and therefore, they are never dropped.
+func RoleChanges(from, to *schema.Schema) []schema.Change {
+	exists := func(name string) bool {
+		attrs := from.Attrs
+		if from.Realm != nil {
+			attrs = append(attrs[:len(attrs):len(attrs)], from.Realm.Attrs...)
+		}
+		for _, a := range attrs {
+			if r, ok := a.(*schema.Role); ok && r.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	var changes []schema.Change
+	for _, a := range to.Attrs {
+		if r, ok := a.(*schema.Role); ok && !exists(r.Name) {
+			changes = append(changes, &schema.AddAttr{A: r})
+		}
+	}
+	return changes
+}
+
+// grantKeys returns the keys of the grants in attrs by their order.
+func grantKeys(attrs []schema.Attr) []string {
+	var keys []string
+	for _, a := range attrs {
+		if g, ok := a.(*schema.Grant); ok && !contains(keys, grantKey(g)) {
+			keys = append(keys, grantKey(g))
+		}
+	}
+	return keys
+}
+
+// mergeGrants merges the grants in attrs by their keys.
+func mergeGrants(attrs []schema.Attr) map[string]*schema.Grant {
+	grants := make(map[string]*schema.Grant)
+	for _, a := range attrs {
+		g, ok := a.(*schema.Grant)
+		if !ok {
+			continue
+		}
+		k := grantKey(g)
+		if m, ok := grants[k]; ok {
+			g = &schema.Grant{Grantee: m.Grantee, Columns: m.Columns, GrantOption: m.GrantOption, Privileges: append(m.Privileges, g.Privileges...)}
+		}
+		grants[k] = &schema.Grant{Grantee: g.Grantee, Columns: g.Columns, GrantOption: g.GrantOption, Privileges: privileges(g.Privileges)}
+	}
+	return grants
+}
+
+func grantKey(g *schema.Grant) string {
+	k := g.Grantee + "|" + columnsKey(g.Columns)
+	if g.GrantOption {
+		k += "|grant"
+	}
+	return k
+}
+
+func columnsKey(columns []*schema.Column) string {
+	names := make([]string, len(columns))
+	for i := range columns {
+		names[i] = columns[i].Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// privileges returns the sorted set of the given privileges.
+func privileges(ps []string) []string {
+	set := make([]string, 0, len(ps))
+	for _, p := range ps {
+		if p = strings.ToUpper(strings.TrimSpace(p)); !contains(set, p) {
+			set = append(set, p)
+		}
+	}
+	sort.Strings(set)
+	return set
+}
+
+func contains(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
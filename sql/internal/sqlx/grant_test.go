@@ -0,0 +1,51 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlx
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantChanges(t *testing.T) {
+	changes := GrantChanges(
+		[]schema.Attr{
+			&schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}},
+			&schema.Grant{Grantee: "app", Privileges: []string{"INSERT"}},
+			&schema.Grant{Grantee: "admin", Privileges: []string{"SELECT"}, GrantOption: true},
+		},
+		[]schema.Attr{
+			&schema.Grant{Grantee: "app", Privileges: []string{"insert", "select"}},
+			&schema.Grant{Grantee: "admin", Privileges: []string{"SELECT"}},
+		},
+	)
+	require.Equal(t, []schema.Change{
+		&schema.DropAttr{A: &schema.Grant{Grantee: "admin", Privileges: []string{"SELECT"}, GrantOption: true}},
+		&schema.AddAttr{A: &schema.Grant{Grantee: "admin", Privileges: []string{"SELECT"}}},
+	}, changes)
+
+	grant, revoke := PrivilegesChange(
+		&schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "INSERT"}},
+		&schema.Grant{Grantee: "app", Privileges: []string{"select", "update", "delete"}},
+	)
+	require.Equal(t, []string{"DELETE", "UPDATE"}, grant)
+	require.Equal(t, []string{"INSERT"}, revoke)
+}
+
+func TestRoleChanges(t *testing.T) {
+	from := &schema.Schema{
+		Name:  "public",
+		Realm: &schema.Realm{Attrs: []schema.Attr{&schema.Role{Name: "app"}}},
+	}
+	to := &schema.Schema{
+		Name:  "public",
+		Attrs: []schema.Attr{&schema.Role{Name: "app"}, &schema.Role{Name: "reporter"}},
+	}
+	require.Equal(t, []schema.Change{&schema.AddAttr{A: &schema.Role{Name: "reporter"}}}, RoleChanges(from, to))
+	require.Empty(t, RoleChanges(to, from), "roles are never dropped")
+}
@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"ariga.io/atlas/sql/schema"
@@ -131,10 +132,107 @@ func ValuesEqual(v1, v2 []string) bool {
 	return true
 }
 
+// MayWrap wraps the given string with parentheses, unless
+// it is already wrapped. For example:
+//
+//	MayWrap("a + b")     => "(a + b)"
+//	MayWrap("(a + b)")   => "(a + b)"
+//	MayWrap("(a) + (b)") => "((a) + (b))"
+func MayWrap(s string) string {
+	if isWrapped(s) {
+		return s
+	}
+	return "(" + s + ")"
+}
+
+// UnwrapExpr trims the whitespace and the redundant outer
+// parentheses (if exist) of the given expression.
+func UnwrapExpr(s string) string {
+	s = strings.TrimSpace(s)
+	for isWrapped(s) {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+// SplitExprs splits the given comma-separated list of expressions, ignoring
+// commas that appear inside parentheses or quotes. For example:
+//
+//	SplitExprs("a, lower(b), concat(c, ',')") => ["a", "lower(b)", "concat(c, ',')"]
+func SplitExprs(s string) []string {
+	var (
+		exprs []string
+		depth int
+		quote byte
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'', c == '"', c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			exprs = append(exprs, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" || len(exprs) > 0 {
+		exprs = append(exprs, last)
+	}
+	return exprs
+}
+
+// isWrapped reports if the given string is wrapped with
+// a pair of matching parentheses.
+func isWrapped(s string) bool {
+	n := len(s)
+	if n < 2 || s[0] != '(' || s[n-1] != ')' {
+		return false
+	}
+	depth := 0
+	for i := 0; i < n; i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		// The first parenthesis is closed before the end of the string.
+		if depth == 0 && i < n-1 {
+			return false
+		}
+	}
+	return depth == 0
+}
+
+// DefaultValue returns the SQL representation of the given default value expression.
+// Quoted string literals (e.g. "hello") are converted to SQL strings ('hello'), and
+// raw expressions and other literals (e.g. 1, true) are returned as-is.
+func DefaultValue(x schema.Expr) (string, bool) {
+	switch x := x.(type) {
+	case *schema.RawExpr:
+		return x.X, true
+	case *schema.Literal:
+		if u, err := strconv.Unquote(x.V); err == nil && strings.HasPrefix(x.V, `"`) {
+			return "'" + strings.ReplaceAll(u, "'", "''") + "'", true
+		}
+		return x.V, true
+	default:
+		return "", false
+	}
+}
+
 // VersionPermutations returns permutations of the dialect version sorted
 // from coarse to fine grained. For example:
 //
-//   VersionPermutations("mysql", "1.2.3") => ["mysql", "mysql 1", "mysql 1.2", "mysql 1.2.3"]
+//	VersionPermutations("mysql", "1.2.3") => ["mysql", "mysql 1", "mysql 1.2", "mysql 1.2.3"]
 //
 // VersionPermutations will split the version number by ".", " ", "-" or "_", and rejoin them
 // with ".". The output slice can be used by drivers to generate a list of permutations
@@ -186,6 +284,11 @@ func (b *Builder) Ident(s string) *Builder {
 	return b
 }
 
+// Lit writes the given string quoted as an SQL string literal.
+func (b *Builder) Lit(s string) *Builder {
+	return b.P("'" + strings.ReplaceAll(s, "'", "''") + "'")
+}
+
 // Table writes the table identifier to the builder, prefixed
 // with the schema name if exists.
 func (b *Builder) Table(t *schema.Table) *Builder {
@@ -20,6 +20,41 @@ func TestVersionNames(t *testing.T) {
 	require.EqualValues(t, []string{"postgres", "postgres 11", "postgres 11.3", "postgres 11.3.nightly"}, names)
 }
 
+func TestMayWrap(t *testing.T) {
+	require.Equal(t, "(a + b)", MayWrap("a + b"))
+	require.Equal(t, "(a + b)", MayWrap("(a + b)"))
+	require.Equal(t, "((a) + (b))", MayWrap("(a) + (b)"))
+	require.Equal(t, "a + b", UnwrapExpr(" ((a + b)) "))
+	require.Equal(t, "(a) + (b)", UnwrapExpr("(a) + (b)"))
+}
+
+func TestSplitExprs(t *testing.T) {
+	require.Empty(t, SplitExprs(""))
+	require.Equal(t, []string{"a"}, SplitExprs(" a "))
+	require.Equal(t, []string{"a", "lower(b)", "concat(c, ',')"}, SplitExprs("a, lower(b), concat(c, ',')"))
+	require.Equal(t, []string{"`a,b`", `"c,d"`, "date_trunc('month', e)"}, SplitExprs("`a,b`,\"c,d\",date_trunc('month', e)"))
+}
+
+func TestDefaultValue(t *testing.T) {
+	for _, tt := range []struct {
+		x    schema.Expr
+		want string
+	}{
+		{x: &schema.RawExpr{X: "uuid()"}, want: "uuid()"},
+		{x: &schema.Literal{V: "1"}, want: "1"},
+		{x: &schema.Literal{V: "true"}, want: "true"},
+		{x: &schema.Literal{V: `"hello"`}, want: "'hello'"},
+		{x: &schema.Literal{V: `"it's"`}, want: "'it''s'"},
+		{x: &schema.Literal{V: "'hello'"}, want: "'hello'"},
+	} {
+		v, ok := DefaultValue(tt.x)
+		require.True(t, ok)
+		require.Equal(t, tt.want, v)
+	}
+	_, ok := DefaultValue(nil)
+	require.False(t, ok)
+}
+
 func TestBuilder(t *testing.T) {
 	var (
 		b       = &Builder{QuoteChar: '"'}
@@ -38,4 +73,8 @@ func TestBuilder(t *testing.T) {
 			})
 		})
 	require.Equal(t, `CREATE TABLE "users" ("a" int NOT NULL, "b" int NOT NULL, "c" int NOT NULL, PRIMARY KEY ("a", "b", "c"))`, b.String())
+
+	b = &Builder{QuoteChar: '"'}
+	b.P("ALTER EXTENSION").Ident("ext").P("UPDATE TO").Lit("1.0'")
+	require.Equal(t, `ALTER EXTENSION "ext" UPDATE TO '1.0'''`, b.String())
 }
@@ -28,6 +28,11 @@ func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
 	if change := d.collationChange(from.Attrs, from.Realm.Attrs, to.Attrs); change != noChange {
 		changes = append(changes, change)
 	}
+	// Roles must be created before privileges are granted to them.
+	changes = append(changes, sqlx.RoleChanges(from, to)...)
+	if sqlx.ManagesGrants(to) {
+		changes = append(changes, sqlx.GrantChanges(from.Attrs, to.Attrs)...)
+	}
 	return changes
 }
 
@@ -42,6 +47,12 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) []schema.Change {
 	if change := d.collationChange(from.Attrs, from.Schema.Attrs, to.Attrs); change != noChange {
 		changes = append(changes, change)
 	}
+	// AUTO_INCREMENT change.
+	if change := d.autoIncChange(from.Attrs, to.Attrs); change != noChange {
+		changes = append(changes, change)
+	}
+	// Table options change (ENGINE, ROW_FORMAT, etc).
+	changes = append(changes, d.tableOptionsChange(from.Attrs, to.Attrs)...)
 	// Drop or modify checks.
 	for _, c1 := range checks(from.Attrs) {
 		switch c2, ok := checkByName(to.Attrs, c1.Name); {
@@ -64,9 +75,84 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) []schema.Change {
 			})
 		}
 	}
+	changes = append(changes, partitionChanges(from.Attrs, to.Attrs)...)
+	if sqlx.ManagesTableGrants(to) {
+		changes = append(changes, sqlx.GrantChanges(from.Attrs, to.Attrs)...)
+	}
+	return changes
+}
+
+// partitionChanges returns the changes for migrating the partitioning of a table.
+// Changes to the partitioning type or key are reported as a modification of the
+// Partition attribute, and changes to the partitions as changes of PartitionDef
+// attributes.
+func partitionChanges(from, to []schema.Attr) []schema.Change {
+	p1, p2 := partition(from), partition(to)
+	switch {
+	case p1 == nil && p2 == nil:
+		return nil
+	case p1 == nil:
+		return []schema.Change{&schema.AddAttr{A: p2}}
+	case p2 == nil:
+		return []schema.Change{&schema.DropAttr{A: p1}}
+	case !strings.EqualFold(p1.T, p2.T) || partitionKeyString(p1.Parts) != partitionKeyString(p2.Parts):
+		return []schema.Change{&schema.ModifyAttr{From: p1, To: p2}}
+	}
+	var changes []schema.Change
+	for _, d1 := range p1.Defs {
+		switch d2, ok := partitionDef(p2, d1.Name); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{
+				A: d1,
+			})
+		case partitionBoundString(d1.Bound) != partitionBoundString(d2.Bound):
+			changes = append(changes, &schema.ModifyAttr{
+				From: d1,
+				To:   d2,
+			})
+		}
+	}
+	for _, d2 := range p2.Defs {
+		if _, ok := partitionDef(p1, d2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{
+				A: d2,
+			})
+		}
+	}
 	return changes
 }
 
+// partitionKeyString returns a normalized representation of
+// the partitioning key that is used for comparing keys.
+func partitionKeyString(parts []*PartitionPart) string {
+	keys := make([]string, len(parts))
+	for i, p := range parts {
+		switch x, ok := p.X.(*schema.RawExpr); {
+		case p.C != nil:
+			keys[i] = p.C.Name
+		case ok:
+			keys[i] = x.X
+		}
+		keys[i] = strings.ToLower(strings.NewReplacer("`", "", " ", "").Replace(keys[i]))
+	}
+	return strings.Join(keys, ",")
+}
+
+// partitionBoundString returns a normalized representation of a partition
+// bound, because MySQL omits the spaces between the listed values.
+func partitionBoundString(b string) string {
+	return strings.ToUpper(strings.ReplaceAll(b, " ", ""))
+}
+
+func partitionDef(p *Partition, name string) (*PartitionDef, bool) {
+	for _, d := range p.Defs {
+		if strings.EqualFold(d.Name, name) {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
 // ColumnChange returns the schema changes (if any) for migrating one column to the other.
 func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error) {
 	change := sqlx.CommentChange(from.Attrs, to.Attrs)
@@ -87,6 +173,10 @@ func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error)
 	if changed {
 		change |= schema.ChangeDefault
 	}
+	change |= sqlx.GeneratedChange(from.Attrs, to.Attrs, "VIRTUAL")
+	if autoIncChanged(from.Attrs, to.Attrs) || onUpdateChanged(from.Attrs, to.Attrs) {
+		change |= schema.ChangeAttr
+	}
 	return change, nil
 }
 
@@ -183,6 +273,67 @@ func (d *diff) charsetChange(from, top, to []schema.Attr) schema.Change {
 	return noChange
 }
 
+// autoIncChange returns the schema change for changing the AUTO_INCREMENT
+// attribute in case it is not the default.
+func (*diff) autoIncChange(from, to []schema.Attr) schema.Change {
+	var fromA, toA AutoIncrement
+	switch fromHas, toHas := sqlx.Has(from, &fromA), sqlx.Has(to, &toA); {
+	// Ignore if the AUTO_INCREMENT attribute was dropped from the desired
+	// schema, or was not set explicitly (default value).
+	case !toHas || toA.V == 0:
+	case !fromHas:
+		return &schema.AddAttr{
+			A: &toA,
+		}
+	// The AUTO_INCREMENT value of a table is advanced by MySQL on
+	// insertion, and it cannot be set to a value lower than the current
+	// maximum of the column. Hence, changes are suggested only if the
+	// desired value is greater than the current one.
+	case toA.V > fromA.V:
+		return &schema.ModifyAttr{
+			From: &fromA,
+			To:   &toA,
+		}
+	}
+	return noChange
+}
+
+// tableOptionsChange returns the schema changes for migrating the table options.
+// Options are changed only if they are set explicitly in the desired schema,
+// because their defaults are derived from the server configuration.
+func (*diff) tableOptionsChange(from, to []schema.Attr) []schema.Change {
+	var (
+		changes    []schema.Change
+		fromE, toE Engine
+		fromR, toR RowFormat
+		fromK, toK KeyBlockSize
+		fromS, toS StatsPersistent
+	)
+	for _, o := range []struct {
+		from, to schema.Attr
+		changed  func() bool
+	}{
+		{&fromE, &toE, func() bool { return !strings.EqualFold(fromE.V, toE.V) }},
+		{&fromR, &toR, func() bool { return !strings.EqualFold(fromR.V, toR.V) }},
+		{&fromK, &toK, func() bool { return fromK.V != toK.V }},
+		{&fromS, &toS, func() bool { return fromS.V != toS.V }},
+	} {
+		switch fromHas, toHas := sqlx.Has(from, o.from), sqlx.Has(to, o.to); {
+		case !toHas:
+		case !fromHas:
+			changes = append(changes, &schema.AddAttr{
+				A: o.to,
+			})
+		case o.changed():
+			changes = append(changes, &schema.ModifyAttr{
+				From: o.from,
+				To:   o.to,
+			})
+		}
+	}
+	return changes
+}
+
 // indexCollation returns the index collation from its attribute.
 // The default collation is ascending if no order was specified.
 func indexCollation(attr []schema.Attr) *schema.Collation {
@@ -344,6 +495,23 @@ func boolValue(x string) (bool, error) {
 	}
 }
 
+// autoIncChanged reports if the AUTO_INCREMENT attribute was
+// added or dropped from the column.
+func autoIncChanged(from, to []schema.Attr) bool {
+	return sqlx.Has(from, &AutoIncrement{}) != sqlx.Has(to, &AutoIncrement{})
+}
+
+// onUpdateChanged reports if the ON UPDATE attribute of the column was changed.
+func onUpdateChanged(from, to []schema.Attr) bool {
+	var fromU, toU OnUpdate
+	fromHas, toHas := sqlx.Has(from, &fromU), sqlx.Has(to, &toU)
+	if fromHas != toHas {
+		return true
+	}
+	// CURRENT_TIMESTAMP and CURRENT_TIMESTAMP() are equivalent.
+	return fromHas && strings.ToLower(strings.TrimSuffix(fromU.A, "()")) != strings.ToLower(strings.TrimSuffix(toU.A, "()"))
+}
+
 func checkByName(attr []schema.Attr, name string) (*Check, bool) {
 	for i := range attr {
 		if c, ok := attr[i].(*Check); ok && c.Name == name {
@@ -38,6 +38,63 @@ func TestDiff_TableDiff(t *testing.T) {
 			to:      &schema.Table{Name: "users"},
 			wantErr: true,
 		},
+		func() testcase {
+			c := &schema.Column{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+			p2021 := &PartitionDef{Name: "p2021", Bound: "VALUES LESS THAN (2022)"}
+			p2022 := &PartitionDef{Name: "p2022", Bound: "VALUES LESS THAN (2023)"}
+			list1 := &PartitionDef{Name: "p1", Bound: "VALUES IN (1,2)"}
+			list2 := &PartitionDef{Name: "p1", Bound: "VALUES IN (1, 2, 3)"}
+			return testcase{
+				name: "partitions",
+				from: &schema.Table{Name: "logs", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c}, Attrs: []schema.Attr{
+					&Partition{T: "RANGE", Parts: []*PartitionPart{{C: c}}, Defs: []*PartitionDef{p2021, list1}},
+				}},
+				to: &schema.Table{Name: "logs", Columns: []*schema.Column{c}, Attrs: []schema.Attr{
+					&Partition{T: "range", Parts: []*PartitionPart{{X: &schema.RawExpr{X: "`id`"}}}, Defs: []*PartitionDef{list2, p2022}},
+				}},
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: p2021},
+					&schema.ModifyAttr{From: list1, To: list2},
+					&schema.AddAttr{A: p2022},
+				},
+			}
+		}(),
+		func() testcase {
+			c := &schema.Column{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+			p := &Partition{T: "HASH", Parts: []*PartitionPart{{C: c}}}
+			return testcase{
+				name: "remove partitioning",
+				from: &schema.Table{Name: "logs", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c}, Attrs: []schema.Attr{p}},
+				to:   &schema.Table{Name: "logs", Columns: []*schema.Column{c}},
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: p},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				id    = &schema.Column{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+				name  = &schema.Column{Name: "name", Type: &schema.ColumnType{Raw: "text", Type: &schema.StringType{T: "text"}}}
+				from1 = &schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "INSERT"}}
+				from2 = &schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: []*schema.Column{id}}
+				to1   = &schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}}
+				to2   = &schema.Grant{Grantee: "app", Privileges: []string{"update"}}
+				to3   = &schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: []*schema.Column{name, id}}
+			)
+			return testcase{
+				name: "grants",
+				from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{id, name}, Attrs: []schema.Attr{from1, from2}},
+				to:   &schema.Table{Name: "users", Columns: []*schema.Column{id, name}, Attrs: []schema.Attr{to1, to2, to3}},
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: &schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: []*schema.Column{id}}},
+					&schema.ModifyAttr{
+						From: &schema.Grant{Grantee: "app", Privileges: []string{"INSERT", "SELECT"}},
+						To:   &schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "UPDATE"}},
+					},
+					&schema.AddAttr{A: &schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: []*schema.Column{name, id}}},
+				},
+			}
+		}(),
 		{
 			name: "add collation",
 			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}},
@@ -171,6 +228,132 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			}
 		}(),
+		{
+			name: "add auto_increment",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&AutoIncrement{V: 100}}},
+			wantChanges: []schema.Change{
+				&schema.AddAttr{
+					A: &AutoIncrement{V: 100},
+				},
+			},
+		},
+		{
+			name: "modify auto_increment",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&AutoIncrement{V: 1}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&AutoIncrement{V: 100}}},
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{
+					From: &AutoIncrement{V: 1},
+					To:   &AutoIncrement{V: 100},
+				},
+			},
+		},
+		{
+			name: "ignore advanced auto_increment",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&AutoIncrement{V: 1000}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&AutoIncrement{V: 100}}},
+		},
+		{
+			name: "ignore dropped auto_increment",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&AutoIncrement{V: 1000}}},
+			to:   &schema.Table{Name: "users"},
+		},
+		{
+			name: "modify table options",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&Engine{V: "MyISAM"}, &RowFormat{V: "DYNAMIC"}, &StatsPersistent{V: true}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&Engine{V: "InnoDB"}, &RowFormat{V: "compressed"}, &KeyBlockSize{V: 8}, &StatsPersistent{V: true}}},
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{
+					From: &Engine{V: "MyISAM"},
+					To:   &Engine{V: "InnoDB"},
+				},
+				&schema.ModifyAttr{
+					From: &RowFormat{V: "DYNAMIC"},
+					To:   &RowFormat{V: "compressed"},
+				},
+				&schema.AddAttr{
+					A: &KeyBlockSize{V: 8},
+				},
+			},
+		},
+		{
+			name: "ignore table options that were not set",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&Engine{V: "InnoDB"}, &RowFormat{V: "DYNAMIC"}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&Engine{V: "innodb"}}},
+		},
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "t1",
+					Schema: &schema.Schema{
+						Name: "public",
+					},
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&AutoIncrement{A: "auto_increment"}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}},
+						{Name: "c5", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "t1",
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&AutoIncrement{}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&AutoIncrement{}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&OnUpdate{A: "current_timestamp()"}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}},
+						{Name: "c5", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}},
+					},
+				}
+			)
+			return testcase{
+				name: "auto_increment and on_update columns",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeAttr},
+					&schema.ModifyColumn{From: from.Columns[3], To: to.Columns[3], Change: schema.ChangeAttr},
+					&schema.ModifyColumn{From: from.Columns[4], To: to.Columns[4], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "t1",
+					Schema: &schema.Schema{
+						Name: "public",
+					},
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`c` * 2)", Type: "VIRTUAL"}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`c` * 2)", Type: "VIRTUAL"}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`c` * 2)", Type: "VIRTUAL"}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "t1",
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "`c` * 2"}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "`c` * 3"}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "`c` * 2", Type: "STORED"}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "`c` * 2"}}},
+					},
+				}
+			)
+			return testcase{
+				name: "generated columns",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeGenerated},
+					&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[2], Change: schema.ChangeGenerated},
+					&schema.ModifyColumn{From: from.Columns[3], To: to.Columns[3], Change: schema.ChangeGenerated},
+				},
+			}
+		}(),
 		func() testcase {
 			var (
 				from = &schema.Table{
@@ -319,13 +502,125 @@ func TestDiff_SchemaDiff(t *testing.T) {
 	changes, err := drv.SchemaDiff(from, to)
 	require.NoError(t, err)
 	require.EqualValues(t, []schema.Change{
-		&schema.ModifySchema{Changes: []schema.Change{&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]}}},
+		&schema.ModifySchema{S: from, Changes: []schema.Change{&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]}}},
 		&schema.ModifyTable{T: from.Tables[0], Changes: []schema.Change{&schema.AddColumn{C: to.Tables[0].Columns[0]}}},
 		&schema.DropTable{T: from.Tables[1]},
 		&schema.AddTable{T: to.Tables[1]},
 	}, changes)
 }
 
+func TestDiff_SchemaDiffOptions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("8.0.19")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := &schema.Schema{
+		Realm: &schema.Realm{},
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+					{Name: "name", Type: &schema.ColumnType{Raw: "varchar(255)", Type: &schema.StringType{T: "varchar", Size: 255}}, Attrs: []schema.Attr{&schema.Comment{Text: "old"}, &schema.Collation{V: "latin1_swedish_ci"}}},
+				},
+				Attrs: []schema.Attr{&schema.Comment{Text: "old"}, &schema.Charset{V: "latin1"}},
+			},
+			{Name: "pets"},
+		},
+		Attrs: []schema.Attr{&schema.Collation{V: "latin1"}},
+	}
+	from.Tables[0].Indexes = []*schema.Index{
+		{Name: "name", Table: from.Tables[0], Parts: []*schema.IndexPart{{C: from.Tables[0].Columns[1]}}, Attrs: []schema.Attr{&schema.Comment{Text: "old"}}},
+		{Name: "id", Table: from.Tables[0], Parts: []*schema.IndexPart{{C: from.Tables[0].Columns[0]}}},
+	}
+	to := &schema.Schema{
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "name", Type: &schema.ColumnType{Raw: "varchar(255)", Type: &schema.StringType{T: "varchar", Size: 255}}, Attrs: []schema.Attr{&schema.Comment{Text: "new"}, &schema.Collation{V: "utf8mb4_bin"}}},
+					{Name: "age", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+				},
+				Attrs: []schema.Attr{&schema.Comment{Text: "new"}, &schema.Charset{V: "utf8mb4"}},
+			},
+		},
+		Attrs: []schema.Attr{&schema.Collation{V: "utf8"}},
+	}
+	to.Tables[0].Indexes = []*schema.Index{
+		{Name: "name", Table: to.Tables[0], Parts: []*schema.IndexPart{{C: to.Tables[0].Columns[0]}}, Attrs: []schema.Attr{&schema.Comment{Text: "new"}}},
+	}
+	from.Tables[0].Schema = from
+	from.Tables[1].Schema = from
+	to.Tables[0].Schema = to
+
+	// Without options, all changes are reported.
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.IsType(t, &schema.DropTable{}, changes[2])
+
+	changes, err = drv.SchemaDiff(from, to, schema.WithDiffOptions(schema.DiffOptions{
+		SkipDrops:    true,
+		SkipComments: true,
+		SkipCharsets: true,
+	}))
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyTable{T: from.Tables[0], Changes: []schema.Change{&schema.AddColumn{C: to.Tables[0].Columns[1]}}},
+	}, changes)
+
+	changes, err = drv.SchemaDiff(from, to, schema.WithDiffOptions(schema.DiffOptions{SkipDrops: true}))
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	for _, c := range changes[1].(*schema.ModifyTable).Changes {
+		switch c.(type) {
+		case *schema.DropColumn, *schema.DropIndex:
+			t.Fatalf("unexpected drop change: %T", c)
+		}
+	}
+
+	// Index attributes changes.
+	t1 := &schema.Table{Name: "t", Schema: from, Columns: []*schema.Column{{Name: "c", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}}}
+	t1.Indexes = []*schema.Index{{Name: "c", Table: t1, Parts: []*schema.IndexPart{{C: t1.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "BTREE"}}}}
+	t2 := &schema.Table{Name: "t", Columns: t1.Columns}
+	t2.Indexes = []*schema.Index{{Name: "c", Table: t2, Parts: []*schema.IndexPart{{C: t2.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "HASH"}}}}
+	changes, err = drv.TableDiff(t1, t2)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	changes, err = drv.TableDiff(t1, t2, schema.WithDiffOptions(schema.DiffOptions{SkipIndexAttrs: true}))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Grants are not managed if the desired schema does not declare any.
+	t1 = &schema.Table{Name: "t", Schema: from, Columns: t1.Columns, Attrs: []schema.Attr{&schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}}}}
+	t2 = &schema.Table{Name: "t", Schema: &schema.Schema{Name: "public"}, Columns: t1.Columns}
+	changes, err = drv.TableDiff(t1, t2)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	changes, err = drv.SchemaDiff(
+		&schema.Schema{Name: "public", Realm: &schema.Realm{}, Attrs: []schema.Attr{&schema.Grant{Grantee: "app", Privileges: []string{"ALL"}}}},
+		&schema.Schema{Name: "public"},
+	)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Grants of a table are not managed by the grants of its schema.
+	t2 = &schema.Table{Name: "t", Schema: &schema.Schema{Name: "public", Attrs: []schema.Attr{&schema.Grant{Grantee: "app", Privileges: []string{"ALL"}}}}, Columns: t1.Columns}
+	changes, err = drv.TableDiff(t1, t2)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Grant changes.
+	t2 = &schema.Table{Name: "t", Schema: &schema.Schema{Name: "public"}, Columns: t1.Columns, Attrs: []schema.Attr{&schema.Grant{Grantee: "app", Privileges: []string{"INSERT"}}}}
+	changes, err = drv.TableDiff(t1, t2)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	changes, err = drv.TableDiff(t1, t2, schema.WithDiffOptions(schema.DiffOptions{SkipGrants: true}))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
 func TestDiff_RealmDiff(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -385,7 +680,7 @@ func TestDiff_RealmDiff(t *testing.T) {
 	changes, err := drv.RealmDiff(from, to)
 	require.NoError(t, err)
 	require.EqualValues(t, []schema.Change{
-		&schema.ModifySchema{Changes: []schema.Change{&schema.ModifyAttr{From: from.Schemas[0].Attrs[0], To: to.Schemas[0].Attrs[0]}}},
+		&schema.ModifySchema{S: from.Schemas[0], Changes: []schema.Change{&schema.ModifyAttr{From: from.Schemas[0].Attrs[0], To: to.Schemas[0].Attrs[0]}}},
 		&schema.ModifyTable{T: from.Schemas[0].Tables[0], Changes: []schema.Change{&schema.AddColumn{C: to.Schemas[0].Tables[0].Columns[0]}}},
 		&schema.DropSchema{S: from.Schemas[1]},
 		&schema.AddSchema{S: to.Schemas[1]},
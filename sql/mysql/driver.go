@@ -64,6 +64,16 @@ func (d *conn) supportsIndexExpr() bool {
 	return !d.mariadb() && d.compareV("8.0.13") != -1
 }
 
+// supportsGeneratedColumns reports if the connected database
+// supports generated columns and exposes their expressions.
+func (d *conn) supportsGeneratedColumns() bool {
+	v := "5.7.6"
+	if d.mariadb() {
+		v = "10.2.5"
+	}
+	return d.compareV(v) != -1
+}
+
 // supportsDisplayWidth reports if the connected database supports
 // getting the display width information from the information schema.
 func (d *conn) supportsDisplayWidth() bool {
@@ -72,6 +82,16 @@ func (d *conn) supportsDisplayWidth() bool {
 	return d.mariadb() || d.compareV("8.0.19") == -1
 }
 
+// supportsRoles reports if the connected database supports roles. Users
+// are created instead of roles in databases that do not support them.
+func (d *conn) supportsRoles() bool {
+	v := "8.0.0"
+	if d.mariadb() {
+		v = "10.1.3"
+	}
+	return d.compareV(v) != -1
+}
+
 // mariadb reports if the Driver is connected to a MariaDB database.
 func (d *conn) mariadb() bool {
 	return strings.Index(d.version, "MariaDB") > 0
@@ -39,9 +39,22 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 			}
 			s.Tables = append(s.Tables, t)
 		}
+		if err := i.grants(ctx, s); err != nil {
+			return nil, err
+		}
 		s.Realm = realm
 	}
+	if err := i.roles(ctx, realm); err != nil {
+		return nil, err
+	}
 	sqlx.LinkSchemaTables(schemas)
+	if opts != nil {
+		for _, s := range schemas {
+			if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return realm, nil
 }
 
@@ -70,8 +83,19 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		}
 		s.Tables = append(s.Tables, t)
 	}
-	sqlx.LinkSchemaTables(schemas)
+	if err := i.grants(ctx, s); err != nil {
+		return nil, err
+	}
 	s.Realm = &schema.Realm{Schemas: schemas, Attrs: []schema.Attr{&schema.Charset{V: i.charset}, &schema.Collation{V: i.collate}}}
+	if err := i.roles(ctx, s.Realm); err != nil {
+		return nil, err
+	}
+	sqlx.LinkSchemaTables(schemas)
+	if opts != nil {
+		if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+			return nil, err
+		}
+	}
 	return s, nil
 }
 
@@ -101,6 +125,9 @@ func (i *inspect) inspectTable(ctx context.Context, name string, opts *schema.In
 	if err := i.checks(ctx, t); err != nil {
 		return nil, err
 	}
+	if err := i.partitions(ctx, t); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
@@ -158,8 +185,9 @@ func (i *inspect) table(ctx context.Context, name string, opts *schema.InspectTa
 	var (
 		autoinc                              sql.NullInt64
 		tSchema, charset, collation, comment sql.NullString
+		engine, options                      sql.NullString
 	)
-	if err := row.Scan(&tSchema, &charset, &collation, &autoinc, &comment); err != nil {
+	if err := row.Scan(&tSchema, &charset, &collation, &autoinc, &comment, &engine, &options); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &schema.NotExistError{
 				Err: fmt.Errorf("mysql: table %q was not found", name),
@@ -188,12 +216,57 @@ func (i *inspect) table(ctx context.Context, name string, opts *schema.InspectTa
 			V: autoinc.Int64,
 		})
 	}
+	if sqlx.ValidString(engine) {
+		t.Attrs = append(t.Attrs, &Engine{
+			V: engine.String,
+		})
+	}
+	if err := createOptions(t, options.String); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
+// createOptions parses the CREATE_OPTIONS column of a table and appends the options
+// that were set explicitly to the table attributes. Note that the ROW_FORMAT column
+// holds the actual format of the table even if it was not set on its definition.
+// For example: "row_format=COMPRESSED KEY_BLOCK_SIZE=8 stats_persistent=1".
+func createOptions(t *schema.Table, options string) error {
+	for _, opt := range strings.Fields(options) {
+		// Partitioned tables are marked with the "partitioned" option,
+		// and their partitions are inspected after the table columns.
+		if strings.EqualFold(opt, "partitioned") {
+			t.Attrs = append(t.Attrs, &Partition{})
+			continue
+		}
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.ToUpper(kv[0]) {
+		case "ROW_FORMAT":
+			t.Attrs = append(t.Attrs, &RowFormat{V: strings.ToUpper(kv[1])})
+		case "KEY_BLOCK_SIZE":
+			v, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("mysql: parse KEY_BLOCK_SIZE option %q: %w", kv[1], err)
+			}
+			t.Attrs = append(t.Attrs, &KeyBlockSize{V: v})
+		case "STATS_PERSISTENT":
+			// The DEFAULT value is not listed in CREATE_OPTIONS.
+			t.Attrs = append(t.Attrs, &StatsPersistent{V: kv[1] == "1"})
+		}
+	}
+	return nil
+}
+
 // columns queries and appends the columns of the given table.
 func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
-	rows, err := i.QueryContext(ctx, columnsQuery, t.Schema.Name, t.Name)
+	query := columnsQuery
+	if i.supportsGeneratedColumns() {
+		query = columnsExprQuery
+	}
+	rows, err := i.QueryContext(ctx, query, t.Schema.Name, t.Name)
 	if err != nil {
 		return fmt.Errorf("mysql: querying %q columns: %w", t.Name, err)
 	}
@@ -208,8 +281,8 @@ func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
 
 // addColumn scans the current row and adds a new column from it to the table.
 func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
-	var name, typ, comment, nullable, key, defaults, extra, charset, collation sql.NullString
-	if err := rows.Scan(&name, &typ, &comment, &nullable, &key, &defaults, &extra, &charset, &collation); err != nil {
+	var name, typ, comment, nullable, key, defaults, extra, charset, collation, expr sql.NullString
+	if err := rows.Scan(&name, &typ, &comment, &nullable, &key, &defaults, &extra, &charset, &collation, &expr); err != nil {
 		return err
 	}
 	c := &schema.Column{
@@ -227,6 +300,12 @@ func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 	if err := extraAttr(c, extra.String); err != nil {
 		return err
 	}
+	for _, a := range c.Attrs {
+		if x, ok := a.(*schema.GeneratedExpr); ok && sqlx.ValidString(expr) {
+			// MySQL escapes the single quotes of string literals in the generation expression.
+			x.Expr = strings.ReplaceAll(expr.String, `\'`, "'")
+		}
+	}
 	if sqlx.ValidString(defaults) {
 		x := defaults.String
 		// From MariaDB 10.2.7, literals are quoted to distinguish them from expressions.
@@ -522,6 +601,131 @@ func (i *inspect) checks(ctx context.Context, t *schema.Table) error {
 	return rows.Err()
 }
 
+// partitions queries and appends the partitions of the given table, if it is
+// partitioned. Note that subpartitions are not supported, and only the first
+// subpartition of each partition is used for describing it.
+func (i *inspect) partitions(ctx context.Context, t *schema.Table) error {
+	p := partition(t.Attrs)
+	if p == nil {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, partitionsQuery, t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: querying %q partitions: %w", t.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, method, expr, desc sql.NullString
+		if err := rows.Scan(&name, &method, &expr, &desc); err != nil {
+			return fmt.Errorf("mysql: scanning partition: %w", err)
+		}
+		if p.T == "" {
+			p.T = method.String
+			for _, x := range sqlx.SplitExprs(expr.String) {
+				part := &PartitionPart{X: &schema.RawExpr{X: x}}
+				if c, ok := t.Column(strings.Trim(x, "`")); ok {
+					part.C, part.X = c, nil
+				}
+				p.Parts = append(p.Parts, part)
+			}
+		}
+		p.Defs = append(p.Defs, &PartitionDef{
+			Name:  name.String,
+			Bound: partitionBound(p.T, desc.String),
+		})
+	}
+	return rows.Err()
+}
+
+// partitionBound returns the VALUES clause of a partition from its description
+// in INFORMATION_SCHEMA. HASH and KEY partitions do not have a VALUES clause.
+func partitionBound(method, desc string) string {
+	switch method = strings.ToUpper(method); {
+	case strings.HasPrefix(method, "RANGE") && desc == "MAXVALUE" && !strings.HasSuffix(method, "COLUMNS"):
+		return "VALUES LESS THAN MAXVALUE"
+	case strings.HasPrefix(method, "RANGE"):
+		return "VALUES LESS THAN (" + desc + ")"
+	case strings.HasPrefix(method, "LIST"):
+		return "VALUES IN (" + desc + ")"
+	default:
+		return ""
+	}
+}
+
+// partition returns the partition attribute of a table, if exists.
+func partition(attrs []schema.Attr) *Partition {
+	for _, a := range attrs {
+		if p, ok := a.(*Partition); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// grants inspects the privileges that were granted on the schema, its tables
+// and their columns, and adds them to the schema and its tables.
+func (i *inspect) grants(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, grantsQuery, s.Name, s.Name, s.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: querying schema %q grants: %w", s.Name, err)
+	}
+	defer rows.Close()
+	if err := sqlx.ScanGrants(s, rows); err != nil {
+		return fmt.Errorf("mysql: scanning schema %q grants: %w", s.Name, err)
+	}
+	for _, attrs := range append([][]schema.Attr{s.Attrs}, tablesAttrs(s)...) {
+		for _, a := range attrs {
+			if g, ok := a.(*schema.Grant); ok {
+				g.Grantee = grantee(g.Grantee)
+			}
+		}
+	}
+	return nil
+}
+
+// roles inspects the users and roles defined in the database, and adds them to the realm.
+func (i *inspect) roles(ctx context.Context, r *schema.Realm) error {
+	rows, err := i.QueryContext(ctx, rolesQuery)
+	if err != nil {
+		return fmt.Errorf("mysql: querying roles: %w", err)
+	}
+	names, err := sqlx.ScanStrings(rows)
+	if err != nil {
+		return fmt.Errorf("mysql: scanning roles: %w", err)
+	}
+	for _, name := range names {
+		// Skip system accounts, such as 'mysql.sys'@'localhost'.
+		if name = grantee(name); !strings.HasPrefix(name, "mysql.") {
+			r.Attrs = append(r.Attrs, &schema.Role{Name: name})
+		}
+	}
+	return nil
+}
+
+// grantee converts an account name in its INFORMATION_SCHEMA form (e.g. 'user'@'host')
+// to its Atlas form. Accounts that match any host (i.e. '%'), or have no host part at
+// all (e.g. MariaDB roles), are represented by their user name only, and others are
+// represented in the "user@host" form.
+func grantee(s string) string {
+	parts := strings.SplitN(s, "'@'", 2)
+	if len(parts) != 2 {
+		return strings.Trim(s, "'")
+	}
+	user, host := strings.TrimPrefix(parts[0], "'"), strings.TrimSuffix(parts[1], "'")
+	if host == "%" || host == "" {
+		return user
+	}
+	return user + "@" + host
+}
+
+func tablesAttrs(s *schema.Schema) [][]schema.Attr {
+	attrs := make([][]schema.Attr, len(s.Tables))
+	for i, t := range s.Tables {
+		attrs[i] = t.Attrs
+	}
+	return attrs
+}
+
 // tableNames returns a list of all tables exist in the schema.
 func (i *inspect) tableNames(ctx context.Context, schema string, opts *schema.InspectOptions) ([]string, error) {
 	query, args := tablesQuery, []interface{}{schema}
@@ -566,16 +770,24 @@ func parseColumn(typ string) (parts []string, size int64, unsigned bool, err err
 // extraAttr parses the EXTRA column from the INFORMATION_SCHEMA.COLUMNS table
 // and appends its parsed representation to the column.
 func extraAttr(c *schema.Column, extra string) error {
-	switch extra := strings.ToLower(extra); extra {
-	case "", "null": // ignore.
-	case "default_generated":
+	switch extra := strings.ToLower(extra); {
+	case extra == "", extra == "null": // ignore.
+	case extra == "default_generated":
 		// The column has an expression default value
 		// and it's handled in Driver.addColumn.
-	case "auto_increment":
+	case extra == "auto_increment":
 		c.Attrs = append(c.Attrs, &AutoIncrement{A: extra})
-	case "default_generated on update current_timestamp", "on update current_timestamp",
-		"on update current_timestamp()" /* MariaDB format. */ :
-		c.Attrs = append(c.Attrs, &OnUpdate{A: extra})
+	case extra == "virtual generated", extra == "stored generated":
+		// The generation expression is set in Driver.addColumn.
+		c.Attrs = append(c.Attrs, &schema.GeneratedExpr{
+			Type: strings.ToUpper(strings.TrimSuffix(extra, " generated")),
+		})
+	// For example, "on update current_timestamp", "on update current_timestamp()"
+	// (MariaDB format), or "default_generated on update current_timestamp(6)".
+	case strings.HasPrefix(strings.TrimPrefix(extra, "default_generated "), "on update "):
+		c.Attrs = append(c.Attrs, &OnUpdate{
+			A: strings.ToUpper(strings.TrimPrefix(strings.TrimPrefix(extra, "default_generated "), "on update ")),
+		})
 	default:
 		return fmt.Errorf("unknown attribute %q", extra)
 	}
@@ -593,7 +805,8 @@ const (
 	tablesQuery = "SELECT `TABLE_NAME` FROM `INFORMATION_SCHEMA`.`TABLES` WHERE `TABLE_TYPE` = 'BASE TABLE' AND `TABLE_SCHEMA` = ?"
 
 	// Query to list table columns.
-	columnsQuery = "SELECT `COLUMN_NAME`, `COLUMN_TYPE`, `COLUMN_COMMENT`, `IS_NULLABLE`, `COLUMN_KEY`, `COLUMN_DEFAULT`, `EXTRA`, `CHARACTER_SET_NAME`, `COLLATION_NAME` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `ORDINAL_POSITION`"
+	columnsQuery     = "SELECT `COLUMN_NAME`, `COLUMN_TYPE`, `COLUMN_COMMENT`, `IS_NULLABLE`, `COLUMN_KEY`, `COLUMN_DEFAULT`, `EXTRA`, `CHARACTER_SET_NAME`, `COLLATION_NAME`, NULL AS `GENERATION_EXPRESSION` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `ORDINAL_POSITION`"
+	columnsExprQuery = "SELECT `COLUMN_NAME`, `COLUMN_TYPE`, `COLUMN_COMMENT`, `IS_NULLABLE`, `COLUMN_KEY`, `COLUMN_DEFAULT`, `EXTRA`, `CHARACTER_SET_NAME`, `COLLATION_NAME`, `GENERATION_EXPRESSION` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `ORDINAL_POSITION`"
 
 	// Query to list table indexes.
 	indexesQuery     = "SELECT `INDEX_NAME`, `COLUMN_NAME`, `NON_UNIQUE`, `SEQ_IN_INDEX`, `INDEX_TYPE`, `COLLATION`, `INDEX_COMMENT`, `SUB_PART`, NULL AS `EXPRESSION` FROM `INFORMATION_SCHEMA`.`STATISTICS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `index_name`, `seq_in_index`"
@@ -606,7 +819,9 @@ SELECT
 	t2.CHARACTER_SET_NAME,
 	t1.TABLE_COLLATION,
 	t1.AUTO_INCREMENT,
-	t1.TABLE_COMMENT
+	t1.TABLE_COMMENT,
+	t1.ENGINE,
+	t1.CREATE_OPTIONS
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN INFORMATION_SCHEMA.COLLATIONS AS t2
@@ -621,7 +836,9 @@ SELECT
 	t2.CHARACTER_SET_NAME,
 	t1.TABLE_COLLATION,
 	t1.AUTO_INCREMENT,
-	t1.TABLE_COMMENT
+	t1.TABLE_COMMENT,
+	t1.ENGINE,
+	t1.CREATE_OPTIONS
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN INFORMATION_SCHEMA.COLLATIONS AS t2
@@ -631,6 +848,67 @@ WHERE
 	AND TABLE_SCHEMA = ?
 `
 
+	// Query to list the partitions of a table.
+	partitionsQuery = `
+SELECT
+	PARTITION_NAME,
+	PARTITION_METHOD,
+	PARTITION_EXPRESSION,
+	PARTITION_DESCRIPTION
+FROM
+	INFORMATION_SCHEMA.PARTITIONS
+WHERE
+	TABLE_SCHEMA = ?
+	AND TABLE_NAME = ?
+	AND (SUBPARTITION_ORDINAL_POSITION IS NULL OR SUBPARTITION_ORDINAL_POSITION = 1)
+ORDER BY
+	PARTITION_ORDINAL_POSITION
+`
+
+	// Query to list the privileges granted on a schema, its tables and their columns.
+	grantsQuery = `
+SELECT
+	'' AS TABLE_NAME,
+	'' AS COLUMN_NAME,
+	GRANTEE,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE
+FROM
+	INFORMATION_SCHEMA.SCHEMA_PRIVILEGES
+WHERE
+	TABLE_SCHEMA = ?
+UNION ALL
+SELECT
+	TABLE_NAME,
+	'' AS COLUMN_NAME,
+	GRANTEE,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE
+FROM
+	INFORMATION_SCHEMA.TABLE_PRIVILEGES
+WHERE
+	TABLE_SCHEMA = ?
+UNION ALL
+SELECT
+	TABLE_NAME,
+	COLUMN_NAME,
+	GRANTEE,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE
+FROM
+	INFORMATION_SCHEMA.COLUMN_PRIVILEGES
+WHERE
+	TABLE_SCHEMA = ?
+ORDER BY
+	TABLE_NAME,
+	COLUMN_NAME,
+	GRANTEE,
+	PRIVILEGE_TYPE
+`
+
+	// Query to list the users and roles in the database.
+	rolesQuery = "SELECT DISTINCT `GRANTEE` FROM `INFORMATION_SCHEMA`.`USER_PRIVILEGES` ORDER BY `GRANTEE`"
+
 	// Query to list table check constraints.
 	myChecksQuery  = `SELECT t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, t1.ENFORCED` + checksQuery
 	marChecksQuery = `SELECT t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, "YES" AS ENFORCED` + checksQuery
@@ -685,6 +963,7 @@ type (
 	}
 
 	// OnUpdate attribute for columns with "ON UPDATE CURRENT_TIMESTAMP" as a default.
+	// The A field holds the expression, e.g. "CURRENT_TIMESTAMP".
 	OnUpdate struct {
 		schema.Attr
 		A string
@@ -704,6 +983,62 @@ type (
 		Enforced bool
 	}
 
+	// Engine attribute describes the storage engine of a table (e.g. InnoDB, MyISAM).
+	Engine struct {
+		schema.Attr
+		V string
+	}
+
+	// RowFormat attribute describes the ROW_FORMAT option of a table (e.g. DYNAMIC, COMPRESSED).
+	RowFormat struct {
+		schema.Attr
+		V string
+	}
+
+	// KeyBlockSize attribute describes the KEY_BLOCK_SIZE option of a table.
+	KeyBlockSize struct {
+		schema.Attr
+		V int64
+	}
+
+	// StatsPersistent attribute describes the STATS_PERSISTENT option of a table.
+	// Tables without this attribute use the value of the innodb_stats_persistent
+	// server variable.
+	StatsPersistent struct {
+		schema.Attr
+		V bool
+	}
+
+	// Partition defines the spec of a partitioned table.
+	// https://dev.mysql.com/doc/refman/8.0/en/partitioning.html
+	Partition struct {
+		schema.Attr
+		// T defines the partitioning type. For example:
+		// RANGE, LIST, HASH, KEY, RANGE COLUMNS or LINEAR HASH.
+		T string
+		// Parts holds the partitioning key, where each part
+		// is either a table column or an expression.
+		Parts []*PartitionPart
+		// Defs holds the partitions of the table.
+		Defs []*PartitionDef
+	}
+
+	// PartitionPart represents a part of a partitioning key.
+	PartitionPart struct {
+		C *schema.Column
+		X schema.Expr
+	}
+
+	// PartitionDef defines a partition of a table.
+	PartitionDef struct {
+		schema.Attr
+		Name string
+		// Bound holds the VALUES clause of the partition. For example,
+		// "VALUES LESS THAN (2022)" or "VALUES IN (1, 2)". It is empty
+		// for HASH and KEY partitions.
+		Bound string
+	}
+
 	// The DisplayWidth represents a display width of an integer type.
 	DisplayWidth struct {
 		schema.Attr
@@ -57,20 +57,20 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.ExpectQuery(sqltest.Escape(tableQuery)).
 					WithArgs("users").
 					WillReturnRows(sqltest.Rows(`
-+--------------+--------------------+--------------------+----------------+---------------+
-| TABLE_SCHEMA | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT |
-+--------------+--------------------+--------------------+----------------+---------------+
-| test         | utf8mb4            | utf8mb4_0900_ai_ci | nil            | Comment       |
-+--------------+--------------------+--------------------+----------------+---------------+
++--------------+--------------------+--------------------+----------------+---------------+--------+-----------------------------------------------------------+
+| TABLE_SCHEMA | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT | ENGINE | CREATE_OPTIONS                                            |
++--------------+--------------------+--------------------+----------------+---------------+--------+-----------------------------------------------------------+
+| test         | utf8mb4            | utf8mb4_0900_ai_ci | nil            | Comment       | InnoDB | row_format=COMPRESSED KEY_BLOCK_SIZE=8 stats_persistent=0 |
++--------------+--------------------+--------------------+----------------+---------------+--------+-----------------------------------------------------------+
 `))
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("test", "users").
 					WillReturnRows(sqltest.Rows(`
-+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| column_name        | column_type          | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name |
-+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| id                 | bigint(20)           |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           |
-+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| column_name        | column_type          | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression |
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| id                 | bigint(20)           |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           | NULL                  |
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -82,6 +82,10 @@ func TestDriver_InspectTable(t *testing.T) {
 					&schema.Charset{V: "utf8mb4"},
 					&schema.Collation{V: "utf8mb4_0900_ai_ci"},
 					&schema.Comment{Text: "Comment"},
+					&Engine{V: "InnoDB"},
+					&RowFormat{V: "COMPRESSED"},
+					&KeyBlockSize{V: 8},
+					&StatsPersistent{V: false},
 				}, t.Attrs)
 				require.Len(t.PrimaryKey.Parts, 1)
 				require.True(t.PrimaryKey.Parts[0].C == t.Columns[0])
@@ -90,32 +94,85 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.Columns)
 			},
 		},
+		{
+			name: "partitions",
+			before: func(m mock) {
+				m.version("8.0.13")
+				m.ExpectQuery(sqltest.Escape(tableQuery)).
+					WithArgs("users").
+					WillReturnRows(sqltest.Rows(`
++--------------+--------------------+--------------------+----------------+---------------+--------+----------------+
+| TABLE_SCHEMA | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT | ENGINE | CREATE_OPTIONS |
++--------------+--------------------+--------------------+----------------+---------------+--------+----------------+
+| test         | utf8mb4            | utf8mb4_0900_ai_ci | nil            |               | InnoDB | partitioned    |
++--------------+--------------------+--------------------+----------------+---------------+--------+----------------+
+`))
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
+					WithArgs("test", "users").
+					WillReturnRows(sqltest.Rows(`
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| column_name        | column_type          | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression |
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| created_at         | date                 |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
++--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.ExpectQuery(sqltest.Escape(partitionsQuery)).
+					WithArgs("test", "users").
+					WillReturnRows(sqltest.Rows(`
++----------------+------------------+----------------------+-----------------------+
+| PARTITION_NAME | PARTITION_METHOD | PARTITION_EXPRESSION | PARTITION_DESCRIPTION |
++----------------+------------------+----------------------+-----------------------+
+| p2021          | RANGE            | year(created_at)     | 2022                  |
+| pmax           | RANGE            | year(created_at)     | MAXVALUE              |
++----------------+------------------+----------------------+-----------------------+
+`))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal("users", t.Name)
+				require.EqualValues([]schema.Attr{
+					&schema.Charset{V: "utf8mb4"},
+					&schema.Collation{V: "utf8mb4_0900_ai_ci"},
+					&Engine{V: "InnoDB"},
+					&Partition{
+						T:     "RANGE",
+						Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(created_at)"}}},
+						Defs: []*PartitionDef{
+							{Name: "p2021", Bound: "VALUES LESS THAN (2022)"},
+							{Name: "pmax", Bound: "VALUES LESS THAN MAXVALUE"},
+						},
+					},
+				}, t.Attrs)
+			},
+		},
 		{
 			name: "int types",
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| column_name        | column_type                  | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name |
-+--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| id                 | bigint(20)                   |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           |
-| v57_tiny           | tinyint(1)                   |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v57_tiny_unsigned  | tinyint(4) unsigned          |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v57_small          | smallint(6)                  |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v57_small_unsigned | smallint(6) unsigned         |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v57_int            | bigint(11)                   |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v57_int_unsigned   | bigint(11) unsigned          |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_tiny            | tinyint                      |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_tiny_unsigned   | tinyint unsigned             |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_small           | smallint                     |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_small_unsigned  | smallint unsigned            |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_big             | bigint                       |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_big_unsigned    | bigint unsigned              | comment              | NO          |            | NULL           |                | NULL               | NULL           |
-| v8_big_zerofill    | bigint(20) unsigned zerofill | comment              | NO          |            | NULL           |                | NULL               | NULL           |
-+--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
++--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| column_name        | column_type                  | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression |
++--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| id                 | bigint(20)                   |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           | NULL                  |
+| v57_tiny           | tinyint(1)                   |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v57_tiny_unsigned  | tinyint(4) unsigned          |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v57_small          | smallint(6)                  |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v57_small_unsigned | smallint(6) unsigned         |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v57_int            | bigint(11)                   |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v57_int_unsigned   | bigint(11) unsigned          |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_tiny            | tinyint                      |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_tiny_unsigned   | tinyint unsigned             |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_small           | smallint                     |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_small_unsigned  | smallint unsigned            |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_big             | bigint                       |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_big_unsigned    | bigint unsigned              | comment              | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| v8_big_zerofill    | bigint(20) unsigned zerofill | comment              | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
++--------------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -148,17 +205,17 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("10.7.1-MariaDB")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| column_name   | column_type                  | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name |
-+---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
-| id            | bigint(20)                   |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           |
-| tiny_int      | tinyint(1)                   |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| longtext      | longtext                     |                      | NO          |            | NULL           |                | NULL               | NULL           |
-| jsonc         | longtext                     |                      | NO          |            | NULL           |                | NULL               | NULL           |
-+---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+
++---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| column_name   | column_type                  | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression |
++---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
+| id            | bigint(20)                   |                      | NO          | PRI        | NULL           | auto_increment | NULL               | NULL           | NULL                  |
+| tiny_int      | tinyint(1)                   |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| longtext      | longtext                     |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
+| jsonc         | longtext                     |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                  |
++---------------+------------------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+-----------------------+
 `))
 				m.ExpectQuery(sqltest.Escape(indexesQuery)).
 					WillReturnRows(sqlmock.NewRows([]string{"index_name", "column_name", "non_unique", "key_part", "expression"}))
@@ -191,15 +248,15 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| d1          | decimal(10,2) |                | NO          |            | 10.20          |       | NULL               | NULL           |
-| d2          | decimal(10,0) |                | NO          |            | 10             |       | NULL               | NULL           |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| d1          | decimal(10,2) |                | NO          |            | 10.20          |       | NULL               | NULL           | NULL                  |
+| d2          | decimal(10,0) |                | NO          |            | 10             |       | NULL               | NULL           | NULL                  |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -218,15 +275,15 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| column_name | column_type  | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name |
-+-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| float       | float        |                | NO          |            |                |       | NULL               | NULL           |
-| double      | double       |                | NO          |            |                |       | NULL               | NULL           |
-+-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| column_name | column_type  | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| float       | float        |                | NO          |            |                |       | NULL               | NULL           | NULL                  |
+| double      | double       |                | NO          |            |                |       | NULL               | NULL           | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -245,19 +302,19 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| c1          | binary(20)    |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c2          | varbinary(30) |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c3          | tinyblob      |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c4          | mediumblob    |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c5          | blob          |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c6          | longblob      |                | NO          |            | NULL           |       | NULL               | NULL           |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| c1          | binary(20)    |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c2          | varbinary(30) |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c3          | tinyblob      |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c4          | mediumblob    |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c5          | blob          |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c6          | longblob      |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -280,19 +337,19 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| c1          | char(20)      |                | NO          |            | char           |       | NULL               | NULL           |
-| c2          | varchar(30)   |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c3          | tinytext      |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c4          | mediumtext    |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c5          | text          |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c6          | longtext      |                | NO          |            | NULL           |       | NULL               | NULL           |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| c1          | char(20)      |                | NO          |            | char           |       | NULL               | NULL           | NULL                  |
+| c2          | varchar(30)   |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c3          | tinytext      |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c4          | mediumtext    |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c5          | text          |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c6          | longtext      |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -315,15 +372,15 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+
-| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name    |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+
-| c1          | enum('a','b') |                | NO          |            | NULL           |       | latin1             | latin1_swedish_ci |
-| c2          | enum('c','d') |                | NO          |            | d              |       | latin1             | latin1_swedish_ci |
-+-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+-----------------------+
+| column_name | column_type   | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name    | generation_expression |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+-----------------------+
+| c1          | enum('a','b') |                | NO          |            | NULL           |       | latin1             | latin1_swedish_ci | NULL                  |
+| c2          | enum('c','d') |                | NO          |            | d              |       | latin1             | latin1_swedish_ci | NULL                  |
++-------------+---------------+----------------+-------------+------------+----------------+-------+--------------------+-------------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -342,19 +399,20 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+-------------+-------------------+-------------+------------+-------------------+-----------------------------+--------------------+----------------+
-| column_name | column_type | column_comment    | is_nullable | column_key | column_default    | extra                       | character_set_name | collation_name |
-+-------------+-------------+-------------------+-------------+------------+-------------------+-----------------------------+--------------------+----------------+
-| c1          | date        |                   | NO          |            | NULL              |                             | NULL               | NULL           |
-| c2          | datetime    |                   | NO          |            | NULL              |                             | NULL               | NULL           |
-| c3          | time        |                   | NO          |            | NULL              |                             | NULL               | NULL           |
-| c4          | timestamp   |                   | NO          |            | CURRENT_TIMESTAMP | on update CURRENT_TIMESTAMP | NULL               | NULL           |
-| c5          | year(4)     |                   | NO          |            | NULL              |                             | NULL               | NULL           |
-| c6          | year        |                   | NO          |            | NULL              |                             | NULL               | NULL           |
-+-------------+-------------+-------------------+-------------+------------+-------------------+-----------------------------+--------------------+----------------+
++-------------+--------------+----------------+-------------+------------+----------------------+--------------------------------------------------+--------------------+----------------+-----------------------+
+| column_name | column_type  | column_comment | is_nullable | column_key | column_default       | extra                                            | character_set_name | collation_name | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------------+--------------------------------------------------+--------------------+----------------+-----------------------+
+| c1          | date         |                | NO          |            | NULL                 |                                                  | NULL               | NULL           | NULL                  |
+| c2          | datetime     |                | NO          |            | NULL                 |                                                  | NULL               | NULL           | NULL                  |
+| c3          | time         |                | NO          |            | NULL                 |                                                  | NULL               | NULL           | NULL                  |
+| c4          | timestamp    |                | NO          |            | CURRENT_TIMESTAMP    | on update CURRENT_TIMESTAMP                      | NULL               | NULL           | NULL                  |
+| c5          | year(4)      |                | NO          |            | NULL                 |                                                  | NULL               | NULL           | NULL                  |
+| c6          | year         |                | NO          |            | NULL                 |                                                  | NULL               | NULL           | NULL                  |
+| c7          | timestamp(6) |                | NO          |            | CURRENT_TIMESTAMP(6) | DEFAULT_GENERATED on update CURRENT_TIMESTAMP(6) | NULL               | NULL           | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------------+--------------------------------------------------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -366,9 +424,10 @@ func TestDriver_InspectTable(t *testing.T) {
 					{Name: "c1", Type: &schema.ColumnType{Raw: "date", Type: &schema.TimeType{T: "date"}}},
 					{Name: "c2", Type: &schema.ColumnType{Raw: "datetime", Type: &schema.TimeType{T: "datetime"}}},
 					{Name: "c3", Type: &schema.ColumnType{Raw: "time", Type: &schema.TimeType{T: "time"}}},
-					{Name: "c4", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: "CURRENT_TIMESTAMP"}, Attrs: []schema.Attr{&OnUpdate{A: "on update current_timestamp"}}},
+					{Name: "c4", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: "CURRENT_TIMESTAMP"}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}},
 					{Name: "c5", Type: &schema.ColumnType{Raw: "year(4)", Type: &schema.TimeType{T: "year"}}},
 					{Name: "c6", Type: &schema.ColumnType{Raw: "year", Type: &schema.TimeType{T: "year"}}},
+					{Name: "c7", Type: &schema.ColumnType{Raw: "timestamp(6)", Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: "CURRENT_TIMESTAMP(6)"}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP(6)"}}},
 				}, t.Columns)
 			},
 		},
@@ -377,14 +436,14 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME |
-+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| c1          | json        |                | NO          |            | NULL           |       | NULL               | NULL           |
-+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | generation_expression |
++-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| c1          | json        |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -402,22 +461,22 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| column_name | column_type        | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name |
-+-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
-| c1          | point              |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c2          | multipoint         |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c3          | linestring         |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c4          | multilinestring    |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c5          | polygon            |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c6          | multipolygon       |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c7          | geometry           |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c8          | geometrycollection |                | NO          |            | NULL           |       | NULL               | NULL           |
-| c9          | geomcollection     |                | NO          |            | NULL           |       | NULL               | NULL           |
-+-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+
++-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| column_name | column_type        | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| c1          | point              |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c2          | multipoint         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c3          | linestring         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c4          | multilinestring    |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c5          | polygon            |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c6          | multipolygon       |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c7          | geometry           |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c8          | geometrycollection |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| c9          | geomcollection     |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++-------------+--------------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -438,22 +497,51 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.Columns)
 			},
 		},
+		{
+			name: "generated columns",
+			before: func(m mock) {
+				m.version("8.0.13")
+				m.tableExists("public", "users", true)
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
++-------------+-------------+----------------+-------------+------------+----------------+-------------------+--------------------+----------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA             | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++-------------+-------------+----------------+-------------+------------+----------------+-------------------+--------------------+----------------+-----------------------+
+| c1          | int         |                | NO          |            | NULL           |                   | NULL               | NULL           |                       |
+| c2          | int         |                | YES         |            | NULL           | VIRTUAL GENERATED | NULL               | NULL           | (` + "`c1`" + ` * 2)         |
+| c3          | int         |                | YES         |            | NULL           | STORED GENERATED  | NULL               | NULL           | (` + "`c1`" + ` + 1)         |
++-------------+-------------+----------------+-------------+------------+----------------+-------------------+--------------------+----------------+-----------------------+
+`))
+				m.noIndexes()
+				m.noFKs()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal("users", t.Name)
+				require.EqualValues([]*schema.Column{
+					{Name: "c1", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+					{Name: "c2", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}, Null: true}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`c1` * 2)", Type: "VIRTUAL"}}},
+					{Name: "c3", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}, Null: true}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`c1` + 1)", Type: "STORED"}}},
+				}, t.Columns)
+			},
+		},
 		{
 			name: "indexes",
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               |
-| nickname    | varchar(255) |                | NO          | UNI        | NULL           |                | utf8mb4            | utf8mb4_0900_ai_ci |
-| oid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               |
-| uid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               | NULL                  |
+| nickname    | varchar(255) |                | NO          | UNI        | NULL           |                | utf8mb4            | utf8mb4_0900_ai_ci | NULL                  |
+| oid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               | NULL                  |
+| uid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
 `))
 				m.ExpectQuery(sqltest.Escape(indexesExprQuery)).
 					WithArgs("public", "users").
@@ -514,16 +602,16 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.13")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               |
-| oid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               |
-| uid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               | NULL                  |
+| oid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               | NULL                  |
+| uid         | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
 `))
 				m.noIndexes()
 				m.ExpectQuery(sqltest.Escape(fksQuery)).
@@ -563,15 +651,15 @@ func TestDriver_InspectTable(t *testing.T) {
 			before: func(m mock) {
 				m.version("8.0.16")
 				m.tableExists("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               |
-| c1          | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               | NULL                  |
+| c1          | int          |                | NO          | MUL        | NULL           |                | NULL               | NULL               | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -631,6 +719,8 @@ func TestDriver_InspectSchema(t *testing.T) {
 +-------------+----------------------------+------------------------+
 				`))
 				m.tables("public")
+				m.grants("public")
+				m.roles("'root'@'localhost'", "'mysql.sys'@'localhost'", "'app'@'%'")
 			},
 			expect: func(require *require.Assertions, s *schema.Schema, err error) {
 				require.NoError(err)
@@ -652,6 +742,8 @@ func TestDriver_InspectSchema(t *testing.T) {
 							&schema.Collation{
 								V: "utf8_general_ci",
 							},
+							&schema.Role{Name: "root@localhost"},
+							&schema.Role{Name: "app"},
 						},
 					}
 					realm.Schemas[0].Realm = realm
@@ -673,15 +765,15 @@ func TestDriver_InspectSchema(t *testing.T) {
 				`))
 				m.tables("public", "users", "pets")
 				m.tableExistsInSchema("public", "users", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               |
-| spouse_id   | int          |                | YES         | NULL       | NULL           |                | NULL               | NULL               |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               | NULL                  |
+| spouse_id   | int          |                | YES         | NULL       | NULL           |                | NULL               | NULL               | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
 		`))
 				m.noIndexes()
 				m.ExpectQuery(sqltest.Escape(fksQuery)).
@@ -695,15 +787,15 @@ func TestDriver_InspectSchema(t *testing.T) {
 		`))
 
 				m.tableExistsInSchema("public", "pets", true)
-				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				m.ExpectQuery(sqltest.Escape(columnsExprQuery)).
 					WithArgs("public", "pets").
 					WillReturnRows(sqltest.Rows(`
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
-| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               |
-| owner_id    | int          |                | YES         | NULL       | NULL           |                | NULL               | NULL               |
-+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | generation_expression |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
+| id          | int          |                | NO          | PRI        | NULL           | auto_increment | NULL               | NULL               | NULL                  |
+| owner_id    | int          |                | YES         | NULL       | NULL           |                | NULL               | NULL               | NULL                  |
++-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+-----------------------+
 		`))
 				m.noIndexes()
 				m.ExpectQuery(sqltest.Escape(fksQuery)).
@@ -715,6 +807,23 @@ func TestDriver_InspectSchema(t *testing.T) {
 | owner_id         | pets       | owner_id    | public       | users                 | id                     | public                 | NO ACTION   | CASCADE     |
 +------------------+------------+-------------+--------------+-----------------------+------------------------+------------------------+-------------+-------------+
 		`))
+				m.ExpectQuery(sqltest.Escape(grantsQuery)).
+					WithArgs("public", "public", "public").
+					WillReturnRows(sqltest.Rows(`
++------------+-------------+-----------------------+----------------+--------------+
+| TABLE_NAME | COLUMN_NAME | GRANTEE               | PRIVILEGE_TYPE | IS_GRANTABLE |
++------------+-------------+-----------------------+----------------+--------------+
+|            |             | 'app'@'%'             | SELECT         | NO           |
+| users      |             | 'app'@'%'             | INSERT         | NO           |
+| users      |             | 'app'@'%'             | UPDATE         | NO           |
+| users      |             | 'admin'@'localhost'   | DELETE         | YES          |
+| users      | id          | 'reporter'@'%'        | SELECT         | NO           |
+| users      | spouse_id   | 'reporter'@'%'        | SELECT         | NO           |
+| users      | spouse_id   | 'reporter'@'%'        | UPDATE         | NO           |
+| view       |             | 'app'@'%'             | SELECT         | NO           |
++------------+-------------+-----------------------+----------------+--------------+
+		`))
+				m.roles()
 			},
 			expect: func(require *require.Assertions, s *schema.Schema, err error) {
 				require.NoError(err)
@@ -746,6 +855,19 @@ func TestDriver_InspectSchema(t *testing.T) {
 				petsFKs[0].Columns = petsColumns[1:]
 				require.EqualValues(petsColumns, pets.Columns)
 				require.EqualValues(petsFKs, pets.ForeignKeys)
+
+				require.EqualValues([]schema.Attr{
+					&schema.Charset{V: "utf8mb4"},
+					&schema.Collation{V: "utf8mb4_unicode_ci"},
+					&schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}},
+				}, s.Attrs)
+				require.EqualValues([]schema.Attr{
+					&schema.Grant{Grantee: "app", Privileges: []string{"INSERT", "UPDATE"}},
+					&schema.Grant{Grantee: "admin@localhost", Privileges: []string{"DELETE"}, GrantOption: true},
+					&schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: users.Columns},
+					&schema.Grant{Grantee: "reporter", Privileges: []string{"UPDATE"}, Columns: users.Columns[1:]},
+				}, users.Attrs)
+				require.Empty(pets.Attrs)
 			},
 		},
 	}
@@ -776,6 +898,8 @@ func TestDriver_Realm(t *testing.T) {
 +-------------+----------------------------+------------------------+
 `))
 	mk.tables("test")
+	mk.grants("test")
+	mk.roles()
 	drv, err := Open(db)
 	require.NoError(t, err)
 	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{})
@@ -816,7 +940,10 @@ func TestDriver_Realm(t *testing.T) {
 +-------------+----------------------------+------------------------+
 `))
 	mk.tables("test")
+	mk.grants("test")
 	mk.tables("public")
+	mk.grants("public")
+	mk.roles()
 	realm, err = drv.InspectRealm(context.Background(), &schema.InspectRealmOption{Schemas: []string{"test", "public"}})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -888,10 +1015,25 @@ func (m mock) tables(schema string, names ...string) {
 		WillReturnRows(rows)
 }
 
+func (m mock) grants(schema string) {
+	m.ExpectQuery(sqltest.Escape(grantsQuery)).
+		WithArgs(schema, schema, schema).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "GRANTEE", "PRIVILEGE_TYPE", "IS_GRANTABLE"}))
+}
+
+func (m mock) roles(names ...string) {
+	rows := sqlmock.NewRows([]string{"GRANTEE"})
+	for i := range names {
+		rows.AddRow(names[i])
+	}
+	m.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(rows)
+}
+
 func (m mock) tableExists(schema, table string, exists bool) {
-	rows := sqlmock.NewRows([]string{"table_schema", "table_collation", "character_set", "auto_increment", "table_comment"})
+	rows := sqlmock.NewRows([]string{"table_schema", "table_collation", "character_set", "auto_increment", "table_comment", "engine", "create_options"})
 	if exists {
-		rows.AddRow(schema, nil, nil, nil, nil)
+		rows.AddRow(schema, nil, nil, nil, nil, nil, nil)
 	}
 	m.ExpectQuery(sqltest.Escape(tableQuery)).
 		WithArgs(table).
@@ -899,9 +1041,9 @@ func (m mock) tableExists(schema, table string, exists bool) {
 }
 
 func (m mock) tableExistsInSchema(schema, table string, exists bool) {
-	rows := sqlmock.NewRows([]string{"table_schema", "table_collation", "character_set", "auto_increment", "table_comment"})
+	rows := sqlmock.NewRows([]string{"table_schema", "table_collation", "character_set", "auto_increment", "table_comment", "engine", "create_options"})
 	if exists {
-		rows.AddRow(schema, nil, nil, nil, nil)
+		rows.AddRow(schema, nil, nil, nil, nil, nil, nil)
 	}
 	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
 		WithArgs(table, schema).
@@ -52,10 +52,11 @@ func (m *migrate) topLevel(ctx context.Context, changes []schema.Change) ([]sche
 	for _, c := range changes {
 		switch c := c.(type) {
 		case *schema.AddSchema:
-			b := Build("CREATE DATABASE").Ident(c.S.Name)
+			b := Build("CREATE DATABASE")
 			if sqlx.Has(c.Extra, &schema.IfNotExists{}) {
 				b.P("IF NOT EXISTS")
 			}
+			b.Ident(c.S.Name)
 			if a := (schema.Charset{}); sqlx.Has(c.S.Attrs, &a) {
 				b.P("CHARACTER SET", a.V)
 			}
@@ -65,6 +66,13 @@ func (m *migrate) topLevel(ctx context.Context, changes []schema.Change) ([]sche
 			if _, err := m.ExecContext(ctx, b.String()); err != nil {
 				return nil, fmt.Errorf("add schema: %w", err)
 			}
+			if err := m.grants(ctx, c.S, nil); err != nil {
+				return nil, err
+			}
+		case *schema.ModifySchema:
+			if err := m.modifySchema(ctx, c); err != nil {
+				return nil, err
+			}
 		case *schema.DropSchema:
 			if _, err := m.ExecContext(ctx, Build("DROP DATABASE").Ident(c.S.Name).String()); err != nil {
 				return nil, fmt.Errorf("drop schema: %w", err)
@@ -78,10 +86,11 @@ func (m *migrate) topLevel(ctx context.Context, changes []schema.Change) ([]sche
 
 // addTable builds and executes the query for creating a table in a schema.
 func (m *migrate) addTable(ctx context.Context, add *schema.AddTable) error {
-	b := Build("CREATE TABLE").Table(add.T)
+	b := Build("CREATE TABLE")
 	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
 		b.P("IF NOT EXISTS")
 	}
+	b.Table(add.T)
 	b.Wrap(func(b *sqlx.Builder) {
 		b.MapComma(add.T.Columns, func(i int, b *sqlx.Builder) {
 			m.column(b, add.T, add.T.Columns[i])
@@ -109,10 +118,13 @@ func (m *migrate) addTable(ctx context.Context, add *schema.AddTable) error {
 		}
 	})
 	m.tableAttr(b, add.T.Attrs...)
+	if p := partition(add.T.Attrs); p != nil {
+		m.partitionBy(b, p)
+	}
 	if _, err := m.ExecContext(ctx, b.String()); err != nil {
 		return fmt.Errorf("create table: %w", err)
 	}
-	return nil
+	return m.grants(ctx, add.T.Schema, add.T)
 }
 
 // dropTable builds and executes the query for dropping a table from a schema.
@@ -129,8 +141,20 @@ func (m *migrate) dropTable(ctx context.Context, drop *schema.DropTable) error {
 
 // modifyTable builds and executes the queries for bringing the table into its modified state.
 func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) error {
-	var changes [2][]schema.Change
+	var (
+		changes    [2][]schema.Change
+		partitions []schema.Change
+		grants     []schema.Change
+	)
 	for _, change := range skipAutoChanges(modify.Changes) {
+		if isPartitionChange(change) {
+			partitions = append(partitions, change)
+			continue
+		}
+		if sqlx.IsGrantChange(change) {
+			grants = append(grants, change)
+			continue
+		}
 		switch change := change.(type) {
 		// Constraints should be dropped before dropping columns, because if a column
 		// is a part of multi-column constraints (like, unique index), ALTER TABLE
@@ -163,6 +187,18 @@ func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) e
 			changes[1] = append(changes[1], &schema.AddIndex{
 				I: change.To,
 			})
+		case *schema.ModifyColumn:
+			if change.Change.Is(schema.ChangeGenerated) {
+				recreate, err := recreateGenerated(change)
+				if err != nil {
+					return err
+				}
+				if recreate {
+					changes[1] = append(changes[1], &schema.DropColumn{C: change.From}, &schema.AddColumn{C: change.To})
+					continue
+				}
+			}
+			changes[1] = append(changes[1], change)
 		case *schema.DropAttr:
 			return fmt.Errorf("unsupported change type: %T", change)
 		default:
@@ -176,9 +212,246 @@ func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) e
 			}
 		}
 	}
+	if err := m.alterPartitions(ctx, modify.T, partitions); err != nil {
+		return err
+	}
+	for _, c := range grants {
+		if err := m.grant(ctx, modify.T.Schema, modify.T, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recreateGenerated reports if the column must be recreated (dropped and added) for applying
+// the given change of its generated expression, as MySQL does not support changing the STORED
+// status of generated columns in place. An error is returned for changes that cannot be applied
+// without losing the column data, like converting a regular column to a virtual one.
+func recreateGenerated(change *schema.ModifyColumn) (bool, error) {
+	var x1, x2 schema.GeneratedExpr
+	has1, has2 := sqlx.Has(change.From.Attrs, &x1), sqlx.Has(change.To.Attrs, &x2)
+	stored1, stored2 := strings.EqualFold(x1.Type, "STORED"), strings.EqualFold(x2.Type, "STORED")
+	switch {
+	case has1 && has2:
+		return stored1 != stored2, nil
+	// Stored columns can be converted to or from regular columns in place.
+	case has1 && stored1, has2 && stored2:
+		return false, nil
+	case has1:
+		return false, fmt.Errorf("changing virtual column %q to a regular column is not supported", change.From.Name)
+	default:
+		return false, fmt.Errorf("changing column %q to a virtual column is not supported", change.From.Name)
+	}
+}
+
+// modifySchema executes the changes of the schema attributes. Roles are created
+// before privileges are granted or revoked on the schema.
+func (m *migrate) modifySchema(ctx context.Context, modify *schema.ModifySchema) error {
+	for _, c := range modify.Changes {
+		if sqlx.IsGrantChange(c) {
+			if err := m.grant(ctx, modify.S, nil, c); err != nil {
+				return err
+			}
+			continue
+		}
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			r, ok := c.A.(*schema.Role)
+			if !ok {
+				return fmt.Errorf("unsupported schema attribute: %T", c.A)
+			}
+			// Roles (or users) may already exist, as they are shared by all databases.
+			b := Build("CREATE USER IF NOT EXISTS")
+			if m.supportsRoles() {
+				b = Build("CREATE ROLE IF NOT EXISTS")
+			}
+			if _, err := m.ExecContext(ctx, b.P(granteeIdent(r.Name)).String()); err != nil {
+				return fmt.Errorf("create role: %w", err)
+			}
+		default:
+			return fmt.Errorf("unsupported schema change: %T", c)
+		}
+	}
+	return nil
+}
+
+// grants executes the GRANT statements of a schema or a table that was created.
+func (m *migrate) grants(ctx context.Context, s *schema.Schema, t *schema.Table) error {
+	var attrs []schema.Attr
+	if t != nil {
+		attrs = t.Attrs
+	} else {
+		attrs = s.Attrs
+	}
+	for _, a := range attrs {
+		if g, ok := a.(*schema.Grant); ok {
+			if err := m.grant(ctx, s, t, &schema.AddAttr{A: g}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// grant executes the GRANT and REVOKE statements of a grant change on a schema or on a table.
+// The t argument is nil for schema grants.
+func (m *migrate) grant(ctx context.Context, s *schema.Schema, t *schema.Table, c schema.Change) error {
+	var stmts []string
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		g := c.A.(*schema.Grant)
+		stmts = append(stmts, m.privileges("GRANT", g, g.Privileges, s, t))
+	case *schema.DropAttr:
+		g := c.A.(*schema.Grant)
+		privs := g.Privileges
+		if g.GrantOption {
+			privs = append(privs[:len(privs):len(privs)], "GRANT OPTION")
+		}
+		stmts = append(stmts, m.privileges("REVOKE", g, privs, s, t))
+	case *schema.ModifyAttr:
+		from, to := c.From.(*schema.Grant), c.To.(*schema.Grant)
+		grant, revoke := sqlx.PrivilegesChange(from, to)
+		if len(grant) > 0 {
+			stmts = append(stmts, m.privileges("GRANT", to, grant, s, t))
+		}
+		if len(revoke) > 0 {
+			stmts = append(stmts, m.privileges("REVOKE", from, revoke, s, t))
+		}
+	}
+	for _, stmt := range stmts {
+		if _, err := m.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("grant privileges: %w", err)
+		}
+	}
+	return nil
+}
+
+// privileges builds the GRANT or REVOKE statement of the given privileges.
+func (m *migrate) privileges(verb string, g *schema.Grant, privs []string, s *schema.Schema, t *schema.Table) string {
+	b := Build(verb)
+	b.MapComma(privs, func(i int, b *sqlx.Builder) {
+		b.P(privs[i])
+		if len(g.Columns) > 0 && privs[i] != "GRANT OPTION" {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(g.Columns, func(i int, b *sqlx.Builder) {
+					b.Ident(g.Columns[i].Name)
+				})
+			})
+		}
+	})
+	b.P("ON")
+	if t != nil {
+		b.Table(t)
+	} else {
+		b.P("`" + s.Name + "`.*")
+	}
+	if verb == "REVOKE" {
+		return b.P("FROM", granteeIdent(g.Grantee)).String()
+	}
+	b.P("TO", granteeIdent(g.Grantee))
+	if g.GrantOption {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String()
+}
+
+// granteeIdent returns the account name of a grantee in its quoted form.
+// For example, "user" => 'user', and "user@localhost" => 'user'@'localhost'.
+func granteeIdent(name string) string {
+	if i := strings.LastIndexByte(name, '@'); i > 0 {
+		return "'" + name[:i] + "'@'" + name[i+1:] + "'"
+	}
+	return "'" + name + "'"
+}
+
+// alterPartitions executes the changes of the table partitioning. Each change is executed
+// in a separate ALTER TABLE statement, because partitioning options cannot be combined
+// with other alter specifications.
+func (m *migrate) alterPartitions(ctx context.Context, t *schema.Table, changes []schema.Change) error {
+	for _, c := range changes {
+		b := Build("ALTER TABLE").Table(t)
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			switch a := c.A.(type) {
+			case *Partition:
+				m.partitionBy(b, a)
+			case *PartitionDef:
+				b.P("ADD PARTITION").Wrap(func(b *sqlx.Builder) {
+					m.partitionDef(b, a)
+				})
+			}
+		case *schema.DropAttr:
+			switch a := c.A.(type) {
+			case *Partition:
+				b.P("REMOVE PARTITIONING")
+			case *PartitionDef:
+				b.P("DROP PARTITION").Ident(a.Name)
+			}
+		case *schema.ModifyAttr:
+			switch a := c.To.(type) {
+			case *Partition:
+				m.partitionBy(b, a)
+			case *PartitionDef:
+				b.P("REORGANIZE PARTITION").Ident(a.Name).P("INTO").Wrap(func(b *sqlx.Builder) {
+					m.partitionDef(b, a)
+				})
+			}
+		}
+		if _, err := m.ExecContext(ctx, b.String()); err != nil {
+			return fmt.Errorf("alter table partitions: %w", err)
+		}
+	}
 	return nil
 }
 
+// partitionBy writes the PARTITION BY clause of the given table partitioning.
+func (m *migrate) partitionBy(b *sqlx.Builder, p *Partition) {
+	b.P("PARTITION BY", p.T).Wrap(func(b *sqlx.Builder) {
+		b.MapComma(p.Parts, func(i int, b *sqlx.Builder) {
+			switch part := p.Parts[i]; {
+			case part.C != nil:
+				b.Ident(part.C.Name)
+			case part.X != nil:
+				b.WriteString(part.X.(*schema.RawExpr).X)
+			}
+		})
+	})
+	if len(p.Defs) > 0 {
+		b.WriteByte(' ')
+		b.Wrap(func(b *sqlx.Builder) {
+			b.MapComma(p.Defs, func(i int, b *sqlx.Builder) {
+				m.partitionDef(b, p.Defs[i])
+			})
+		})
+	}
+}
+
+func (m *migrate) partitionDef(b *sqlx.Builder, d *PartitionDef) {
+	b.WriteString("PARTITION ")
+	b.Ident(d.Name)
+	if d.Bound != "" {
+		b.P(d.Bound)
+	}
+}
+
+// isPartitionChange reports if the given change modifies the table partitioning.
+func isPartitionChange(c schema.Change) bool {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.DropAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	switch a.(type) {
+	case *Partition, *PartitionDef:
+		return true
+	}
+	return false
+}
+
 // alterTable modifies the given table by executing on it a list of changes in one SQL statement.
 func (m *migrate) alterTable(ctx context.Context, t *schema.Table, changes []schema.Change) error {
 	b := Build("ALTER TABLE").Table(t)
@@ -221,12 +494,17 @@ func (m *migrate) alterTable(ctx context.Context, t *schema.Table, changes []sch
 
 func (m *migrate) column(b *sqlx.Builder, t *schema.Table, c *schema.Column) {
 	b.Ident(c.Name).P(mustFormat(c.Type.Type))
+	var x schema.GeneratedExpr
+	generated := sqlx.Has(c.Attrs, &x)
+	if generated {
+		b.P("GENERATED ALWAYS AS", sqlx.MayWrap(x.Expr), x.Type)
+	}
 	if !c.Type.Null {
 		b.P("NOT")
 	}
 	b.P("NULL")
-	if x, ok := c.Default.(*schema.RawExpr); ok {
-		v := x.X
+	// Generated columns cannot have a DEFAULT value.
+	if v, ok := sqlx.DefaultValue(c.Default); ok && !generated {
 		// Ensure string/enum default values are quoted.
 		switch c.Type.Type.(type) {
 		case *schema.EnumType, *schema.StringType:
@@ -319,6 +597,18 @@ func (m *migrate) tableAttr(b *sqlx.Builder, attrs ...schema.Attr) {
 			}
 		case *schema.Charset:
 			b.P("CHARACTER SET", a.V)
+		case *Engine:
+			b.P("ENGINE", a.V)
+		case *RowFormat:
+			b.P("ROW_FORMAT", a.V)
+		case *KeyBlockSize:
+			b.P("KEY_BLOCK_SIZE", strconv.FormatInt(a.V, 10))
+		case *StatsPersistent:
+			v := "0"
+			if a.V {
+				v = "1"
+			}
+			b.P("STATS_PERSISTENT", v)
 		default:
 			m.attr(b, a)
 		}
@@ -26,7 +26,9 @@ func TestMigrate_Exec(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 0))
 	mk.ExpectExec(sqltest.Escape("DROP TABLE `public`.`pets` IF EXISTS")).
 		WillReturnResult(sqlmock.NewResult(0, 0))
-	mk.ExpectExec(sqltest.Escape("CREATE TABLE `pets` IF NOT EXISTS (`a` int NOT NULL, `b` bigint NOT NULL, `c` bigint NULL, PRIMARY KEY (`a`, `b`), UNIQUE INDEX `b_c_unique` (`b`, `c`) COMMENT \"comment\")")).
+	mk.ExpectExec(sqltest.Escape("CREATE TABLE IF NOT EXISTS `pets` (`a` int NOT NULL, `b` bigint NOT NULL, `c` bigint NULL, PRIMARY KEY (`a`, `b`), UNIQUE INDEX `b_c_unique` (`b`, `c`) COMMENT \"comment\")")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("CREATE TABLE `orders` (`price` int NOT NULL, `total` int GENERATED ALWAYS AS (`price` * 2) STORED NOT NULL, `tax` int GENERATED ALWAYS AS (`price` / 10) NULL)")).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 	mk.ExpectExec(sqltest.Escape("ALTER TABLE `users` DROP INDEX `id_spouse_id`")).
 		WillReturnResult(sqlmock.NewResult(0, 0))
@@ -63,6 +65,16 @@ func TestMigrate_Exec(t *testing.T) {
 				&schema.IfNotExists{},
 			},
 		},
+		&schema.AddTable{
+			T: &schema.Table{
+				Name: "orders",
+				Columns: []*schema.Column{
+					{Name: "price", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+					{Name: "total", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "`price` * 2", Type: "STORED"}}},
+					{Name: "tax", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}, Null: true}, Default: &schema.RawExpr{X: "0"}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(`price` / 10)"}}},
+				},
+			},
+		},
 	})
 	require.NoError(t, err)
 	err = migrate.Exec(context.Background(), func() []schema.Change {
@@ -127,6 +139,235 @@ func TestMigrate_Exec(t *testing.T) {
 		}
 	}())
 	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `accounts` MODIFY COLUMN `id` bigint NOT NULL AUTO_INCREMENT, MODIFY COLUMN `updated_at` timestamp NOT NULL ON UPDATE CURRENT_TIMESTAMP, AUTO_INCREMENT 1000")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	accounts := &schema.Table{
+		Name: "accounts",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Raw: "bigint", Type: &schema.IntegerType{T: "bigint"}}, Attrs: []schema.Attr{&AutoIncrement{}}},
+			{Name: "updated_at", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}, Attrs: []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}},
+		},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: accounts,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "id", Type: &schema.ColumnType{Raw: "bigint", Type: &schema.IntegerType{T: "bigint"}}},
+					To:     accounts.Columns[0],
+					Change: schema.ChangeAttr,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "updated_at", Type: &schema.ColumnType{Raw: "timestamp", Type: &schema.TimeType{T: "timestamp"}}},
+					To:     accounts.Columns[1],
+					Change: schema.ChangeAttr,
+				},
+				&schema.ModifyAttr{
+					From: &AutoIncrement{V: 1},
+					To:   &AutoIncrement{V: 1000},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape("CREATE TABLE `logs` (`id` bigint NOT NULL) ENGINE InnoDB ROW_FORMAT COMPRESSED KEY_BLOCK_SIZE 8 STATS_PERSISTENT 0")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` ENGINE InnoDB, STATS_PERSISTENT 1")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logs := &schema.Table{
+		Name: "logs",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Raw: "bigint", Type: &schema.IntegerType{T: "bigint"}}},
+		},
+		Attrs: []schema.Attr{&Engine{V: "InnoDB"}, &RowFormat{V: "COMPRESSED"}, &KeyBlockSize{V: 8}, &StatsPersistent{}},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddTable{T: logs},
+		&schema.ModifyTable{
+			T: logs,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{From: &Engine{V: "MyISAM"}, To: &Engine{V: "InnoDB"}},
+				&schema.AddAttr{A: &StatsPersistent{V: true}},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Generated(t *testing.T) {
+	migrate, mk, err := newMigrate("8.0.13")
+	require.NoError(t, err)
+	users := &schema.Table{Name: "users"}
+	column := func(attrs ...schema.Attr) *schema.Column {
+		return &schema.Column{Name: "c", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}, Attrs: attrs}
+	}
+	modify := func(from, to *schema.Column) []schema.Change {
+		return []schema.Change{&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.ModifyColumn{From: from, To: to, Change: schema.ChangeGenerated}}}}
+	}
+	var (
+		regular = column()
+		virtual = column(&schema.GeneratedExpr{Expr: "1"})
+		stored  = column(&schema.GeneratedExpr{Expr: "1", Type: "STORED"})
+	)
+	// The STORED status cannot be changed in place.
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `users` DROP COLUMN `c`, ADD COLUMN `c` int GENERATED ALWAYS AS (1) STORED NOT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, migrate.Exec(context.Background(), modify(virtual, stored)))
+
+	// Expressions can be changed in place.
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `users` MODIFY COLUMN `c` int GENERATED ALWAYS AS (2) NOT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, migrate.Exec(context.Background(), modify(virtual, column(&schema.GeneratedExpr{Expr: "2"}))))
+
+	// Stored columns can be converted to or from regular columns in place.
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `users` MODIFY COLUMN `c` int NOT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, migrate.Exec(context.Background(), modify(stored, regular)))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `users` MODIFY COLUMN `c` int GENERATED ALWAYS AS (1) STORED NOT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, migrate.Exec(context.Background(), modify(regular, stored)))
+
+	// Virtual columns cannot.
+	err = migrate.Exec(context.Background(), modify(regular, virtual))
+	require.EqualError(t, err, `changing column "c" to a virtual column is not supported`)
+	err = migrate.Exec(context.Background(), modify(virtual, regular))
+	require.EqualError(t, err, `changing virtual column "c" to a regular column is not supported`)
+}
+
+func TestMigrate_Grants(t *testing.T) {
+	migrate, mk, err := newMigrate("8.0.13")
+	require.NoError(t, err)
+	s := &schema.Schema{Name: "test"}
+	users := &schema.Table{
+		Name:   "users",
+		Schema: s,
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "name", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+		},
+	}
+	users.Attrs = []schema.Attr{
+		&schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "INSERT"}},
+		&schema.Grant{Grantee: "admin@localhost", Privileges: []string{"SELECT", "UPDATE"}, Columns: users.Columns, GrantOption: true},
+	}
+	mk.ExpectExec(sqltest.Escape("CREATE ROLE IF NOT EXISTS 'app'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("GRANT SELECT ON `test`.* TO 'app'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("REVOKE DROP, GRANT OPTION ON `test`.* FROM 'admin'@'localhost'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("CREATE TABLE `test`.`users` (`id` int NOT NULL, `name` text NOT NULL)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("GRANT SELECT, INSERT ON `test`.`users` TO 'app'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("GRANT SELECT (`id`, `name`), UPDATE (`id`, `name`) ON `test`.`users` TO 'admin'@'localhost' WITH GRANT OPTION")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{
+			S: s,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: &schema.Role{Name: "app"}},
+				&schema.AddAttr{A: &schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}}},
+				&schema.DropAttr{A: &schema.Grant{Grantee: "admin@localhost", Privileges: []string{"DROP"}, GrantOption: true}},
+			},
+		},
+		&schema.AddTable{T: users},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape("GRANT DELETE ON `test`.`users` TO 'app'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("REVOKE INSERT ON `test`.`users` FROM 'app'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{
+					From: &schema.Grant{Grantee: "app", Privileges: []string{"INSERT", "SELECT"}},
+					To:   &schema.Grant{Grantee: "app", Privileges: []string{"DELETE", "SELECT"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Users are created in databases that do not support roles.
+	migrate, mk, err = newMigrate("5.7.36")
+	require.NoError(t, err)
+	mk.ExpectExec(sqltest.Escape("CREATE USER IF NOT EXISTS 'app'@'localhost'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{S: s, Changes: []schema.Change{&schema.AddAttr{A: &schema.Role{Name: "app@localhost"}}}},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Partitions(t *testing.T) {
+	migrate, mk, err := newMigrate("8.0.13")
+	require.NoError(t, err)
+	logs := &schema.Table{
+		Name: "logs",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+		},
+	}
+	p2021 := &PartitionDef{Name: "p2021", Bound: "VALUES LESS THAN (2022)"}
+	logs.Attrs = []schema.Attr{
+		&Engine{V: "InnoDB"},
+		&Partition{
+			T:     "RANGE",
+			Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created_at`)"}}},
+			Defs:  []*PartitionDef{p2021, {Name: "pmax", Bound: "VALUES LESS THAN MAXVALUE"}},
+		},
+	}
+	mk.ExpectExec(sqltest.Escape("CREATE TABLE `logs` (`id` int NOT NULL, `created_at` date NOT NULL) ENGINE InnoDB PARTITION BY RANGE (year(`created_at`)) (PARTITION `p2021` VALUES LESS THAN (2022), PARTITION `pmax` VALUES LESS THAN MAXVALUE)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: logs}})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` ADD COLUMN `name` text NOT NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` ADD PARTITION (PARTITION `p2022` VALUES LESS THAN (2023))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` DROP PARTITION `p2021`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` REORGANIZE PARTITION `p2020` INTO (PARTITION `p2020` VALUES LESS THAN (2021))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: logs,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: &PartitionDef{Name: "p2022", Bound: "VALUES LESS THAN (2023)"}},
+				&schema.AddColumn{C: &schema.Column{Name: "name", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}}},
+				&schema.DropAttr{A: p2021},
+				&schema.ModifyAttr{
+					From: &PartitionDef{Name: "p2020", Bound: "VALUES LESS THAN (2020)"},
+					To:   &PartitionDef{Name: "p2020", Bound: "VALUES LESS THAN (2021)"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` PARTITION BY HASH (`id`)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape("ALTER TABLE `logs` REMOVE PARTITIONING")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T:       logs,
+			Changes: []schema.Change{&schema.ModifyAttr{From: logs.Attrs[1], To: &Partition{T: "HASH", Parts: []*PartitionPart{{C: logs.Columns[0]}}}}},
+		},
+		&schema.ModifyTable{
+			T:       logs,
+			Changes: []schema.Change{&schema.DropAttr{A: logs.Attrs[1]}},
+		},
+	})
+	require.NoError(t, err)
 }
 
 func TestMigrate_DetachCycles(t *testing.T) {
@@ -15,10 +15,28 @@ import (
 	"ariga.io/atlas/sql/sqlspec"
 )
 
-type doc struct {
-	Tables  []*sqlspec.Table  `spec:"table"`
-	Schemas []*sqlspec.Schema `spec:"schema"`
-}
+type (
+	doc struct {
+		Tables  []*sqlspec.Table  `spec:"table"`
+		Schemas []*sqlspec.Schema `spec:"schema"`
+		Roles   []*sqlspec.Role   `spec:"role"`
+	}
+
+	// partitionSpec holds the specification of a partitioned table. The partitioning
+	// key is defined either by a list of columns or by a raw SQL expression.
+	partitionSpec struct {
+		Type    string              `spec:"type"`
+		Columns []*schemaspec.Ref   `spec:"columns"`
+		Expr    string              `spec:"expr"`
+		Parts   []*partitionDefSpec `spec:"part"`
+	}
+
+	// partitionDefSpec holds the specification of a table partition.
+	partitionDefSpec struct {
+		Name  string `spec:",name"`
+		Bound string `spec:"bound"`
+	}
+)
 
 // UnmarshalSpec unmarshals an Atlas DDL document using an unmarshaler into v.
 func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{}) error {
@@ -40,10 +58,47 @@ func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{
 	if err := convertCharset(d.Schemas[0], &conv.Attrs); err != nil {
 		return err
 	}
+	grants, err := specutil.Grants(&d.Schemas[0].Extra, nil)
+	if err != nil {
+		return fmt.Errorf("mysql: failed reading grants of schema %q: %w", conv.Name, err)
+	}
+	conv.Attrs = append(conv.Attrs, specutil.Roles(d.Roles)...)
+	conv.Attrs = append(conv.Attrs, grants...)
 	*s = *conv
 	return nil
 }
 
+// ValidateSpec validates an Atlas DDL document without a database connection. Unlike
+// UnmarshalSpec, it does not stop on the first error, and reports all errors found in
+// the document.
+func ValidateSpec(data []byte, unmarshaler schemaspec.Unmarshaler) error {
+	var d doc
+	// Evaluation diagnostics do not stop the validation, as the document
+	// is still decoded from the resources that were evaluated.
+	evalErr := unmarshaler.UnmarshalSpec(data, &d)
+	if evalErr != nil && !specutil.Partial(evalErr, d.Schemas) {
+		return evalErr
+	}
+	if len(d.Schemas) != 1 {
+		return fmt.Errorf("mysql: expecting document to contain a single schema, got %d", len(d.Schemas))
+	}
+	err := specutil.Validate(d.Schemas, d.Tables, specutil.ValidateOptions{
+		EvalErr:       evalErr,
+		ConvertColumn: convertColumn,
+		Registry:      TypeRegistry,
+		Compatible:    fkTypesCompatible,
+	})
+	if err != nil {
+		return err
+	}
+	// Run the full conversion to catch errors that are not covered by the validation.
+	var s schema.Schema
+	return UnmarshalSpec(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		*v.(*doc) = d
+		return nil
+	}), &s)
+}
+
 // MarshalSpec marshals v into an Atlas DDL document using a schemaspec.Marshaler.
 func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error) {
 	var (
@@ -60,6 +115,7 @@ func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error)
 	return marshaler.MarshalSpec(&doc{
 		Tables:  tables,
 		Schemas: []*sqlspec.Schema{spec},
+		Roles:   specutil.FromRoles(s),
 	})
 }
 
@@ -74,7 +130,94 @@ func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, er
 	if err := convertCharset(spec, &t.Attrs); err != nil {
 		return nil, err
 	}
-	return t, err
+	// MySQL allows setting the initial AUTO_INCREMENT value
+	// on the table definition.
+	if attr, ok := spec.Attr("auto_increment"); ok {
+		v, err := attr.Int()
+		if err != nil {
+			return nil, err
+		}
+		t.Attrs = append(t.Attrs, &AutoIncrement{V: int64(v)})
+	}
+	if err := convertTableOptions(spec, &t.Attrs); err != nil {
+		return nil, err
+	}
+	for _, r := range spec.Extra.Children {
+		if r.Type != "partition" {
+			continue
+		}
+		p, err := convertPartition(r, t)
+		if err != nil {
+			return nil, fmt.Errorf("mysql: failed reading partition of table %q: %w", spec.Name, err)
+		}
+		t.Attrs = append(t.Attrs, p)
+	}
+	grants, err := specutil.Grants(&spec.Extra, t)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: failed reading grants of table %q: %w", spec.Name, err)
+	}
+	t.Attrs = append(t.Attrs, grants...)
+	return t, nil
+}
+
+// convertPartition converts a "partition" resource of a table into a Partition attribute.
+func convertPartition(r *schemaspec.Resource, t *schema.Table) (*Partition, error) {
+	var ps partitionSpec
+	if err := r.As(&ps); err != nil {
+		return nil, err
+	}
+	p := &Partition{T: strings.ToUpper(ps.Type)}
+	switch {
+	case len(ps.Columns) > 0 && ps.Expr != "":
+		return nil, errors.New("partition key must be defined by either columns or expr")
+	case ps.Expr != "":
+		p.Parts = append(p.Parts, &PartitionPart{X: &schema.RawExpr{X: ps.Expr}})
+	default:
+		for _, ref := range ps.Columns {
+			c, err := specutil.ColumnByRef(t, ref)
+			if err != nil {
+				return nil, err
+			}
+			p.Parts = append(p.Parts, &PartitionPart{C: c})
+		}
+	}
+	for _, d := range ps.Parts {
+		p.Defs = append(p.Defs, &PartitionDef{Name: d.Name, Bound: d.Bound})
+	}
+	return p, nil
+}
+
+// convertTableOptions converts the table options (e.g. engine) of a sqlspec.Table to attributes.
+func convertTableOptions(spec *sqlspec.Table, attrs *[]schema.Attr) error {
+	if attr, ok := spec.Attr("engine"); ok {
+		s, err := attr.String()
+		if err != nil {
+			return err
+		}
+		*attrs = append(*attrs, &Engine{V: s})
+	}
+	if attr, ok := spec.Attr("row_format"); ok {
+		s, err := attr.String()
+		if err != nil {
+			return err
+		}
+		*attrs = append(*attrs, &RowFormat{V: strings.ToUpper(s)})
+	}
+	if attr, ok := spec.Attr("key_block_size"); ok {
+		v, err := attr.Int()
+		if err != nil {
+			return err
+		}
+		*attrs = append(*attrs, &KeyBlockSize{V: int64(v)})
+	}
+	if attr, ok := spec.Attr("stats_persistent"); ok {
+		b, err := attr.Bool()
+		if err != nil {
+			return err
+		}
+		*attrs = append(*attrs, &StatsPersistent{V: b})
+	}
+	return nil
 }
 
 // convertPrimaryKey converts a sqlspec.PrimaryKey to a schema.Index.
@@ -88,14 +231,33 @@ func convertIndex(spec *sqlspec.Index, parent *schema.Table) (*schema.Index, err
 }
 
 // convertColumn converts a sqlspec.Column into a schema.Column.
-func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
+func convertColumn(spec *sqlspec.Column, t *schema.Table) (*schema.Column, error) {
 	c, err := specutil.Column(spec, convertColumnType)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := c.Type.Type.(*schema.UnsupportedType); ok {
+		return nil, unknownTypeError(spec, t)
+	}
 	if err := convertCharset(spec, &c.Attrs); err != nil {
 		return nil, err
 	}
+	if attr, ok := spec.Attr("auto_increment"); ok {
+		b, err := attr.Bool()
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			c.Attrs = append(c.Attrs, &AutoIncrement{})
+		}
+	}
+	if attr, ok := spec.Attr("on_update"); ok {
+		x, err := attr.String()
+		if err != nil {
+			return nil, err
+		}
+		c.Attrs = append(c.Attrs, &OnUpdate{A: x})
+	}
 	return c, err
 }
 
@@ -124,6 +286,20 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 	return parseRawType(spec.Type)
 }
 
+// unknownTypeError returns an error for a column with a type that is not
+// supported by the driver, with a suggestion for a similar type if one exists.
+func unknownTypeError(spec *sqlspec.Column, t *schema.Table) error {
+	name := spec.Type
+	if i := strings.IndexAny(name, "( "); i != -1 {
+		name = name[:i]
+	}
+	msg := fmt.Sprintf("mysql: unknown type %q for column %s.%s", spec.Type, t.Name, spec.Name)
+	if s, ok := TypeRegistry.Suggest(name); ok {
+		msg += fmt.Sprintf(", did you mean %q?", s)
+	}
+	return errors.New(msg)
+}
+
 func convertInteger(spec *sqlspec.Column) (schema.Type, error) {
 	typ := &schema.IntegerType{
 		Unsigned: strings.HasPrefix(spec.Type, "u"),
@@ -266,6 +442,7 @@ func schemaSpec(s *schema.Schema) (*sqlspec.Schema, []*sqlspec.Table, error) {
 	if c, ok := hasCollate(s.Attrs, nil); ok {
 		sc.Extra.Attrs = append(sc.Extra.Attrs, specutil.StrAttr("collation", c))
 	}
+	sc.Extra.Children = append(sc.Extra.Children, specutil.FromGrants(s.Attrs, nil)...)
 	return sc, t, nil
 }
 
@@ -281,9 +458,65 @@ func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
 	if c, ok := hasCollate(t.Attrs, t.Schema.Attrs); ok {
 		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.StrAttr("collation", c))
 	}
+	// Skip the default AUTO_INCREMENT value.
+	if a := (AutoIncrement{}); sqlx.Has(t.Attrs, &a) && a.V > 1 {
+		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.LitAttr("auto_increment", strconv.FormatInt(a.V, 10)))
+	}
+	if e := (Engine{}); sqlx.Has(t.Attrs, &e) {
+		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.StrAttr("engine", e.V))
+	}
+	if r := (RowFormat{}); sqlx.Has(t.Attrs, &r) {
+		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.StrAttr("row_format", r.V))
+	}
+	if k := (KeyBlockSize{}); sqlx.Has(t.Attrs, &k) {
+		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.LitAttr("key_block_size", strconv.FormatInt(k.V, 10)))
+	}
+	if p := (StatsPersistent{}); sqlx.Has(t.Attrs, &p) {
+		ts.Extra.Attrs = append(ts.Extra.Attrs, specutil.LitAttr("stats_persistent", strconv.FormatBool(p.V)))
+	}
+	if p := partition(t.Attrs); p != nil {
+		r, err := partitionResource(p, t)
+		if err != nil {
+			return nil, err
+		}
+		ts.Extra.Children = append(ts.Extra.Children, r)
+	}
+	ts.Extra.Children = append(ts.Extra.Children, specutil.FromGrants(t.Attrs, t)...)
 	return ts, nil
 }
 
+// partitionResource converts a Partition attribute of a table into a "partition" resource.
+func partitionResource(p *Partition, t *schema.Table) (*schemaspec.Resource, error) {
+	r := &schemaspec.Resource{Type: "partition"}
+	r.SetAttr(specutil.StrAttr("type", p.T))
+	columns := make([]schemaspec.Value, 0, len(p.Parts))
+	exprs := make([]string, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		switch {
+		case part.C != nil:
+			columns = append(columns, specutil.ColumnRef(part.C, t))
+			exprs = append(exprs, "`"+part.C.Name+"`")
+		case part.X != nil:
+			exprs = append(exprs, part.X.(*schema.RawExpr).X)
+		}
+	}
+	// Keys that contain expressions are written as a raw expression.
+	if len(columns) == len(p.Parts) {
+		r.SetAttr(&schemaspec.Attr{K: "columns", V: &schemaspec.ListValue{V: columns}})
+	} else {
+		r.SetAttr(specutil.StrAttr("expr", strings.Join(exprs, ", ")))
+	}
+	for _, d := range p.Defs {
+		c := &schemaspec.Resource{}
+		if err := c.Scan(&partitionDefSpec{Name: d.Name, Bound: d.Bound}); err != nil {
+			return nil, err
+		}
+		c.Type = "part"
+		r.Children = append(r.Children, c)
+	}
+	return r, nil
+}
+
 // columnSpec converts from a concrete MySQL schema.Column into a sqlspec.Column.
 func columnSpec(c *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 	ct, err := columnTypeSpec(c.Type.Type)
@@ -296,10 +529,17 @@ func columnSpec(c *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 	if c, ok := hasCollate(c.Attrs, t.Attrs); ok {
 		ct.Extra.Attrs = append(ct.Extra.Attrs, specutil.StrAttr("collation", c))
 	}
+	if sqlx.Has(c.Attrs, &AutoIncrement{}) {
+		ct.Extra.Attrs = append(ct.Extra.Attrs, specutil.LitAttr("auto_increment", "true"))
+	}
+	if u := (OnUpdate{}); sqlx.Has(c.Attrs, &u) {
+		ct.Extra.Attrs = append(ct.Extra.Attrs, specutil.StrAttr("on_update", u.A))
+	}
 	return &sqlspec.Column{
 		Name: c.Name,
 		Type: ct.Type,
 		Null: c.Type.Null,
+		As:   specutil.FromGeneratedExpr(c, "VIRTUAL"),
 		DefaultExtension: schemaspec.DefaultExtension{
 			Extra: schemaspec.Resource{Attrs: ct.DefaultExtension.Extra.Attrs},
 		},
@@ -486,3 +726,17 @@ func unsignedTypeAttr() *schemaspec.TypeAttr {
 		Kind: reflect.Bool,
 	}
 }
+
+// fkTypesCompatible reports if a foreign-key column of type t1 can reference a column
+// of type t2. In MySQL, integer columns must have the same size and sign, and string
+// and binary columns must be of the same kind.
+func fkTypesCompatible(t1, t2 schema.Type) bool {
+	if reflect.TypeOf(t1) != reflect.TypeOf(t2) {
+		return false
+	}
+	if i1, ok := t1.(*schema.IntegerType); ok {
+		i2 := t2.(*schema.IntegerType)
+		return i1.T == i2.T && i1.Unsigned == i2.Unsigned
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"testing"
@@ -283,6 +284,370 @@ schema "test" {
 	require.Equal(t, utf8mb4, b.Attrs)
 }
 
+func TestMarshalSpec_GeneratedColumn(t *testing.T) {
+	s := &schema.Schema{
+		Name: "test",
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{
+						Name: "a",
+						Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}},
+					},
+					{
+						Name: "b",
+						Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}},
+						Attrs: []schema.Attr{
+							&schema.GeneratedExpr{Expr: "a * 2", Type: "STORED"},
+						},
+					},
+					{
+						Name: "c",
+						Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}},
+						Attrs: []schema.Attr{
+							&schema.GeneratedExpr{Expr: "a * 3"},
+						},
+					},
+				},
+			},
+		},
+	}
+	s.Tables[0].Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "a" {
+    null = false
+    type = "int"
+  }
+  column "b" {
+    null = false
+    type = "int"
+    as {
+      expr = "a * 2"
+      type = "STORED"
+    }
+  }
+  column "c" {
+    null = false
+    type = "int"
+    as {
+      expr = "a * 3"
+      type = "VIRTUAL"
+    }
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Empty(t, users.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&schema.GeneratedExpr{Expr: "a * 2", Type: "STORED"}}, users.Columns[1].Attrs)
+	require.Equal(t, []schema.Attr{&schema.GeneratedExpr{Expr: "a * 3", Type: "VIRTUAL"}}, users.Columns[2].Attrs)
+}
+
+func TestMarshalSpec_AutoIncrement(t *testing.T) {
+	s := &schema.Schema{
+		Name: "test",
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Attrs: []schema.Attr{
+					&AutoIncrement{V: 1000},
+				},
+				Columns: []*schema.Column{
+					{
+						Name: "id",
+						Type: &schema.ColumnType{Type: &schema.IntegerType{T: "bigint"}},
+						Attrs: []schema.Attr{
+							&AutoIncrement{A: "auto_increment"},
+						},
+					},
+					{
+						Name: "updated_at",
+						Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}},
+						Attrs: []schema.Attr{
+							&OnUpdate{A: "CURRENT_TIMESTAMP"},
+						},
+					},
+				},
+			},
+		},
+	}
+	s.Tables[0].Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema         = schema.test
+  auto_increment = 1000
+  column "id" {
+    null           = false
+    type           = "int64"
+    auto_increment = true
+  }
+  column "updated_at" {
+    null      = false
+    type      = "timestamp"
+    on_update = "CURRENT_TIMESTAMP"
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&AutoIncrement{V: 1000}}, users.Attrs)
+	require.Equal(t, []schema.Attr{&AutoIncrement{}}, users.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&OnUpdate{A: "CURRENT_TIMESTAMP"}}, users.Columns[1].Attrs)
+}
+
+func TestMarshalSpec_TableOptions(t *testing.T) {
+	s := &schema.Schema{
+		Name: "test",
+		Tables: []*schema.Table{
+			{
+				Name: "logs",
+				Attrs: []schema.Attr{
+					&Engine{V: "MyISAM"},
+					&RowFormat{V: "COMPRESSED"},
+					&KeyBlockSize{V: 8},
+					&StatsPersistent{V: true},
+				},
+			},
+		},
+	}
+	s.Tables[0].Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "logs" {
+  schema           = schema.test
+  engine           = "MyISAM"
+  row_format       = "COMPRESSED"
+  key_block_size   = 8
+  stats_persistent = true
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	logs, ok := s2.Table("logs")
+	require.True(t, ok)
+	require.Equal(t, s.Tables[0].Attrs, logs.Attrs)
+}
+
+func TestMarshalSpec_Partition(t *testing.T) {
+	logs := &schema.Table{
+		Name: "logs",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+		},
+	}
+	logs.Attrs = []schema.Attr{
+		&Partition{
+			T:     "RANGE COLUMNS",
+			Parts: []*PartitionPart{{C: logs.Columns[1]}},
+			Defs: []*PartitionDef{
+				{Name: "p2021", Bound: "VALUES LESS THAN ('2022-01-01')"},
+				{Name: "pmax", Bound: "VALUES LESS THAN (MAXVALUE)"},
+			},
+		},
+	}
+	s := &schema.Schema{Name: "test", Tables: []*schema.Table{logs}}
+	logs.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "logs" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = "int"
+  }
+  column "created_at" {
+    null = false
+    type = "date"
+  }
+  partition {
+    type    = "RANGE COLUMNS"
+    columns = [table.logs.column.created_at, ]
+    part "p2021" {
+      bound = "VALUES LESS THAN ('2022-01-01')"
+    }
+    part "pmax" {
+      bound = "VALUES LESS THAN (MAXVALUE)"
+    }
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	logs2, ok := s2.Table("logs")
+	require.True(t, ok)
+	p := partition(logs2.Attrs)
+	require.NotNil(t, p)
+	require.Equal(t, "RANGE COLUMNS", p.T)
+	require.Len(t, p.Parts, 1)
+	require.Equal(t, logs2.Columns[1], p.Parts[0].C)
+	require.Equal(t, logs.Attrs[0].(*Partition).Defs, p.Defs)
+
+	// Partitioning keys with expressions.
+	logs.Attrs[0] = &Partition{T: "HASH", Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created_at`)"}}}}
+	buf, err = MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "expr = \"year(`created_at`)\"")
+	s2 = schema.Schema{}
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	p = partition(s2.Tables[0].Attrs)
+	require.Equal(t, logs.Attrs[0], p)
+}
+
+func TestMarshalSpec_Grants(t *testing.T) {
+	users := &schema.Table{
+		Name: "users",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+		},
+	}
+	users.Attrs = []schema.Attr{
+		&schema.Grant{Grantee: "admin@localhost", Privileges: []string{"SELECT", "UPDATE"}, Columns: users.Columns, GrantOption: true},
+	}
+	s := &schema.Schema{
+		Name:   "test",
+		Realm:  &schema.Realm{Attrs: []schema.Attr{&schema.Role{Name: "root@localhost"}, &schema.Role{Name: "admin@localhost"}}},
+		Tables: []*schema.Table{users},
+		Attrs:  []schema.Attr{&schema.Role{Name: "app"}, &schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}}},
+	}
+	users.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = "int"
+  }
+  grant "admin@localhost" {
+    privileges   = ["SELECT", "UPDATE", ]
+    columns      = [table.users.column.id, ]
+    grant_option = true
+  }
+}
+schema "test" {
+  grant "app" {
+    privileges = ["SELECT", ]
+  }
+}
+role "app" {
+}
+role "admin@localhost" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	require.Equal(t, []schema.Attr{
+		&schema.Role{Name: "app"},
+		&schema.Role{Name: "admin@localhost"},
+		&schema.Grant{Grantee: "app", Privileges: []string{"SELECT"}},
+	}, s2.Attrs)
+	require.Equal(t, []schema.Attr{
+		&schema.Grant{Grantee: "admin@localhost", Privileges: []string{"SELECT", "UPDATE"}, Columns: s2.Tables[0].Columns, GrantOption: true},
+	}, s2.Tables[0].Attrs)
+}
+
+func TestUnmarshalSpec_UnknownType(t *testing.T) {
+	var s schema.Schema
+	err := UnmarshalSpec([]byte(`
+schema "s" {}
+table "users" {
+  schema = schema.s
+  column "name" {
+    type = "varchr(255)"
+  }
+}
+`), hclState, &s)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `5:3: mysql: unknown type "varchr(255)" for column users.name, did you mean "varchar"?`)
+	var pe *schemaspec.PosError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, 5, pe.Range.Start.Line)
+}
+
+func TestValidateSpec(t *testing.T) {
+	err := ValidateSpec([]byte(`
+schema "s" {}
+table "users" {
+  schema = schema.s
+  column "id" {
+    type = "int"
+    null = true
+  }
+  column "id" {
+    type = "int"
+  }
+  column "name" {
+    type = "varchr(255)"
+  }
+  primary_key {
+    columns = [table.users.column.id]
+  }
+  index "idx" {
+    columns = [table.users.column.name]
+  }
+  index "idx" {
+    columns = [table.users.column.id]
+  }
+}
+table "posts" {
+  schema = schema.s
+  column "author" {
+    type = "bigint"
+  }
+  foreign_key "fk" {
+    columns     = [table.posts.column.author]
+    ref_columns = [table.users.column.id]
+  }
+}
+`), hclState)
+	require.EqualError(t, err, `9:3: duplicate column "id" in table "users"
+13:12: mysql: unknown type "varchr(255)" for column users.name, did you mean "varchar"?
+16:15: primary key of table "users" contains the nullable column "id"
+21:3: duplicate index "idx" in table "users"
+32:19: foreign key "fk": column "author" of type "bigint" is incompatible with the referenced column "id" of type "int"`)
+
+	err = ValidateSpec([]byte(`
+schema "s" {}
+table "users" {
+  schema = schema.s
+  column "id" {
+    type = "int"
+  }
+  primary_key {
+    columns = [table.users.column.id]
+  }
+}
+`), hclState)
+	require.NoError(t, err)
+}
+
 func TestUnmarshalSpecColumnTypes(t *testing.T) {
 	for _, tt := range []struct {
 		spec     *sqlspec.Column
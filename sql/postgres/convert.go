@@ -37,6 +37,24 @@ func FormatType(t schema.Type) (string, error) {
 			return "", errors.New("postgres: missing enum type name")
 		}
 		f = t.T
+		if t.Schema != nil && t.Schema.Name != "" {
+			f = fmt.Sprintf("%q.%q", t.Schema.Name, t.T)
+		}
+	case *DomainType:
+		if t.T == "" {
+			return "", errors.New("postgres: missing domain type name")
+		}
+		f = t.T
+	case *CompositeType:
+		if t.T == "" {
+			return "", errors.New("postgres: missing composite type name")
+		}
+		f = t.T
+	case *RangeType:
+		if t.T == "" {
+			return "", errors.New("postgres: missing range type name")
+		}
+		f = t.T
 	case *schema.IntegerType:
 		switch f = strings.ToLower(t.T); f {
 		case tSmallInt, tInteger, tBigInt:
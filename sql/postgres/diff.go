@@ -7,6 +7,7 @@ package postgres
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -18,9 +19,117 @@ import (
 type diff struct{ conn }
 
 // SchemaAttrDiff returns a changeset for migrating schema attributes from one state to the other.
-func (d *diff) SchemaAttrDiff(_, _ *schema.Schema) []schema.Change {
-	// No special schema attribute diffing for PostgreSQL.
-	return nil
+func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	// Drop or update extensions. An extension version is
+	// changed only if it is set explicitly in the desired schema.
+	// Extensions are dropped only if the desired schema manages
+	// extensions, i.e. it declares at least one of them.
+	managed := len(extensions(to.Attrs)) > 0
+	for _, e1 := range extensions(from.Attrs) {
+		switch e2, ok := extensionByName(to.Attrs, e1.Name); {
+		case !ok:
+			if managed {
+				changes = append(changes, &schema.DropAttr{
+					A: e1,
+				})
+			}
+		case e2.Version != "" && e1.Version != e2.Version:
+			changes = append(changes, &schema.ModifyAttr{
+				From: e1,
+				To:   e2,
+			})
+		}
+	}
+	// Add extensions.
+	for _, e2 := range extensions(to.Attrs) {
+		if _, ok := extensionByName(from.Attrs, e2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{
+				A: e2,
+			})
+		}
+	}
+	// Drop enums that are defined in the schema, but are not used by the desired
+	// schema. The migration drops them only if they are no longer in use.
+	used := schemaEnums(to)
+	for _, a := range from.Attrs {
+		if e, ok := a.(*Enum); ok && !used[e.T] {
+			changes = append(changes, &schema.DropAttr{
+				A: e,
+			})
+		}
+	}
+	// Roles must be created before privileges are granted to them.
+	changes = append(changes, sqlx.RoleChanges(from, to)...)
+	if sqlx.ManagesGrants(to) {
+		changes = append(changes, sqlx.GrantChanges(skipPublicGrants(from, to), to.Attrs)...)
+	}
+	return changes
+}
+
+// skipPublicGrants returns the attributes of the current schema without the privileges
+// that are granted to PUBLIC on the "public" schema by default (USAGE, and CREATE before
+// v15), unless the desired schema declares grants to PUBLIC.
+func skipPublicGrants(from, to *schema.Schema) []schema.Attr {
+	if from.Name != "public" {
+		return from.Attrs
+	}
+	for _, a := range to.Attrs {
+		if g, ok := a.(*schema.Grant); ok && strings.EqualFold(g.Grantee, "PUBLIC") {
+			return from.Attrs
+		}
+	}
+	attrs := make([]schema.Attr, 0, len(from.Attrs))
+	for _, a := range from.Attrs {
+		if g, ok := a.(*schema.Grant); ok && g.Grantee == "PUBLIC" && len(g.Columns) == 0 && builtinPublic(g.Privileges) {
+			continue
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+// builtinPublic reports if the given privileges are
+// granted to PUBLIC on the "public" schema by default.
+func builtinPublic(privileges []string) bool {
+	for _, p := range privileges {
+		if p = strings.ToUpper(p); p != "USAGE" && p != "CREATE" {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaEnums returns the names of the enums that are defined in the
+// schema, or used by the columns of its tables and belong to it.
+func schemaEnums(s *schema.Schema) map[string]bool {
+	names := make(map[string]bool)
+	for _, a := range s.Attrs {
+		if e, ok := a.(*Enum); ok {
+			names[e.T] = true
+		}
+	}
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			e, ok := c.Type.Type.(*schema.EnumType)
+			if !ok {
+				continue
+			}
+			if ns := enumSchema(t, e); ns == "" || ns == s.Name {
+				names[e.T] = true
+			}
+		}
+	}
+	return names
+}
+
+func extensionByName(attrs []schema.Attr, name string) (*Extension, bool) {
+	for _, e := range extensions(attrs) {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return nil, false
 }
 
 // TableAttrDiff returns a changeset for migrating table attributes from one state to the other.
@@ -48,9 +157,262 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) []schema.Change {
 			})
 		}
 	}
+	changes = append(changes, excludeChanges(from.Attrs, to.Attrs)...)
+	changes = append(changes, partitionChanges(from.Attrs, to.Attrs)...)
+	if sqlx.ManagesTableGrants(to) {
+		changes = append(changes, sqlx.GrantChanges(from.Attrs, to.Attrs)...)
+	}
+	return append(changes, policyChanges(from.Attrs, to.Attrs)...)
+}
+
+// policyChanges returns the changes for migrating the row-level security
+// settings and the policies of a table. A RowSecurity attribute that has
+// none of its options set is treated as if it is missing.
+func policyChanges(from, to []schema.Attr) []schema.Change {
+	var changes []schema.Change
+	r1, r2 := rowSecurity(from), rowSecurity(to)
+	if r1 != nil && !r1.Enabled && !r1.Force {
+		r1 = nil
+	}
+	if r2 != nil && !r2.Enabled && !r2.Force {
+		r2 = nil
+	}
+	switch {
+	case r1 == nil && r2 != nil:
+		changes = append(changes, &schema.AddAttr{A: r2})
+	case r1 != nil && r2 == nil:
+		changes = append(changes, &schema.DropAttr{A: r1})
+	case r1 != nil && (r1.Enabled != r2.Enabled || r1.Force != r2.Force):
+		changes = append(changes, &schema.ModifyAttr{From: r1, To: r2})
+	}
+	for _, p1 := range policies(from) {
+		switch p2, ok := policyByName(to, p1.Name); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{
+				A: p1,
+			})
+		case policyChanged(p1, p2):
+			changes = append(changes, &schema.ModifyAttr{
+				From: p1,
+				To:   p2,
+			})
+		}
+	}
+	for _, p2 := range policies(to) {
+		if _, ok := policyByName(from, p2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{
+				A: p2,
+			})
+		}
+	}
+	return changes
+}
+
+// policyChanged reports if the definition of a policy was changed.
+// Unset options are compared with their default values.
+func policyChanged(p1, p2 *Policy) bool {
+	return !strings.EqualFold(policyAs(p1), policyAs(p2)) ||
+		!strings.EqualFold(policyFor(p1), policyFor(p2)) ||
+		!sqlx.ValuesEqual(policyRoles(p1), policyRoles(p2)) ||
+		sqlx.UnwrapExpr(p1.Using) != sqlx.UnwrapExpr(p2.Using) ||
+		sqlx.UnwrapExpr(p1.Check) != sqlx.UnwrapExpr(p2.Check)
+}
+
+func policyByName(attrs []schema.Attr, name string) (*Policy, bool) {
+	for _, p := range policies(attrs) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func policyAs(p *Policy) string {
+	if p.As == "" {
+		return "PERMISSIVE"
+	}
+	return p.As
+}
+
+func policyFor(p *Policy) string {
+	if p.For == "" {
+		return "ALL"
+	}
+	return p.For
+}
+
+// policyRoles returns the sorted roles of a policy. The PUBLIC
+// pseudo-role is case-insensitive, and it is used by default.
+func policyRoles(p *Policy) []string {
+	if len(p.To) == 0 {
+		return []string{"PUBLIC"}
+	}
+	roles := make([]string, len(p.To))
+	for i, r := range p.To {
+		if strings.EqualFold(r, "PUBLIC") {
+			r = "PUBLIC"
+		}
+		roles[i] = r
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// excludeChanges returns the changes for migrating the exclusion constraints of a table.
+func excludeChanges(from, to []schema.Attr) []schema.Change {
+	var changes []schema.Change
+	for _, e1 := range excludes(from) {
+		switch e2, ok := excludeByName(to, e1.Name); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{
+				A: e1,
+			})
+		case excludeChanged(e1, e2):
+			changes = append(changes, &schema.ModifyAttr{
+				From: e1,
+				To:   e2,
+			})
+		}
+	}
+	for _, e2 := range excludes(to) {
+		if _, ok := excludeByName(from, e2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{
+				A: e2,
+			})
+		}
+	}
+	return changes
+}
+
+// excludeChanged reports if the definition of an exclusion constraint was changed.
+func excludeChanged(e1, e2 *Exclude) bool {
+	if !strings.EqualFold(excludeType(e1), excludeType(e2)) || len(e1.Elems) != len(e2.Elems) ||
+		sqlx.UnwrapExpr(e1.Where) != sqlx.UnwrapExpr(e2.Where) || deferrableChanged(e1.Attrs, e2.Attrs) {
+		return true
+	}
+	for i := range e1.Elems {
+		if excludeElemString(e1.Elems[i]) != excludeElemString(e2.Elems[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeElemString returns a normalized representation of an exclusion
+// constraint element that is used for comparing elements.
+func excludeElemString(e *ExcludeElem) string {
+	var k string
+	switch x, ok := e.X.(*schema.RawExpr); {
+	case e.C != nil:
+		k = e.C.Name
+	case ok:
+		k = strings.ToLower(strings.NewReplacer(`"`, "", " ", "").Replace(sqlx.UnwrapExpr(x.X)))
+	}
+	return k + " " + e.Op
+}
+
+func excludeType(e *Exclude) string {
+	if e.T == "" {
+		return "BTREE"
+	}
+	return e.T
+}
+
+func excludeByName(attrs []schema.Attr, name string) (*Exclude, bool) {
+	for _, e := range excludes(attrs) {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// ForeignKeyAttrChanged reports if the foreign-key attributes were changed.
+// That is, the DEFERRABLE options or the MATCH type of the foreign key.
+func (*diff) ForeignKeyAttrChanged(from, to []schema.Attr) bool {
+	var m1, m2 MatchType
+	sqlx.Has(from, &m1)
+	sqlx.Has(to, &m2)
+	return deferrableChanged(from, to) || !strings.EqualFold(matchType(m1), matchType(m2))
+}
+
+func matchType(m MatchType) string {
+	if m.T == "" {
+		return "SIMPLE"
+	}
+	return m.T
+}
+
+// deferrableChanged reports if the DEFERRABLE options of a constraint were changed.
+func deferrableChanged(from, to []schema.Attr) bool {
+	var d1, d2 Deferrable
+	return sqlx.Has(from, &d1) != sqlx.Has(to, &d2) || d1.InitiallyDeferred != d2.InitiallyDeferred
+}
+
+// partitionChanges returns the changes for migrating the partitioning of a table.
+// Changes to the partition key are reported as a modification of the Partition
+// attribute, and changes to the partitions as changes of PartitionDef attributes.
+func partitionChanges(from, to []schema.Attr) []schema.Change {
+	p1, p2 := partition(from), partition(to)
+	switch {
+	case p1 == nil && p2 == nil:
+		return nil
+	case p1 == nil:
+		return []schema.Change{&schema.AddAttr{A: p2}}
+	case p2 == nil:
+		return []schema.Change{&schema.DropAttr{A: p1}}
+	case !strings.EqualFold(p1.T, p2.T) || partitionKeyString(p1.Parts) != partitionKeyString(p2.Parts):
+		return []schema.Change{&schema.ModifyAttr{From: p1, To: p2}}
+	}
+	var changes []schema.Change
+	for _, d1 := range p1.Defs {
+		switch d2, ok := partitionDef(p2, d1.Name); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{
+				A: d1,
+			})
+		case d1.Bound != d2.Bound:
+			changes = append(changes, &schema.ModifyAttr{
+				From: d1,
+				To:   d2,
+			})
+		}
+	}
+	for _, d2 := range p2.Defs {
+		if _, ok := partitionDef(p1, d2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{
+				A: d2,
+			})
+		}
+	}
 	return changes
 }
 
+// partitionKeyString returns a normalized representation of
+// the partition key that is used for comparing partition keys.
+func partitionKeyString(parts []*PartitionPart) string {
+	keys := make([]string, len(parts))
+	for i, p := range parts {
+		switch x, ok := p.X.(*schema.RawExpr); {
+		case p.C != nil:
+			keys[i] = p.C.Name
+		case ok:
+			keys[i] = x.X
+		}
+		keys[i] = strings.ToLower(strings.NewReplacer(`"`, "", " ", "").Replace(keys[i]))
+	}
+	return strings.Join(keys, ",")
+}
+
+func partitionDef(p *Partition, name string) (*PartitionDef, bool) {
+	for _, d := range p.Defs {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
 // ColumnChange returns the schema changes (if any) for migrating one column to the other.
 func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error) {
 	change := sqlx.CommentChange(from.Attrs, to.Attrs)
@@ -71,9 +433,27 @@ func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error)
 	if changed {
 		change |= schema.ChangeDefault
 	}
+	change |= sqlx.GeneratedChange(from.Attrs, to.Attrs, "STORED")
+	if identityChanged(from.Attrs, to.Attrs) {
+		change |= schema.ChangeAttr
+	}
 	return change, nil
 }
 
+// identityChanged reports if one of the identity attributes was changed.
+func identityChanged(from, to []schema.Attr) bool {
+	i1, i2 := &Identity{}, &Identity{}
+	has1, has2 := sqlx.Has(from, i1), sqlx.Has(to, i2)
+	if has1 != has2 {
+		return true
+	}
+	if !has1 {
+		return false
+	}
+	i1, i2 = identity(i1), identity(i2)
+	return i1.Generation != i2.Generation || i1.Start != i2.Start || i1.Increment != i2.Increment
+}
+
 // defaultChanged reports if the a default value of a column
 // type was changed.
 func (d *diff) defaultChanged(from, to *schema.Column) (bool, error) {
@@ -111,7 +491,34 @@ func (*diff) IndexAttrChanged(from, to []schema.Attr) bool {
 	if sqlx.Has(from, &p1) != sqlx.Has(to, &p2) || p1.P != p2.P {
 		return true
 	}
-	return false
+	// A UNIQUE constraint and a unique index are not interchangeable.
+	// For example, only constraints can be deferred.
+	var c1, c2 ConType
+	sqlx.Has(from, &c1)
+	sqlx.Has(to, &c2)
+	if (c1.T == "u") != (c2.T == "u") {
+		return true
+	}
+	if nullsDistinct(from) != nullsDistinct(to) {
+		return true
+	}
+	if includeColumns(from) != includeColumns(to) {
+		return true
+	}
+	return deferrableChanged(from, to)
+}
+
+// includeColumns returns the names of the INCLUDE columns of an index, joined by comma.
+func includeColumns(attrs []schema.Attr) string {
+	inc, ok := indexInclude(attrs)
+	if !ok {
+		return ""
+	}
+	names := make([]string, len(inc.Columns))
+	for i, c := range inc.Columns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ",")
 }
 
 // IndexPartAttrChanged reports if the index-part attributes were changed.
@@ -143,6 +550,11 @@ func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 	if fromT == nil || toT == nil {
 		return false, fmt.Errorf("postgres: missing type infromation for column %q", from.Name)
 	}
+	// User-defined types can be referenced only by their names (e.g. in HCL).
+	// In this case, their definitions are not compared.
+	if n1, n2, ok := userTypeNames(fromT, toT); ok {
+		return n1 != n2, nil
+	}
 	if reflect.TypeOf(fromT) != reflect.TypeOf(toT) {
 		return true, nil
 	}
@@ -157,7 +569,16 @@ func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 		changed = fromT.T != toT.T || !sqlx.ValuesEqual(fromT.Values, toT.Values)
 	case *schema.EnumType:
 		toT := toT.(*schema.EnumType)
-		changed = fromT.T != toT.T || !sqlx.ValuesEqual(fromT.Values, toT.Values)
+		// Enums without a schema are resolved to the table schema.
+		changed = fromT.T != toT.T || !sqlx.ValuesEqual(fromT.Values, toT.Values) ||
+			fromT.Schema != nil && toT.Schema != nil && fromT.Schema.Name != toT.Schema.Name
+	case *DomainType:
+		changed = !domainEqual(fromT, toT.(*DomainType))
+	case *CompositeType:
+		changed = !compositeEqual(fromT, toT.(*CompositeType))
+	case *RangeType:
+		toT := toT.(*RangeType)
+		changed = fromT.T != toT.T || !typesMatch(fromT.Subtype, toT.Subtype)
 	case *CurrencyType:
 		toT := toT.(*CurrencyType)
 		changed = fromT.T != toT.T
@@ -182,6 +603,79 @@ func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 	return changed, nil
 }
 
+// userTypeNames returns the names of the given types in case one of them
+// is a UserDefinedType and the other is a named type created by the user.
+func userTypeNames(t1, t2 schema.Type) (string, string, bool) {
+	u1, ok1 := t1.(*UserDefinedType)
+	u2, ok2 := t2.(*UserDefinedType)
+	switch {
+	case ok1 && !ok2:
+		if n, ok := userTypeName(t2); ok {
+			return u1.T, n, true
+		}
+	case !ok1 && ok2:
+		if n, ok := userTypeName(t1); ok {
+			return n, u2.T, true
+		}
+	}
+	return "", "", false
+}
+
+// userTypeName returns the name of domain, composite or range types.
+func userTypeName(t schema.Type) (string, bool) {
+	switch t := t.(type) {
+	case *DomainType:
+		return t.T, true
+	case *CompositeType:
+		return t.T, true
+	case *RangeType:
+		return t.T, true
+	}
+	return "", false
+}
+
+// domainEqual reports if the two domain types are equal.
+func domainEqual(d1, d2 *DomainType) bool {
+	if d1.T != d2.T || d1.NotNull != d2.NotNull || !typesMatch(d1.Base, d2.Base) || len(d1.Checks) != len(d2.Checks) {
+		return false
+	}
+	x1, ok1 := d1.Default.(*schema.RawExpr)
+	x2, ok2 := d2.Default.(*schema.RawExpr)
+	if ok1 != ok2 || ok1 && trimCast(x1.X) != trimCast(x2.X) {
+		return false
+	}
+	for _, c1 := range d1.Checks {
+		c2, ok := checkNamed(d2.Checks, c1.Name)
+		if !ok || sqlx.UnwrapExpr(c1.Clause) != sqlx.UnwrapExpr(c2.Clause) {
+			return false
+		}
+	}
+	return true
+}
+
+// compositeEqual reports if the two composite types are equal.
+func compositeEqual(c1, c2 *CompositeType) bool {
+	if c1.T != c2.T || len(c1.Fields) != len(c2.Fields) {
+		return false
+	}
+	for i := range c1.Fields {
+		if c1.Fields[i].Name != c2.Fields[i].Name || !typesMatch(c1.Fields[i].Type.Type, c2.Fields[i].Type.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// typesMatch reports if the two types are formatted the same.
+func typesMatch(t1, t2 schema.Type) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == t2
+	}
+	f1, err1 := FormatType(t1)
+	f2, err2 := FormatType(t2)
+	return err1 == nil && err2 == nil && f1 == f2
+}
+
 // Normalize implements the sqlx.Normalizer interface.
 func (d *diff) Normalize(from, to *schema.Table) {
 	d.normalize(from)
@@ -294,6 +788,15 @@ func checkByName(attr []schema.Attr, name string) (*Check, bool) {
 	return nil, false
 }
 
+func checkNamed(checks []*Check, name string) (*Check, bool) {
+	for _, c := range checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
 func trimCast(s string) string {
 	i := strings.LastIndex(s, "::")
 	if i == -1 {
@@ -306,3 +809,12 @@ func trimCast(s string) string {
 	}
 	return s[:i]
 }
+
+func compositeField(t *CompositeType, name string) (*schema.Column, bool) {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
@@ -70,6 +70,79 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			},
 		},
+		func() testcase {
+			c := &schema.Column{Name: "created_at", Type: &schema.ColumnType{Raw: "date", Type: &schema.TimeType{T: "date"}}}
+			jan := &PartitionDef{Name: "logs_2021_01", Bound: "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')"}
+			feb := &PartitionDef{Name: "logs_2021_02", Bound: "FOR VALUES FROM ('2021-02-01') TO ('2021-03-01')"}
+			def1 := &PartitionDef{Name: "logs_default", Bound: "DEFAULT"}
+			def2 := &PartitionDef{Name: "logs_default", Bound: "FOR VALUES FROM (MINVALUE) TO ('2021-01-01')"}
+			return testcase{
+				name: "partitions",
+				from: &schema.Table{Name: "logs", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c}, Attrs: []schema.Attr{
+					&Partition{T: "RANGE", Parts: []*PartitionPart{{C: c}}, Defs: []*PartitionDef{jan, def1}},
+				}},
+				to: &schema.Table{Name: "logs", Columns: []*schema.Column{c}, Attrs: []schema.Attr{
+					&Partition{T: "range", Parts: []*PartitionPart{{X: &schema.RawExpr{X: `"created_at"`}}}, Defs: []*PartitionDef{feb, def2}},
+				}},
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: jan},
+					&schema.ModifyAttr{From: def1, To: def2},
+					&schema.AddAttr{A: feb},
+				},
+			}
+		}(),
+		func() testcase {
+			c := &schema.Column{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+			from := &Partition{T: "RANGE", Parts: []*PartitionPart{{C: c}}}
+			to := &Partition{T: "HASH", Parts: []*PartitionPart{{C: c}}}
+			return testcase{
+				name: "change partition key",
+				from: &schema.Table{Name: "logs", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c}, Attrs: []schema.Attr{from}},
+				to:   &schema.Table{Name: "logs", Columns: []*schema.Column{c}, Attrs: []schema.Attr{to}},
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: from, To: to},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				rs1     = &RowSecurity{Enabled: true}
+				rs2     = &RowSecurity{Enabled: true, Force: true}
+				tenant  = &Policy{Name: "tenant", As: "PERMISSIVE", For: "ALL", To: []string{"PUBLIC"}, Using: "(tenant_id = 1)"}
+				admins  = &Policy{Name: "admins", As: "PERMISSIVE", For: "ALL", To: []string{"admin", "app"}}
+				old     = &Policy{Name: "old", As: "RESTRICTIVE", For: "SELECT", To: []string{"PUBLIC"}}
+				admins2 = &Policy{Name: "admins", To: []string{"app"}}
+				added   = &Policy{Name: "added", For: "insert", Check: "tenant_id = 1"}
+			)
+			return testcase{
+				name: "policies",
+				from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{rs1, tenant, admins, old}},
+				to: &schema.Table{Name: "users", Attrs: []schema.Attr{
+					rs2,
+					// Unset options are compared with their default values.
+					&Policy{Name: "tenant", To: []string{"public"}, Using: "tenant_id = 1"},
+					admins2,
+					added,
+				}},
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: rs1, To: rs2},
+					&schema.ModifyAttr{From: admins, To: admins2},
+					&schema.DropAttr{A: old},
+					&schema.AddAttr{A: added},
+				},
+			}
+		}(),
+		func() testcase {
+			rs := &RowSecurity{Enabled: true}
+			return testcase{
+				name: "disable row security",
+				from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{rs}},
+				to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&RowSecurity{}}},
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: rs},
+				},
+			}
+		}(),
 		func() testcase {
 			var (
 				from = &schema.Table{
@@ -110,6 +183,142 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			}
 		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "t1",
+					Schema: &schema.Schema{
+						Name: "public",
+					},
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(c * 2)", Type: "STORED"}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(c * 2)", Type: "STORED"}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(c * 2)", Type: "STORED"}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "t1",
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "c * 2"}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "c * 3"}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}},
+					},
+				}
+			)
+			return testcase{
+				name: "generated columns",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeGenerated},
+					&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[2], Change: schema.ChangeGenerated},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "t1",
+					Schema: &schema.Schema{
+						Name: "public",
+					},
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Start: 1, Increment: 1}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Start: 1, Increment: 1}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Start: 1, Increment: 1}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "t1",
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{}}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Start: 100}}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "always"}}},
+					},
+				}
+			)
+			return testcase{
+				name: "identity columns",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeAttr},
+					&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[2], Change: schema.ChangeAttr},
+					&schema.ModifyColumn{From: from.Columns[3], To: to.Columns[3], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			domain := func(checks ...*Check) *DomainType {
+				return &DomainType{T: "posint", Base: &schema.IntegerType{T: "integer"}, Checks: checks}
+			}
+			var (
+				from = &schema.Table{
+					Name: "t1",
+					Schema: &schema.Schema{
+						Name: "public",
+					},
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "posint", Type: domain(&Check{Name: "positive", Clause: "(VALUE > 0)"})}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "posint", Type: domain(&Check{Name: "positive", Clause: "(VALUE > 0)"})}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "address", Type: &CompositeType{T: "address", Fields: []*schema.Column{{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int4"}}}}}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "address", Type: &CompositeType{T: "address", Fields: []*schema.Column{{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int4"}}}}}}},
+						{Name: "c5", Type: &schema.ColumnType{Raw: "floatrange", Type: &RangeType{T: "floatrange", Subtype: &schema.FloatType{T: "double precision"}}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "t1",
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Raw: "posint", Type: domain(&Check{Name: "positive", Clause: "VALUE > 0"})}},
+						{Name: "c2", Type: &schema.ColumnType{Raw: "posint", Type: domain(&Check{Name: "positive", Clause: "VALUE >= 1"})}},
+						{Name: "c3", Type: &schema.ColumnType{Raw: "address", Type: &CompositeType{T: "address", Fields: []*schema.Column{{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}}}}}},
+						{Name: "c4", Type: &schema.ColumnType{Raw: "address", Type: &CompositeType{T: "address", Fields: []*schema.Column{{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "bigint"}}}}}}},
+						// User-defined types referenced by name (e.g. in HCL) are compared by their names.
+						{Name: "c5", Type: &schema.ColumnType{Raw: "floatrange", Type: &UserDefinedType{T: "floatrange"}}},
+					},
+				}
+			)
+			return testcase{
+				name: "user-defined types",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeType},
+					&schema.ModifyColumn{From: from.Columns[3], To: to.Columns[3], Change: schema.ChangeType},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				public = &schema.Schema{Name: "public"}
+				from   = &schema.Table{
+					Name:   "t1",
+					Schema: public,
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}, Schema: public}}},
+						{Name: "c2", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}, Schema: public}}},
+					},
+				}
+				to = &schema.Table{
+					Name:   "t1",
+					Schema: public,
+					Columns: []*schema.Column{
+						{Name: "c1", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+						{Name: "c2", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}, Schema: &schema.Schema{Name: "other"}}}},
+					},
+				}
+			)
+			return testcase{
+				name: "enum schemas",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeType},
+				},
+			}
+		}(),
 		func() testcase {
 			var (
 				from = &schema.Table{
@@ -207,6 +416,81 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			}
 		}(),
+		func() testcase {
+			var (
+				ref  = &schema.Table{Name: "t2", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}}}
+				from = &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{{Name: "t2_id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}}}
+				to   = &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{{Name: "t2_id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}}}
+			)
+			from.ForeignKeys = []*schema.ForeignKey{
+				{Symbol: "deferred", Table: from, Columns: from.Columns, RefTable: ref, RefColumns: ref.Columns, Attrs: []schema.Attr{&Deferrable{}}},
+				{Symbol: "simple", Table: from, Columns: from.Columns, RefTable: ref, RefColumns: ref.Columns, Attrs: []schema.Attr{&MatchType{T: "SIMPLE"}}},
+			}
+			to.ForeignKeys = []*schema.ForeignKey{
+				{Symbol: "deferred", Table: to, Columns: to.Columns, RefTable: ref, RefColumns: ref.Columns, Attrs: []schema.Attr{&Deferrable{InitiallyDeferred: true}}},
+				// The SIMPLE match type is the default.
+				{Symbol: "simple", Table: to, Columns: to.Columns, RefTable: ref, RefColumns: ref.Columns},
+			}
+			return testcase{
+				name: "foreign-key attributes",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyForeignKey{
+						From:   from.ForeignKeys[0],
+						To:     to.ForeignKeys[0],
+						Change: schema.ChangeAttr,
+					},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = &schema.Column{Name: "c", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+				d    = &schema.Column{Name: "d", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+				from = &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c, d}}
+				to   = &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c, d}}
+			)
+			from.Indexes = []*schema.Index{
+				{Name: "t1_c_key", Unique: true, Table: from, Parts: []*schema.IndexPart{{C: c}}, Attrs: []schema.Attr{&ConType{T: "u"}}},
+				{Name: "t1_d_key", Unique: true, Table: from, Parts: []*schema.IndexPart{{C: d}}, Attrs: []schema.Attr{&ConType{T: "u"}, &IndexInclude{Columns: []*schema.Column{c}}}},
+				{Name: "t1_cd_idx", Unique: true, Table: from, Parts: []*schema.IndexPart{{C: c}, {C: d}}},
+			}
+			to.Indexes = []*schema.Index{
+				// A unique index instead of a UNIQUE constraint.
+				{Name: "t1_c_key", Unique: true, Table: to, Parts: []*schema.IndexPart{{C: c}}},
+				{Name: "t1_d_key", Unique: true, Table: to, Parts: []*schema.IndexPart{{C: d}}, Attrs: []schema.Attr{&ConType{T: "u"}, &IndexInclude{Columns: []*schema.Column{d}}}},
+				// NULL values are considered distinct by default.
+				{Name: "t1_cd_idx", Unique: true, Table: to, Parts: []*schema.IndexPart{{C: c}, {C: d}}, Attrs: []schema.Attr{&IndexNullsDistinct{V: true}}},
+			}
+			return testcase{
+				name: "unique constraints",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyIndex{From: from.Indexes[0], To: to.Indexes[0], Change: schema.ChangeAttr},
+					&schema.ModifyIndex{From: from.Indexes[1], To: to.Indexes[1], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = &schema.Column{Name: "room", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+				from = &Exclude{Name: "no_overlap", T: "gist", Elems: []*ExcludeElem{{C: c, Op: "="}, {X: &schema.RawExpr{X: "tsrange(start_at, end_at)"}, Op: "&&"}}}
+				old  = &Exclude{Name: "old", Elems: []*ExcludeElem{{C: c, Op: "="}}}
+				to   = &Exclude{Name: "no_overlap", T: "GIST", Elems: []*ExcludeElem{{C: c, Op: "="}, {X: &schema.RawExpr{X: "(tsrange(start_at, end_at))"}, Op: "&&"}}, Attrs: []schema.Attr{&Deferrable{}}}
+				same = &Exclude{Name: "same", T: "btree", Elems: []*ExcludeElem{{C: c, Op: "="}}}
+			)
+			return testcase{
+				name: "exclusion constraints",
+				from: &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}, Columns: []*schema.Column{c}, Attrs: []schema.Attr{from, old, same}},
+				to:   &schema.Table{Name: "t1", Columns: []*schema.Column{c}, Attrs: []schema.Attr{to, &Exclude{Name: "same", Elems: same.Elems}}},
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: from, To: to},
+					&schema.DropAttr{A: old},
+				},
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		db, m, err := sqlmock.New()
@@ -255,3 +539,144 @@ func TestDiff_SchemaDiff(t *testing.T) {
 		&schema.AddTable{T: to.Tables[1]},
 	}, changes)
 }
+
+func TestDiff_SkipDrops(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		c     = &schema.Column{Name: "c", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}}
+		jan   = &PartitionDef{Name: "logs_2021_01", Bound: "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')"}
+		feb   = &PartitionDef{Name: "logs_2021_02", Bound: "FOR VALUES FROM ('2021-02-01') TO ('2021-03-01')"}
+		check = &Check{Name: "positive", Clause: "(c > 0)"}
+		from  = &schema.Schema{Name: "public", Attrs: []schema.Attr{&Extension{Name: "pgcrypto"}, &Extension{Name: "citext"}}}
+		to    = &schema.Schema{Name: "public", Attrs: []schema.Attr{&Extension{Name: "citext"}}}
+	)
+	from.Tables = []*schema.Table{
+		{Name: "logs", Schema: from, Columns: []*schema.Column{c}, Attrs: []schema.Attr{check, &Partition{T: "RANGE", Parts: []*PartitionPart{{C: c}}, Defs: []*PartitionDef{jan, feb}}}},
+	}
+	to.Tables = []*schema.Table{
+		{Name: "logs", Schema: to, Columns: []*schema.Column{c}, Attrs: []schema.Attr{&Partition{T: "RANGE", Parts: []*PartitionPart{{C: c}}, Defs: []*PartitionDef{jan}}}},
+	}
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	// Removed checks, extensions and partitions are not dropped.
+	changes, err = drv.SchemaDiff(from, to, schema.WithDiffOptions(schema.DiffOptions{SkipDrops: true}))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestDiff_SchemaAttrDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		citext   = &Extension{Name: "citext", Version: "1.5"}
+		postgis  = &Extension{Name: "postgis", Version: "3.1.4"}
+		pgcrypto = &Extension{Name: "pgcrypto"}
+		from     = &schema.Schema{Name: "public", Attrs: []schema.Attr{citext, postgis}}
+		to       = &schema.Schema{Name: "public", Attrs: []schema.Attr{&Extension{Name: "citext", Version: "1.6"}, pgcrypto}}
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifySchema{
+			S: from,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{From: citext, To: to.Attrs[0]},
+				&schema.DropAttr{A: postgis},
+				&schema.AddAttr{A: pgcrypto},
+			},
+		},
+	}, changes)
+
+	// Versions are compared only if set explicitly.
+	to.Attrs[0] = &Extension{Name: "citext"}
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].(*schema.ModifySchema).Changes, 2)
+
+	// Extensions are not dropped if the desired schema does not declare any.
+	changes, err = drv.SchemaDiff(from, &schema.Schema{Name: "public"})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Enums that are not used by the desired schema are dropped.
+	var (
+		mood  = &Enum{T: "mood"}
+		state = &Enum{T: "state"}
+		users = &schema.Table{
+			Name: "users",
+			Columns: []*schema.Column{
+				{Name: "state", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+				{Name: "level", Type: &schema.ColumnType{Type: &schema.EnumType{T: "level", Values: []string{"info"}, Schema: &schema.Schema{Name: "other"}}}},
+			},
+		}
+	)
+	from = &schema.Schema{Name: "public", Attrs: []schema.Attr{mood, state, &Enum{T: "level"}}}
+	to = &schema.Schema{Name: "public", Tables: []*schema.Table{users}}
+	users.Schema = to
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.EqualValues(t, &schema.ModifySchema{
+		S: from,
+		Changes: []schema.Change{
+			&schema.DropAttr{A: mood},
+			&schema.DropAttr{A: from.Attrs[2]},
+		},
+	}, changes[0])
+	require.EqualValues(t, &schema.AddTable{T: users}, changes[1])
+
+	// Roles that exist in the realm are not created.
+	from = &schema.Schema{
+		Name:  "public",
+		Realm: &schema.Realm{Attrs: []schema.Attr{&schema.Role{Name: "app"}}},
+		Attrs: []schema.Attr{
+			&schema.Grant{Grantee: "app", Privileges: []string{"USAGE"}},
+			&schema.Grant{Grantee: "PUBLIC", Privileges: []string{"CREATE", "USAGE"}},
+		},
+	}
+	to = &schema.Schema{
+		Name: "public",
+		Attrs: []schema.Attr{
+			&schema.Role{Name: "app"},
+			&schema.Role{Name: "reporter"},
+			&schema.Grant{Grantee: "app", Privileges: []string{"usage", "create"}},
+			&schema.Grant{Grantee: "reporter", Privileges: []string{"USAGE"}},
+		},
+	}
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifySchema{
+			S: from,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: &schema.Role{Name: "reporter"}},
+				// The default privileges of PUBLIC on the "public" schema are kept.
+				&schema.ModifyAttr{
+					From: &schema.Grant{Grantee: "app", Privileges: []string{"USAGE"}},
+					To:   &schema.Grant{Grantee: "app", Privileges: []string{"CREATE", "USAGE"}},
+				},
+				&schema.AddAttr{A: &schema.Grant{Grantee: "reporter", Privileges: []string{"USAGE"}}},
+			},
+		},
+	}, changes)
+
+	// Unless the desired schema declares grants to PUBLIC.
+	to.Attrs = append(to.Attrs, &schema.Grant{Grantee: "PUBLIC", Privileges: []string{"USAGE"}})
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Contains(t, changes[0].(*schema.ModifySchema).Changes, &schema.ModifyAttr{
+		From: &schema.Grant{Grantee: "PUBLIC", Privileges: []string{"CREATE", "USAGE"}},
+		To:   &schema.Grant{Grantee: "PUBLIC", Privileges: []string{"USAGE"}},
+	})
+}
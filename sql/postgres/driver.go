@@ -6,6 +6,8 @@ package postgres
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"ariga.io/atlas/sql/internal/sqlx"
 
@@ -64,11 +66,30 @@ func Open(db schema.ExecQuerier) (*Driver, error) {
 	return &Driver{
 		conn:      c,
 		Differ:    &sqlx.Diff{DiffDriver: &diff{c}},
-		Execer:    &migrate{c},
+		Execer:    &migrate{conn: c},
 		Inspector: &inspect{c},
 	}, nil
 }
 
+// supportsNullsDistinct reports if the connected database supports the
+// NULLS [NOT] DISTINCT option of unique indexes, that was added in v15.
+func (c conn) supportsNullsDistinct() bool {
+	return semver.Compare(c.semVersion(), "v15.0.0") != -1
+}
+
+// semVersion returns the version of the connected database in its semver form.
+// Versions are formatted as "MM.mm.pp" (e.g. 15.00.02) on connection, and since
+// leading zeros are invalid in semver, each part is converted to a number.
+func (c conn) semVersion() string {
+	parts := strings.Split(c.version, ".")
+	for i, p := range parts {
+		if n, err := strconv.Atoi(p); err == nil {
+			parts[i] = strconv.Itoa(n)
+		}
+	}
+	return "v" + strings.Join(parts, ".")
+}
+
 // Standard column types (and their aliases) as defined in
 // PostgreSQL codebase/website.
 const (
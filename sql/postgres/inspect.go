@@ -19,12 +19,25 @@ type inspect struct{ conn }
 
 var _ schema.Inspector = (*inspect)(nil)
 
+var (
+	// Query to list table indexes.
+	indexesQuery = fmt.Sprintf(indexesQueryTmpl, "false")
+	// Query to list table indexes, including their NULLS NOT DISTINCT option.
+	indexesQueryNullsDistinct = fmt.Sprintf(indexesQueryTmpl, "idx.indnullsnotdistinct")
+)
+
 // InspectRealm returns schema descriptions of all resources in the given realm.
 func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOption) (*schema.Realm, error) {
 	schemas, err := i.schemas(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
+	if err := i.extensions(ctx, schemas); err != nil {
+		return nil, err
+	}
+	if err := i.schemaEnums(ctx, schemas); err != nil {
+		return nil, err
+	}
 	realm := &schema.Realm{Schemas: schemas, Attrs: []schema.Attr{&schema.Collation{V: i.collate}, &CType{V: i.ctype}}}
 	for _, s := range schemas {
 		names, err := i.tableNames(ctx, s.Name, nil)
@@ -38,9 +51,22 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 			}
 			s.Tables = append(s.Tables, t)
 		}
+		if err := i.grants(ctx, s); err != nil {
+			return nil, err
+		}
 		s.Realm = realm
 	}
+	if err := i.roles(ctx, realm); err != nil {
+		return nil, err
+	}
 	sqlx.LinkSchemaTables(schemas)
+	if opts != nil {
+		for _, s := range schemas {
+			if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return realm, nil
 }
 
@@ -57,6 +83,12 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 			Err: fmt.Errorf("postgres: schema %q was not found", name),
 		}
 	}
+	if err := i.extensions(ctx, schemas); err != nil {
+		return nil, err
+	}
+	if err := i.schemaEnums(ctx, schemas); err != nil {
+		return nil, err
+	}
 	names, err := i.tableNames(ctx, name, opts)
 	if err != nil {
 		return nil, err
@@ -69,8 +101,19 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		}
 		s.Tables = append(s.Tables, t)
 	}
-	sqlx.LinkSchemaTables(schemas)
+	if err := i.grants(ctx, s); err != nil {
+		return nil, err
+	}
 	s.Realm = &schema.Realm{Schemas: schemas, Attrs: []schema.Attr{&schema.Collation{V: i.collate}, &CType{V: i.ctype}}}
+	if err := i.roles(ctx, s.Realm); err != nil {
+		return nil, err
+	}
+	sqlx.LinkSchemaTables(schemas)
+	if opts != nil {
+		if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+			return nil, err
+		}
+	}
 	return s, nil
 }
 
@@ -97,9 +140,21 @@ func (i *inspect) inspectTable(ctx context.Context, name string, opts *schema.In
 	if err := i.fks(ctx, t); err != nil {
 		return nil, err
 	}
+	if err := i.fkAttrs(ctx, t); err != nil {
+		return nil, err
+	}
 	if err := i.checks(ctx, t); err != nil {
 		return nil, err
 	}
+	if err := i.excludes(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := i.partitions(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := i.policies(ctx, t); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
@@ -114,8 +169,11 @@ func (i *inspect) table(ctx context.Context, name string, opts *schema.InspectTa
 		args = append(args, opts.Schema)
 	}
 	row := i.QueryRowContext(ctx, query, args...)
-	var tSchema, comment sql.NullString
-	if err := row.Scan(&tSchema, &comment); err != nil {
+	var (
+		rls, forceRLS             bool
+		tSchema, comment, partKey sql.NullString
+	)
+	if err := row.Scan(&tSchema, &comment, &partKey, &rls, &forceRLS); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &schema.NotExistError{
 				Err: fmt.Errorf("postgres: table %q was not found", name),
@@ -129,9 +187,120 @@ func (i *inspect) table(ctx context.Context, name string, opts *schema.InspectTa
 			Text: comment.String,
 		})
 	}
+	if sqlx.ValidString(partKey) {
+		p, err := partitionKey(partKey.String)
+		if err != nil {
+			return nil, err
+		}
+		t.Attrs = append(t.Attrs, p)
+	}
+	if rls || forceRLS {
+		t.Attrs = append(t.Attrs, &RowSecurity{Enabled: rls, Force: forceRLS})
+	}
 	return t, nil
 }
 
+// partitionKey parses the partition key definition of a table as returned
+// by pg_get_partkeydef. For example: "RANGE (created_at)". The key parts are
+// linked to the table columns after they were inspected.
+func partitionKey(def string) (*Partition, error) {
+	i := strings.IndexByte(def, ' ')
+	if i == -1 {
+		return nil, fmt.Errorf("postgres: unexpected partition key definition: %q", def)
+	}
+	p := &Partition{T: strings.ToUpper(def[:i])}
+	for _, x := range sqlx.SplitExprs(sqlx.UnwrapExpr(def[i+1:])) {
+		p.Parts = append(p.Parts, &PartitionPart{X: &schema.RawExpr{X: x}})
+	}
+	return p, nil
+}
+
+// partitions links the partition key of the given table to its columns,
+// and queries and appends its partitions, if the table is partitioned.
+func (i *inspect) partitions(ctx context.Context, t *schema.Table) error {
+	p := partition(t.Attrs)
+	if p == nil {
+		return nil
+	}
+	for _, part := range p.Parts {
+		if x, ok := part.X.(*schema.RawExpr); ok {
+			if c, ok := t.Column(strings.Trim(x.X, `"`)); ok {
+				part.C, part.X = c, nil
+			}
+		}
+	}
+	rows, err := i.QueryContext(ctx, partitionsQuery, t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying %q partitions: %w", t.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		d := &PartitionDef{}
+		if err := rows.Scan(&d.Name, &d.Bound); err != nil {
+			return fmt.Errorf("postgres: scanning partition: %w", err)
+		}
+		p.Defs = append(p.Defs, d)
+	}
+	return rows.Err()
+}
+
+// partition returns the partition attribute of a table, if exists.
+func partition(attrs []schema.Attr) *Partition {
+	for _, a := range attrs {
+		if p, ok := a.(*Partition); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// policies queries and appends the row-level security policies of the given table.
+func (i *inspect) policies(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, policiesQuery, t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying %q policies: %w", t.Name, err)
+	}
+	defer rows.Close()
+	names := make(map[string]*Policy)
+	for rows.Next() {
+		var (
+			name, as, cmd, role string
+			using, check        sql.NullString
+		)
+		if err := rows.Scan(&name, &as, &cmd, &role, &using, &check); err != nil {
+			return fmt.Errorf("postgres: scanning policy: %w", err)
+		}
+		p, ok := names[name]
+		if !ok {
+			p = &Policy{Name: name, As: as, For: cmd, Using: using.String, Check: check.String}
+			names[name] = p
+			t.Attrs = append(t.Attrs, p)
+		}
+		p.To = append(p.To, role)
+	}
+	return rows.Err()
+}
+
+// policies returns the row-level security policies of a table.
+func policies(attrs []schema.Attr) (ps []*Policy) {
+	for _, a := range attrs {
+		if p, ok := a.(*Policy); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// rowSecurity returns the row-level security attribute of a table, if exists.
+func rowSecurity(attrs []schema.Attr) *RowSecurity {
+	for _, a := range attrs {
+		if r, ok := a.(*RowSecurity); ok {
+			return r
+		}
+	}
+	return nil
+}
+
 // columns queries and appends the columns of the given table.
 func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
 	rows, err := i.QueryContext(ctx, columnsQuery, t.Schema.Name, t.Name)
@@ -150,16 +319,25 @@ func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
 	if err := i.enumValues(ctx, t.Columns); err != nil {
 		return err
 	}
+	if err := i.domainDefs(ctx, t.Columns); err != nil {
+		return err
+	}
+	if err := i.compositeFields(ctx, t.Columns); err != nil {
+		return err
+	}
+	if err := i.rangeSubtypes(ctx, t.Columns); err != nil {
+		return err
+	}
 	return nil
 }
 
 // addColumn scans the current row and adds a new column from it to the table.
 func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 	var (
-		typid, maxlen, precision, scale                                                                sql.NullInt64
-		name, typ, nullable, defaults, udt, identity, generation, charset, collation, comment, typtype sql.NullString
+		typid, maxlen, precision, scale, start, increment                                                            sql.NullInt64
+		name, typ, nullable, defaults, udt, identity, generation, charset, collation, comment, typtype, expr, domain sql.NullString
 	)
-	if err := rows.Scan(&name, &typ, &nullable, &defaults, &maxlen, &precision, &scale, &charset, &collation, &udt, &identity, &generation, &comment, &typtype, &typid); err != nil {
+	if err := rows.Scan(&name, &typ, &nullable, &defaults, &maxlen, &precision, &scale, &charset, &collation, &udt, &identity, &generation, &comment, &typtype, &typid, &expr, &start, &increment, &domain); err != nil {
 		return err
 	}
 	c := &schema.Column{
@@ -178,12 +356,27 @@ func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 		typtype:   typtype.String,
 		typid:     typid.Int64,
 	})
+	// For columns that are based on a domain, the data_type
+	// column holds the type underlying the domain, and their
+	// definition is filled in batch after the rows are closed.
+	if sqlx.ValidString(domain) && typtype.String == "d" {
+		c.Type.Raw = domain.String
+		c.Type.Type = &DomainType{T: domain.String, ID: typid.Int64, Base: c.Type.Type}
+	}
 	if sqlx.ValidString(defaults) {
 		c.Default = defaultExpr(defaults.String)
 	}
+	if sqlx.ValidString(expr) {
+		c.Attrs = append(c.Attrs, &schema.GeneratedExpr{
+			Expr: expr.String,
+			Type: "STORED",
+		})
+	}
 	if identity.String == "YES" {
 		c.Attrs = append(c.Attrs, &Identity{
 			Generation: generation.String,
+			Start:      start.Int64,
+			Increment:  increment.Int64,
 		})
 	}
 	if sqlx.ValidString(comment) {
@@ -252,11 +445,17 @@ func columnType(c *columnDesc) schema.Type {
 		typ = &ArrayType{T: strings.TrimPrefix(c.udt, "_") + "[]"}
 	case tUserDefined:
 		typ = &UserDefinedType{T: c.udt}
-		// The `typtype` column is set to 'e' for enum types, and the
-		// values are filled in batch after the rows above is closed.
+		// The `typtype` column is set to 'e' for enum types, 'c' for composite
+		// types and 'r' for range types. Their definitions are filled in batch
+		// after the rows above is closed.
 		// https://www.postgresql.org/docs/current/catalog-pg-type.html
-		if c.typtype == "e" {
+		switch c.typtype {
+		case "e":
 			typ = &EnumType{T: c.udt, ID: c.typid}
+		case "c":
+			typ = &CompositeType{T: c.udt, ID: c.typid}
+		case "r":
+			typ = &RangeType{T: c.udt, ID: c.typid}
 		}
 	default:
 		typ = &schema.UnsupportedType{T: t}
@@ -264,21 +463,16 @@ func columnType(c *columnDesc) schema.Type {
 	return typ
 }
 
-// enumValues fills enum columns with their values from the database.
+// enumValues fills enum columns with their values and schema from the database.
 func (i *inspect) enumValues(ctx context.Context, columns []*schema.Column) error {
 	var (
-		args  []interface{}
-		ids   = make(map[int64][]*schema.EnumType)
-		query = "SELECT enumtypid, enumlabel FROM pg_enum WHERE enumtypid IN ("
+		args []interface{}
+		ids  = make(map[int64][]*schema.EnumType)
 	)
 	for _, c := range columns {
 		if enum, ok := c.Type.Type.(*EnumType); ok {
 			if _, ok := ids[enum.ID]; !ok {
-				if len(args) > 0 {
-					query += ", "
-				}
 				args = append(args, enum.ID)
-				query += fmt.Sprintf("$%d", len(args))
 			}
 			// Convert the intermediate type to the standard schema.EnumType.
 			e := &schema.EnumType{T: enum.T}
@@ -287,33 +481,176 @@ func (i *inspect) enumValues(ctx context.Context, columns []*schema.Column) erro
 			ids[enum.ID] = append(ids[enum.ID], e)
 		}
 	}
-	query += ")"
 	if len(ids) == 0 {
 		return nil
 	}
-	rows, err := i.QueryContext(ctx, query, args...)
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(enumsQuery, placeholders(len(args))), args...)
 	if err != nil {
 		return fmt.Errorf("postgres: querying enum values: %w", err)
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var (
-			id int64
-			v  string
+			id    int64
+			v, ns string
 		)
-		if err := rows.Scan(&id, &v); err != nil {
+		if err := rows.Scan(&id, &v, &ns); err != nil {
 			return fmt.Errorf("postgres: scanning enum label: %w", err)
 		}
 		for _, enum := range ids[id] {
+			if enum.Schema == nil {
+				enum.Schema = &schema.Schema{Name: ns}
+			}
 			enum.Values = append(enum.Values, v)
 		}
 	}
-	return nil
+	return rows.Err()
+}
+
+// domainDefs fills domain columns with their definitions from the database.
+func (i *inspect) domainDefs(ctx context.Context, columns []*schema.Column) error {
+	var (
+		args []interface{}
+		ids  = make(map[int64][]*DomainType)
+	)
+	for _, c := range columns {
+		if d, ok := c.Type.Type.(*DomainType); ok {
+			if _, ok := ids[d.ID]; !ok {
+				args = append(args, d.ID)
+			}
+			ids[d.ID] = append(ids[d.ID], d)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(domainsQuery, placeholders(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying domain definitions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id                int64
+			notNull           bool
+			defaults, name, x sql.NullString
+		)
+		if err := rows.Scan(&id, &notNull, &defaults, &name, &x); err != nil {
+			return fmt.Errorf("postgres: scanning domain definition: %w", err)
+		}
+		for _, d := range ids[id] {
+			d.NotNull = notNull
+			if sqlx.ValidString(defaults) && d.Default == nil {
+				d.Default = &schema.RawExpr{X: defaults.String}
+			}
+			if sqlx.ValidString(name) {
+				d.Checks = append(d.Checks, &Check{Name: name.String, Clause: x.String})
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// compositeFields fills composite columns with their fields from the database.
+func (i *inspect) compositeFields(ctx context.Context, columns []*schema.Column) error {
+	var (
+		args []interface{}
+		ids  = make(map[int64][]*CompositeType)
+	)
+	for _, c := range columns {
+		if t, ok := c.Type.Type.(*CompositeType); ok {
+			if _, ok := ids[t.ID]; !ok {
+				args = append(args, t.ID)
+			}
+			ids[t.ID] = append(ids[t.ID], t)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(compositesQuery, placeholders(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying composite fields: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id                       int64
+			maxlen, precision, scale sql.NullInt64
+			name, typ, fieldUDT      sql.NullString
+		)
+		if err := rows.Scan(&id, &name, &typ, &maxlen, &precision, &scale, &fieldUDT); err != nil {
+			return fmt.Errorf("postgres: scanning composite field: %w", err)
+		}
+		for _, t := range ids[id] {
+			t.Fields = append(t.Fields, &schema.Column{
+				Name: name.String,
+				Type: &schema.ColumnType{
+					Raw: typ.String,
+					Type: columnType(&columnDesc{
+						typ:       typ.String,
+						size:      maxlen.Int64,
+						udt:       fieldUDT.String,
+						precision: precision.Int64,
+						scale:     scale.Int64,
+					}),
+					Null: true,
+				},
+			})
+		}
+	}
+	return rows.Err()
+}
+
+// rangeSubtypes fills range columns with their subtypes from the database.
+func (i *inspect) rangeSubtypes(ctx context.Context, columns []*schema.Column) error {
+	var (
+		args []interface{}
+		ids  = make(map[int64][]*RangeType)
+	)
+	for _, c := range columns {
+		if r, ok := c.Type.Type.(*RangeType); ok {
+			if _, ok := ids[r.ID]; !ok {
+				args = append(args, r.ID)
+			}
+			ids[r.ID] = append(ids[r.ID], r)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(rangesQuery, placeholders(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying range subtypes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id  int64
+			sub string
+		)
+		if err := rows.Scan(&id, &sub); err != nil {
+			return fmt.Errorf("postgres: scanning range subtype: %w", err)
+		}
+		for _, r := range ids[id] {
+			r.Subtype = columnType(&columnDesc{typ: sub})
+			// Subtypes that are unknown to us are
+			// treated as user-defined types.
+			if ut, ok := r.Subtype.(*schema.UnsupportedType); ok {
+				r.Subtype = &UserDefinedType{T: ut.T}
+			}
+		}
+	}
+	return rows.Err()
 }
 
 // indexes queries and appends the indexes of the given table.
 func (i *inspect) indexes(ctx context.Context, t *schema.Table) error {
-	rows, err := i.QueryContext(ctx, indexesQuery, t.Schema.Name, t.Name)
+	query := indexesQuery
+	if i.supportsNullsDistinct() {
+		query = indexesQueryNullsDistinct
+	}
+	rows, err := i.QueryContext(ctx, query, t.Schema.Name, t.Name)
 	if err != nil {
 		return fmt.Errorf("postgres: querying %q indexes: %w", t.Name, err)
 	}
@@ -329,12 +666,13 @@ func (i *inspect) addIndexes(t *schema.Table, rows *sql.Rows) error {
 	names := make(map[string]*schema.Index)
 	for rows.Next() {
 		var (
-			name, typ                            string
-			uniq, primary                        bool
-			asc, desc, nullsfirst, nullslast     sql.NullBool
-			column, contype, pred, expr, comment sql.NullString
+			name, typ                                        string
+			uniq, primary                                    bool
+			asc, desc, nullsfirst, nullslast                 sql.NullBool
+			included, nullsnotdistinct, deferrable, deferred sql.NullBool
+			column, contype, pred, expr, comment             sql.NullString
 		)
-		if err := rows.Scan(&name, &typ, &column, &primary, &uniq, &contype, &pred, &expr, &asc, &desc, &nullsfirst, &nullslast, &comment); err != nil {
+		if err := rows.Scan(&name, &typ, &column, &primary, &uniq, &contype, &pred, &expr, &asc, &desc, &nullsfirst, &nullslast, &comment, &included, &nullsnotdistinct, &deferrable, &deferred); err != nil {
 			return fmt.Errorf("postgres: scanning index: %w", err)
 		}
 		idx, ok := names[name]
@@ -356,6 +694,12 @@ func (i *inspect) addIndexes(t *schema.Table, rows *sql.Rows) error {
 			if sqlx.ValidString(pred) {
 				idx.Attrs = append(idx.Attrs, &IndexPredicate{P: pred.String})
 			}
+			if nullsnotdistinct.Bool {
+				idx.Attrs = append(idx.Attrs, &IndexNullsDistinct{V: false})
+			}
+			if deferrable.Bool {
+				idx.Attrs = append(idx.Attrs, &Deferrable{InitiallyDeferred: deferred.Bool})
+			}
 			names[name] = idx
 			if primary {
 				t.PrimaryKey = idx
@@ -363,6 +707,21 @@ func (i *inspect) addIndexes(t *schema.Table, rows *sql.Rows) error {
 				t.Indexes = append(t.Indexes, idx)
 			}
 		}
+		// Non-key columns are not part of the index key,
+		// and are stored in the INCLUDE clause instead.
+		if included.Bool {
+			c, ok := t.Column(column.String)
+			if !ok {
+				return fmt.Errorf("postgres: included column %q was not found for index %q", column.String, idx.Name)
+			}
+			inc, ok := indexInclude(idx.Attrs)
+			if !ok {
+				inc = &IndexInclude{}
+				idx.Attrs = append(idx.Attrs, inc)
+			}
+			inc.Columns = append(inc.Columns, c)
+			continue
+		}
 		part := &schema.IndexPart{
 			SeqNo: len(idx.Parts) + 1,
 			Attrs: []schema.Attr{
@@ -406,6 +765,111 @@ func (i *inspect) fks(ctx context.Context, t *schema.Table) error {
 	return rows.Err()
 }
 
+// fkAttrs queries and appends the DEFERRABLE and MATCH options of the
+// table foreign keys. The query is skipped for tables without foreign keys.
+func (i *inspect) fkAttrs(ctx context.Context, t *schema.Table) error {
+	if len(t.ForeignKeys) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fkAttrsQuery, t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying %q foreign keys attributes: %w", t.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name, match          string
+			deferrable, deferred bool
+		)
+		if err := rows.Scan(&name, &deferrable, &deferred, &match); err != nil {
+			return fmt.Errorf("postgres: scanning foreign key attributes: %w", err)
+		}
+		fk, ok := t.ForeignKey(name)
+		if !ok {
+			continue
+		}
+		if deferrable {
+			fk.Attrs = append(fk.Attrs, &Deferrable{InitiallyDeferred: deferred})
+		}
+		if match != "SIMPLE" {
+			fk.Attrs = append(fk.Attrs, &MatchType{T: match})
+		}
+	}
+	return rows.Err()
+}
+
+// excludes queries and appends the exclusion constraints of the given table.
+func (i *inspect) excludes(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, excludesQuery, t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying %q exclusion constraints: %w", t.Name, err)
+	}
+	defer rows.Close()
+	names := make(map[string]*Exclude)
+	for rows.Next() {
+		var (
+			name, typ, elem, op  string
+			deferrable, deferred bool
+			pred                 sql.NullString
+		)
+		if err := rows.Scan(&name, &typ, &elem, &op, &pred, &deferrable, &deferred); err != nil {
+			return fmt.Errorf("postgres: scanning exclusion constraint: %w", err)
+		}
+		e, ok := names[name]
+		if !ok {
+			e = &Exclude{Name: name, T: typ, Where: pred.String}
+			if deferrable {
+				e.Attrs = append(e.Attrs, &Deferrable{InitiallyDeferred: deferred})
+			}
+			names[name] = e
+			t.Attrs = append(t.Attrs, e)
+		}
+		el := &ExcludeElem{Op: op}
+		if c, ok := t.Column(strings.Trim(elem, `"`)); ok {
+			el.C = c
+		} else {
+			el.X = &schema.RawExpr{X: elem}
+		}
+		e.Elems = append(e.Elems, el)
+	}
+	return rows.Err()
+}
+
+// excludes returns the exclusion constraints of a table.
+func excludes(attrs []schema.Attr) (es []*Exclude) {
+	for _, a := range attrs {
+		if e, ok := a.(*Exclude); ok {
+			es = append(es, e)
+		}
+	}
+	return es
+}
+
+// indexInclude returns the INCLUDE clause of an index, if it exists.
+func indexInclude(attrs []schema.Attr) (*IndexInclude, bool) {
+	for _, a := range attrs {
+		if i, ok := a.(*IndexInclude); ok {
+			return i, true
+		}
+	}
+	return nil, false
+}
+
+// nullsDistinct reports if NULL values are considered distinct
+// by the index, which is the default if no option was set.
+func nullsDistinct(attrs []schema.Attr) bool {
+	n := &IndexNullsDistinct{V: true}
+	sqlx.Has(attrs, n)
+	return n.V
+}
+
+// uniqueConstraint reports if the index is backed by a UNIQUE constraint,
+// rather than created as a standalone unique index.
+func uniqueConstraint(idx *schema.Index) bool {
+	var t ConType
+	return idx.Unique && sqlx.Has(idx.Attrs, &t) && t.T == "u"
+}
+
 // checks queries and appends the check constraints of the given table.
 func (i *inspect) checks(ctx context.Context, t *schema.Table) error {
 	rows, err := i.QueryContext(ctx, checksQuery, t.Schema.Name, t.Name)
@@ -473,6 +937,108 @@ func (i *inspect) schemas(ctx context.Context, opts *schema.InspectRealmOption)
 	return schemas, nil
 }
 
+// extensions queries and appends the extensions that are installed in the given schemas.
+func (i *inspect) extensions(ctx context.Context, schemas []*schema.Schema) error {
+	if len(schemas) == 0 {
+		return nil
+	}
+	names := make([]string, len(schemas))
+	for j, s := range schemas {
+		names[j] = s.Name
+	}
+	query, args := inStrings(names, extensionsQuery+" WHERE t2.nspname ", nil)
+	rows, err := i.QueryContext(ctx, query+" ORDER BY t1.extname", args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying extensions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ns string
+		e := &Extension{}
+		if err := rows.Scan(&ns, &e.Name, &e.Version); err != nil {
+			return fmt.Errorf("postgres: scanning extension: %w", err)
+		}
+		for _, s := range schemas {
+			if s.Name == ns {
+				s.Attrs = append(s.Attrs, e)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// schemaEnums queries and appends the enum types that are defined in the given schemas.
+func (i *inspect) schemaEnums(ctx context.Context, schemas []*schema.Schema) error {
+	if len(schemas) == 0 {
+		return nil
+	}
+	names := make([]string, len(schemas))
+	for j, s := range schemas {
+		names[j] = s.Name
+	}
+	query, args := inStrings(names, schemaEnumsQuery, nil)
+	rows, err := i.QueryContext(ctx, query+" ORDER BY t1.typname", args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema enums: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ns string
+		e := &Enum{}
+		if err := rows.Scan(&ns, &e.T); err != nil {
+			return fmt.Errorf("postgres: scanning schema enum: %w", err)
+		}
+		for _, s := range schemas {
+			if s.Name == ns {
+				s.Attrs = append(s.Attrs, e)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// extensions returns the extensions of a schema.
+func extensions(attrs []schema.Attr) (exts []*Extension) {
+	for _, a := range attrs {
+		if e, ok := a.(*Extension); ok {
+			exts = append(exts, e)
+		}
+	}
+	return exts
+}
+
+// grants inspects the privileges that were granted on the schema, its tables and their
+// columns, and adds them to the schema and its tables. The privileges of the owners are
+// implicit and therefore are not inspected.
+func (i *inspect) grants(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, grantsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q grants: %w", s.Name, err)
+	}
+	defer rows.Close()
+	if err := sqlx.ScanGrants(s, rows); err != nil {
+		return fmt.Errorf("postgres: scanning schema %q grants: %w", s.Name, err)
+	}
+	return nil
+}
+
+// roles inspects the roles defined in the database cluster, and adds them to the realm.
+// Predefined roles (e.g. pg_monitor) are skipped.
+func (i *inspect) roles(ctx context.Context, r *schema.Realm) error {
+	rows, err := i.QueryContext(ctx, rolesQuery)
+	if err != nil {
+		return fmt.Errorf("postgres: querying roles: %w", err)
+	}
+	names, err := sqlx.ScanStrings(rows)
+	if err != nil {
+		return fmt.Errorf("postgres: scanning roles: %w", err)
+	}
+	for _, name := range names {
+		r.Attrs = append(r.Attrs, &schema.Role{Name: name})
+	}
+	return nil
+}
+
 // tableNames returns a list of all tables exist in the schema.
 func (i *inspect) tableNames(ctx context.Context, schema string, opts *schema.InspectOptions) ([]string, error) {
 	query, args := tablesQuery, []interface{}{schema}
@@ -504,6 +1070,15 @@ func inStrings(s []string, query string, args []interface{}) (string, []interfac
 	return query, args
 }
 
+// placeholders returns n comma-separated positional parameters.
+func placeholders(n int) string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return strings.Join(p, ", ")
+}
+
 func defaultExpr(x string) schema.Expr {
 	for _, fn := range [...]string{"currval", "lastval", "setval", "nextval"} {
 		if strings.HasPrefix(x, fn) {
@@ -534,6 +1109,36 @@ type (
 		Values []string
 	}
 
+	// DomainType represents a domain type.
+	// https://www.postgresql.org/docs/current/domains.html
+	DomainType struct {
+		schema.Type
+		T       string      // Type name.
+		ID      int64       // Type id.
+		Base    schema.Type // Underlying type.
+		NotNull bool
+		Default schema.Expr
+		Checks  []*Check
+	}
+
+	// CompositeType represents a composite type.
+	// https://www.postgresql.org/docs/current/rowtypes.html
+	CompositeType struct {
+		schema.Type
+		T      string // Type name.
+		ID     int64  // Type id.
+		Fields []*schema.Column
+	}
+
+	// RangeType represents a range type.
+	// https://www.postgresql.org/docs/current/rangetypes.html
+	RangeType struct {
+		schema.Type
+		T       string // Type name.
+		ID      int64  // Type id.
+		Subtype schema.Type
+	}
+
 	// ArrayType defines an array type.
 	// https://www.postgresql.org/docs/current/arrays.html
 	ArrayType struct {
@@ -593,6 +1198,8 @@ type (
 	Identity struct {
 		schema.Attr
 		Generation string // ALWAYS, BY DEFAULT.
+		Start      int64  // Optional start value. Defaults to 1.
+		Increment  int64  // Optional increment value. Defaults to 1.
 	}
 
 	// IndexType represents an index type.
@@ -609,6 +1216,21 @@ type (
 		P string
 	}
 
+	// IndexInclude describes the non-key columns that are
+	// included in the index using the INCLUDE clause.
+	// https://www.postgresql.org/docs/current/sql-createindex.html
+	IndexInclude struct {
+		schema.Attr
+		Columns []*schema.Column
+	}
+
+	// IndexNullsDistinct describes the NULLS [NOT] DISTINCT option of a unique
+	// index or constraint. NULL values are considered distinct by default.
+	IndexNullsDistinct struct {
+		schema.Attr
+		V bool
+	}
+
 	// IndexColumnProperty describes an index column property.
 	// https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-INDEX-COLUMN-PROPS
 	IndexColumnProperty struct {
@@ -629,6 +1251,124 @@ type (
 		Columns   []string
 	}
 
+	// Partition defines the spec of a partitioned table.
+	// https://www.postgresql.org/docs/current/ddl-partitioning.html
+	Partition struct {
+		schema.Attr
+		// T defines the partitioning strategy: RANGE, LIST or HASH.
+		T string
+		// Parts holds the partition key, where each part
+		// is either a table column or an expression.
+		Parts []*PartitionPart
+		// Defs holds the partitions of the table.
+		Defs []*PartitionDef
+	}
+
+	// PartitionPart represents a part of a partition key.
+	PartitionPart struct {
+		C *schema.Column
+		X schema.Expr
+	}
+
+	// PartitionDef defines a partition of a table. In PostgreSQL,
+	// partitions are tables that are defined in the same schema as
+	// their parent table, and therefore, they are not inspected as
+	// standalone tables.
+	PartitionDef struct {
+		schema.Attr
+		Name string
+		// Bound holds the partition bound clause. For example,
+		// "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')" or "DEFAULT".
+		Bound string
+	}
+
+	// Extension describes a PostgreSQL extension that is installed in a schema.
+	// Extensions are defined as attributes of the schema their objects are
+	// installed in. https://www.postgresql.org/docs/current/sql-createextension.html
+	Extension struct {
+		schema.Attr
+		Name string
+		// Version of the extension. If empty, the default
+		// version of the extension is used on creation.
+		Version string
+	}
+
+	// Enum describes an enum type that is defined in a schema. Enums are inspected
+	// as schema attributes, in addition to the types of the columns that use them,
+	// to allow dropping enums that are no longer used by the desired schema.
+	Enum struct {
+		schema.Attr
+		T string
+	}
+
+	// RowSecurity describes the row-level security settings of a table.
+	// https://www.postgresql.org/docs/current/ddl-rowsecurity.html
+	RowSecurity struct {
+		schema.Attr
+		Enabled bool
+		// Force reports if the policies of the table
+		// are applied to the table owner as well.
+		Force bool
+	}
+
+	// Policy describes a row-level security policy of a table.
+	// https://www.postgresql.org/docs/current/sql-createpolicy.html
+	Policy struct {
+		schema.Attr
+		Name string
+		// As is either PERMISSIVE (the default) or RESTRICTIVE.
+		As string
+		// For holds the command the policy applies to: ALL
+		// (the default), SELECT, INSERT, UPDATE or DELETE.
+		For string
+		// To holds the roles the policy applies to. PUBLIC
+		// is used in case no roles were specified.
+		To []string
+		// Using and Check hold the USING and the WITH CHECK
+		// expressions of the policy. Both are optional.
+		Using, Check string
+	}
+
+	// Deferrable describes a DEFERRABLE constraint. For example, a foreign key or an
+	// exclusion constraint. https://www.postgresql.org/docs/current/sql-set-constraints.html
+	Deferrable struct {
+		schema.Attr
+		// InitiallyDeferred reports if the constraint is checked at the end
+		// of the transaction by default, instead of after each statement.
+		InitiallyDeferred bool
+	}
+
+	// MatchType describes the match type of a foreign key: SIMPLE (the default), FULL
+	// or PARTIAL. https://www.postgresql.org/docs/current/sql-createtable.html
+	MatchType struct {
+		schema.Attr
+		T string
+	}
+
+	// Exclude describes an exclusion constraint of a table.
+	// https://www.postgresql.org/docs/current/ddl-constraints.html#DDL-CONSTRAINTS-EXCLUSION
+	Exclude struct {
+		schema.Attr
+		Name string
+		// T holds the index method of the constraint (e.g. GiST).
+		// BTREE is used in case it was not set.
+		T     string
+		Elems []*ExcludeElem
+		// Where holds the predicate of a partial exclusion constraint.
+		Where string
+		// Attrs holds additional attributes of the
+		// constraint. For example, a Deferrable attribute.
+		Attrs []schema.Attr
+	}
+
+	// ExcludeElem represents an element of an exclusion constraint that is either a
+	// column or an expression, and the operator it is compared with (e.g. "&&").
+	ExcludeElem struct {
+		C  *schema.Column
+		X  schema.Expr
+		Op string
+	}
+
 	// SeqFuncExpr describe a sequence generator function.
 	// https://www.postgresql.org/docs/current/functions-sequence.html
 	SeqFuncExpr struct {
@@ -644,14 +1384,85 @@ const (
 	// Query to list database schemas.
 	schemasQuery = "SELECT schema_name FROM information_schema.schemata"
 
+	// Query to list the installed extensions and their schemas.
+	extensionsQuery = "SELECT t2.nspname AS schema_name, t1.extname AS name, t1.extversion AS version FROM pg_catalog.pg_extension AS t1 JOIN pg_catalog.pg_namespace AS t2 ON t1.extnamespace = t2.oid"
+
+	// Query to list the enum types that are defined in schemas.
+	schemaEnumsQuery = "SELECT t2.nspname AS schema_name, t1.typname AS name FROM pg_catalog.pg_type AS t1 JOIN pg_catalog.pg_namespace AS t2 ON t1.typnamespace = t2.oid WHERE t1.typtype = 'e' AND t2.nspname "
+
+	// Query to list the roles in the database cluster, except the predefined ones.
+	rolesQuery = "SELECT rolname FROM pg_catalog.pg_roles WHERE rolname !~ '^pg_' ORDER BY rolname"
+
+	// Query to list the privileges granted on a schema, its tables and their columns.
+	// Privileges that were granted to PUBLIC are returned with the grantee "PUBLIC".
+	grantsQuery = `
+SELECT
+	'' AS table_name,
+	'' AS column_name,
+	COALESCE(r.rolname, 'PUBLIC') AS grantee,
+	a.privilege_type,
+	CASE WHEN a.is_grantable THEN 'YES' ELSE 'NO' END AS is_grantable
+FROM
+	pg_catalog.pg_namespace AS n
+	CROSS JOIN LATERAL aclexplode(n.nspacl) AS a
+	LEFT JOIN pg_catalog.pg_roles AS r ON a.grantee = r.oid
+WHERE
+	n.nspname = $1
+	AND a.grantee <> n.nspowner
+UNION ALL
+SELECT
+	c.relname AS table_name,
+	'' AS column_name,
+	COALESCE(r.rolname, 'PUBLIC') AS grantee,
+	a.privilege_type,
+	CASE WHEN a.is_grantable THEN 'YES' ELSE 'NO' END AS is_grantable
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON c.relnamespace = n.oid
+	CROSS JOIN LATERAL aclexplode(c.relacl) AS a
+	LEFT JOIN pg_catalog.pg_roles AS r ON a.grantee = r.oid
+WHERE
+	n.nspname = $1
+	AND c.relkind IN ('r', 'p')
+	AND a.grantee <> c.relowner
+UNION ALL
+SELECT
+	c.relname AS table_name,
+	t.attname AS column_name,
+	COALESCE(r.rolname, 'PUBLIC') AS grantee,
+	a.privilege_type,
+	CASE WHEN a.is_grantable THEN 'YES' ELSE 'NO' END AS is_grantable
+FROM
+	pg_catalog.pg_attribute AS t
+	JOIN pg_catalog.pg_class AS c ON t.attrelid = c.oid
+	JOIN pg_catalog.pg_namespace AS n ON c.relnamespace = n.oid
+	CROSS JOIN LATERAL aclexplode(t.attacl) AS a
+	LEFT JOIN pg_catalog.pg_roles AS r ON a.grantee = r.oid
+WHERE
+	n.nspname = $1
+	AND c.relkind IN ('r', 'p')
+	AND t.attnum > 0
+	AND NOT t.attisdropped
+	AND a.grantee <> c.relowner
+ORDER BY
+	table_name,
+	column_name,
+	grantee,
+	privilege_type
+`
+
 	// Query to list schema tables.
-	tablesQuery = "SELECT table_name FROM information_schema.tables WHERE table_type = 'BASE TABLE' AND table_schema = $1 ORDER BY table_name"
+	// Partitions of partitioned tables are inspected as part of their parent table.
+	tablesQuery = "SELECT table_name FROM information_schema.tables WHERE table_type = 'BASE TABLE' AND table_schema = $1 AND table_name NOT IN (SELECT c.relname FROM pg_catalog.pg_class AS c JOIN pg_catalog.pg_namespace AS n ON c.relnamespace = n.oid WHERE c.relispartition AND n.nspname = $1) ORDER BY table_name"
 
 	// Query to list table information.
 	tableQuery = `
 SELECT
 	t1.table_schema,
-	pg_catalog.obj_description(t2.oid, 'pg_class') AS COMMENT
+	pg_catalog.obj_description(t2.oid, 'pg_class') AS COMMENT,
+	pg_catalog.pg_get_partkeydef(t2.oid) AS PARTITION_KEY,
+	t2.relrowsecurity AS ROW_SECURITY,
+	t2.relforcerowsecurity AS FORCE_ROW_SECURITY
 FROM
 	information_schema.tables AS t1
 	INNER JOIN pg_catalog.pg_class AS t2
@@ -664,7 +1475,10 @@ WHERE
 	tableSchemaQuery = `
 SELECT
 	t1.TABLE_SCHEMA,
-	pg_catalog.obj_description(t2.oid, 'pg_class') AS COMMENT
+	pg_catalog.obj_description(t2.oid, 'pg_class') AS COMMENT,
+	pg_catalog.pg_get_partkeydef(t2.oid) AS PARTITION_KEY,
+	t2.relrowsecurity AS ROW_SECURITY,
+	t2.relforcerowsecurity AS FORCE_ROW_SECURITY
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN pg_catalog.pg_class AS t2
@@ -691,17 +1505,99 @@ SELECT
 	t1.identity_generation,
 	col_description(to_regclass("table_schema" || '.' || "table_name")::oid, "ordinal_position") AS comment,
 	t2.typtype,
-	t2.oid
+	t2.oid,
+	t1.generation_expression,
+	t1.identity_start,
+	t1.identity_increment,
+	t1.domain_name
 FROM
 	"information_schema"."columns" AS t1
+	LEFT JOIN pg_catalog.pg_namespace AS t3
+	ON t3.nspname = COALESCE(t1.domain_schema, t1.udt_schema)
 	LEFT JOIN pg_catalog.pg_type AS t2
-	ON t1.udt_name = t2.typname
+	ON t2.typnamespace = t3.oid AND t2.typname = COALESCE(t1.domain_name, t1.udt_name)
 WHERE
 	TABLE_SCHEMA = $1 AND TABLE_NAME = $2
 `
 
-	// Query to list table indexes.
-	indexesQuery = `
+	// Query to list enum values and their schema. The
+	// placeholders are filled with the enum type ids.
+	enumsQuery = `
+SELECT
+	t1.enumtypid,
+	t1.enumlabel,
+	t3.nspname
+FROM
+	pg_catalog.pg_enum AS t1
+	JOIN pg_catalog.pg_type AS t2
+	ON t2.oid = t1.enumtypid
+	JOIN pg_catalog.pg_namespace AS t3
+	ON t3.oid = t2.typnamespace
+WHERE
+	t1.enumtypid IN (%s)
+ORDER BY
+	t1.enumtypid, t1.enumsortorder
+`
+
+	// Query to list domain definitions. The placeholders
+	// are filled with the domain type ids.
+	domainsQuery = `
+SELECT
+	t1.oid,
+	t1.typnotnull,
+	t1.typdefault,
+	t2.conname,
+	pg_get_expr(t2.conbin, 0) AS expression
+FROM
+	pg_catalog.pg_type AS t1
+	LEFT JOIN pg_catalog.pg_constraint AS t2
+	ON t2.contypid = t1.oid AND t2.contype = 'c'
+WHERE
+	t1.oid IN (%s)
+ORDER BY
+	t1.oid, t2.conname
+`
+
+	// Query to list the fields of composite types. The placeholders
+	// are filled with the composite type ids. Attributes are matched
+	// by both the type name and its namespace, as types with the same
+	// name may exist in different schemas.
+	compositesQuery = `
+SELECT
+	t2.oid,
+	t1.attribute_name,
+	t1.data_type,
+	t1.character_maximum_length,
+	t1.numeric_precision,
+	t1.numeric_scale,
+	t1.attribute_udt_name
+FROM
+	information_schema.attributes AS t1
+	JOIN pg_catalog.pg_namespace AS t3
+	ON t3.nspname = t1.udt_schema
+	JOIN pg_catalog.pg_type AS t2
+	ON t2.typnamespace = t3.oid AND t2.typname = t1.udt_name
+WHERE
+	t2.oid IN (%s)
+ORDER BY
+	t2.oid, t1.ordinal_position
+`
+
+	// Query to list the subtypes of range types. The
+	// placeholders are filled with the range type ids.
+	rangesQuery = `
+SELECT
+	t1.rngtypid,
+	format_type(t1.rngsubtype, NULL) AS subtype
+FROM
+	pg_catalog.pg_range AS t1
+WHERE
+	t1.rngtypid IN (%s)
+`
+
+	// Query to list table indexes. The placeholder is filled with the
+	// expression of the NULLS NOT DISTINCT option, that was added in v15.
+	indexesQueryTmpl = `
 SELECT
 	i.relname AS index_name,
 	am.amname AS index_type,
@@ -715,7 +1611,11 @@ SELECT
 	pg_index_column_has_property(idx.indexrelid, a.attnum, 'desc') AS desc,
 	pg_index_column_has_property(idx.indexrelid, a.attnum, 'nulls_first') AS nulls_first,
 	pg_index_column_has_property(idx.indexrelid, a.attnum, 'nulls_last') AS nulls_last,
-	obj_description(to_regclass($1 || i.relname)::oid) AS comment
+	obj_description(to_regclass($1 || i.relname)::oid) AS comment,
+	a.attnum > idx.indnkeyatts AS included,
+	%s AS nulls_not_distinct,
+	c.condeferrable AS deferrable,
+	c.condeferred AS initially_deferred
 FROM
 	pg_index idx
 	JOIN pg_class i
@@ -728,7 +1628,7 @@ FROM
 	ON am.oid = i.relam
 WHERE
 	idx.indrelid = to_regclass($1 || '.' || $2)::oid
-	AND COALESCE(c.contype, '') <> 'f'
+	AND COALESCE(c.contype, '') NOT IN ('f', 'x')
 ORDER BY
 	index_name, a.attnum
 `
@@ -763,6 +1663,83 @@ ORDER BY
     t2.ordinal_position
 `
 
+	// Query to list the DEFERRABLE and MATCH options of the table foreign keys.
+	fkAttrsQuery = `
+SELECT
+	t1.conname AS constraint_name,
+	t1.condeferrable AS deferrable,
+	t1.condeferred AS initially_deferred,
+	CASE t1.confmatchtype WHEN 'f' THEN 'FULL' WHEN 'p' THEN 'PARTIAL' ELSE 'SIMPLE' END AS match_type
+FROM
+	pg_catalog.pg_constraint AS t1
+WHERE
+	t1.contype = 'f'
+	AND t1.conrelid = to_regclass($1 || '.' || $2)::oid
+ORDER BY
+	t1.conname
+`
+
+	// Query to list the exclusion constraints of a table. A row is returned for each element
+	// of a constraint, with the column name or the expression, and its exclusion operator.
+	excludesQuery = `
+SELECT
+	t1.conname AS constraint_name,
+	t3.amname AS index_type,
+	pg_catalog.pg_get_indexdef(t1.conindid, t4.n, true) AS element,
+	t5.oprname AS operator,
+	pg_catalog.pg_get_expr(t6.indpred, t6.indrelid) AS predicate,
+	t1.condeferrable AS deferrable,
+	t1.condeferred AS initially_deferred
+FROM
+	pg_catalog.pg_constraint AS t1
+	JOIN pg_catalog.pg_class AS t2 ON t2.oid = t1.conindid
+	JOIN pg_catalog.pg_am AS t3 ON t3.oid = t2.relam
+	CROSS JOIN LATERAL generate_subscripts(t1.conexclop, 1) AS t4(n)
+	JOIN pg_catalog.pg_operator AS t5 ON t5.oid = t1.conexclop[t4.n]
+	JOIN pg_catalog.pg_index AS t6 ON t6.indexrelid = t1.conindid
+WHERE
+	t1.contype = 'x'
+	AND t1.conrelid = to_regclass($1 || '.' || $2)::oid
+ORDER BY
+	t1.conname, t4.n
+`
+
+	// Query to list the partitions of a partitioned table.
+	partitionsQuery = `
+SELECT
+	t2.relname AS partition_name,
+	pg_catalog.pg_get_expr(t2.relpartbound, t2.oid) AS partition_bound
+FROM
+	pg_catalog.pg_inherits AS t1
+	JOIN pg_catalog.pg_class AS t2
+	ON t1.inhrelid = t2.oid
+WHERE
+	t2.relispartition
+	AND t1.inhparent = to_regclass($1 || '.' || $2)::oid
+ORDER BY
+	t2.relname
+`
+
+	// Query to list the row-level security policies of a table. A row is
+	// returned for each role of a policy, and PUBLIC is returned for oid 0.
+	policiesQuery = `
+SELECT
+	t1.polname AS policy_name,
+	CASE WHEN t1.polpermissive THEN 'PERMISSIVE' ELSE 'RESTRICTIVE' END AS policy_as,
+	CASE t1.polcmd WHEN 'r' THEN 'SELECT' WHEN 'a' THEN 'INSERT' WHEN 'w' THEN 'UPDATE' WHEN 'd' THEN 'DELETE' ELSE 'ALL' END AS policy_for,
+	COALESCE(t3.rolname, 'PUBLIC') AS role_name,
+	pg_catalog.pg_get_expr(t1.polqual, t1.polrelid) AS policy_using,
+	pg_catalog.pg_get_expr(t1.polwithcheck, t1.polrelid) AS policy_check
+FROM
+	pg_catalog.pg_policy AS t1
+	CROSS JOIN LATERAL unnest(t1.polroles) AS t2(oid)
+	LEFT JOIN pg_catalog.pg_roles AS t3 ON t3.oid = t2.oid
+WHERE
+	t1.polrelid = to_regclass($1 || '.' || $2)::oid
+ORDER BY
+	t1.polname, role_name
+`
+
 	// Query to list table check constraints.
 	checksQuery = `
 SELECT
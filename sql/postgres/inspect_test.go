@@ -6,6 +6,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"ariga.io/atlas/sql/internal/sqltest"
@@ -57,46 +58,50 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.ExpectQuery(sqltest.Escape(columnsQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
- column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid  
--------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------
- id          | bigint              | NO          |                                 |                          |                64 |             0 |                    |                | int8     | NO          |                     |         | b       |    20
- rank        | integer             | YES         |                                 |                          |                32 |             0 |                    |                | int4     | NO          |                     | rank    | b       |    23
- c1          | smallint            | NO          |                                 |                          |                16 |             0 |                    |                | int2     | NO          |                     |         | b       |    21
- c2          | bit                 | NO          |                                 |                        1 |                   |               |                    |                | bit      | NO          |                     |         | b       |  1560
- c3          | bit varying         | NO          |                                 |                       10 |                   |               |                    |                | varbit   | NO          |                     |         | b       |  1562
- c4          | boolean             | NO          |                                 |                          |                   |               |                    |                | bool     | NO          |                     |         | b       |    16
- c5          | bytea               | NO          |                                 |                          |                   |               |                    |                | bytea    | NO          |                     |         | b       |    17
- c6          | character           | NO          |                                 |                      100 |                   |               |                    |                | bpchar   | NO          |                     |         | b       |  1042
- c7          | character varying   | NO          |                                 |                          |                   |               |                    |                | varchar  | NO          |                     |         | b       |  1043
- c8          | cidr                | NO          |                                 |                          |                   |               |                    |                | cidr     | NO          |                     |         | b       |   650
- c9          | circle              | NO          |                                 |                          |                   |               |                    |                | circle   | NO          |                     |         | b       |   718
- c10         | date                | NO          |                                 |                          |                   |               |                    |                | date     | NO          |                     |         | b       |  1082
- c11         | time with time zone | NO          |                                 |                          |                   |               |                    |                | timetz   | NO          |                     |         | b       |  1266
- c12         | double precision    | NO          |                                 |                          |                53 |               |                    |                | float8   | NO          |                     |         | b       |   701
- c13         | real                | NO          |                                 |                          |                24 |               |                    |                | float4   | NO          |                     |         | b       |   700
- c14         | json                | NO          |                                 |                          |                   |               |                    |                | json     | NO          |                     |         | b       |   114
- c15         | jsonb               | NO          |                                 |                          |                   |               |                    |                | jsonb    | NO          |                     |         | b       |  3802
- c16         | money               | NO          |                                 |                          |                   |               |                    |                | money    | NO          |                     |         | b       |   790
- c17         | numeric             | NO          |                                 |                          |                   |               |                    |                | numeric  | NO          |                     |         | b       |  1700
- c18         | numeric             | NO          |                                 |                          |                 4 |             4 |                    |                | numeric  | NO          |                     |         | b       |  1700
- c19         | integer             | NO          | nextval('t1_c19_seq'::regclass) |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |    23
- c20         | uuid                | NO          |                                 |                          |                   |               |                    |                | uuid     | NO          |                     |         | b       |  2950
- c21         | xml                 | NO          |                                 |                          |                   |               |                    |                | xml      | NO          |                     |         | b       |   142
- c22         | ARRAY               | YES         |                                 |                          |                   |               |                    |                | _int4    | NO          |                     |         | b       |  1007
- c23         | USER-DEFINED        | YES         |                                 |                          |                   |               |                    |                | ltree    | NO          |                     |         | b       | 16535
- c24         | USER-DEFINED        | NO          |                                 |                          |                   |               |                    |                | state    | NO          |                     |         | e       | 16774
+ column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid  | generation_expression | identity_start | identity_increment | domain_name
+-------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------+-----------------------+----------------+--------------------+-------------
+ id          | bigint              | NO          |                                 |                          |                64 |             0 |                    |                | int8     | NO          |                     |         | b       |    20 |                       |                |                    |
+ rank        | integer             | YES         |                                 |                          |                32 |             0 |                    |                | int4     | NO          |                     | rank    | b       |    23 |                       |                |                    |
+ c1          | smallint            | NO          |                                 |                          |                16 |             0 |                    |                | int2     | NO          |                     |         | b       |    21 |                       |                |                    |
+ c2          | bit                 | NO          |                                 |                        1 |                   |               |                    |                | bit      | NO          |                     |         | b       |  1560 |                       |                |                    |
+ c3          | bit varying         | NO          |                                 |                       10 |                   |               |                    |                | varbit   | NO          |                     |         | b       |  1562 |                       |                |                    |
+ c4          | boolean             | NO          |                                 |                          |                   |               |                    |                | bool     | NO          |                     |         | b       |    16 |                       |                |                    |
+ c5          | bytea               | NO          |                                 |                          |                   |               |                    |                | bytea    | NO          |                     |         | b       |    17 |                       |                |                    |
+ c6          | character           | NO          |                                 |                      100 |                   |               |                    |                | bpchar   | NO          |                     |         | b       |  1042 |                       |                |                    |
+ c7          | character varying   | NO          |                                 |                          |                   |               |                    |                | varchar  | NO          |                     |         | b       |  1043 |                       |                |                    |
+ c8          | cidr                | NO          |                                 |                          |                   |               |                    |                | cidr     | NO          |                     |         | b       |   650 |                       |                |                    |
+ c9          | circle              | NO          |                                 |                          |                   |               |                    |                | circle   | NO          |                     |         | b       |   718 |                       |                |                    |
+ c10         | date                | NO          |                                 |                          |                   |               |                    |                | date     | NO          |                     |         | b       |  1082 |                       |                |                    |
+ c11         | time with time zone | NO          |                                 |                          |                   |               |                    |                | timetz   | NO          |                     |         | b       |  1266 |                       |                |                    |
+ c12         | double precision    | NO          |                                 |                          |                53 |               |                    |                | float8   | NO          |                     |         | b       |   701 |                       |                |                    |
+ c13         | real                | NO          |                                 |                          |                24 |               |                    |                | float4   | NO          |                     |         | b       |   700 |                       |                |                    |
+ c14         | json                | NO          |                                 |                          |                   |               |                    |                | json     | NO          |                     |         | b       |   114 |                       |                |                    |
+ c15         | jsonb               | NO          |                                 |                          |                   |               |                    |                | jsonb    | NO          |                     |         | b       |  3802 |                       |                |                    |
+ c16         | money               | NO          |                                 |                          |                   |               |                    |                | money    | NO          |                     |         | b       |   790 |                       |                |                    |
+ c17         | numeric             | NO          |                                 |                          |                   |               |                    |                | numeric  | NO          |                     |         | b       |  1700 |                       |                |                    |
+ c18         | numeric             | NO          |                                 |                          |                 4 |             4 |                    |                | numeric  | NO          |                     |         | b       |  1700 |                       |                |                    |
+ c19         | integer             | NO          | nextval('t1_c19_seq'::regclass) |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |    23 |                       |                |                    |
+ c20         | uuid                | NO          |                                 |                          |                   |               |                    |                | uuid     | NO          |                     |         | b       |  2950 |                       |                |                    |
+ c21         | xml                 | NO          |                                 |                          |                   |               |                    |                | xml      | NO          |                     |         | b       |   142 |                       |                |                    |
+ c22         | ARRAY               | YES         |                                 |                          |                   |               |                    |                | _int4    | NO          |                     |         | b       |  1007 |                       |                |                    |
+ c23         | USER-DEFINED        | YES         |                                 |                          |                   |               |                    |                | ltree    | NO          |                     |         | b       | 16535 |                       |                |                    |
+ c24         | USER-DEFINED        | NO          |                                 |                          |                   |               |                    |                | state    | NO          |                     |         | e       | 16774 |                       |                |                    |
+ c25         | integer             | YES         |                                 |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |    23 | (c1 * 2)              |                |                    |
+ c26         | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int4     | YES         | ALWAYS              |         | b       |    23 |                       | 100            | 1                  |
 `))
-				m.ExpectQuery(sqltest.Escape(`SELECT enumtypid, enumlabel FROM pg_enum WHERE enumtypid IN ($1)`)).
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(enumsQuery, "$1"))).
 					WithArgs(16774).
 					WillReturnRows(sqltest.Rows(`
- enumtypid | enumlabel
------------+-----------
-     16774 | on
-     16774 | off
+ enumtypid | enumlabel | nspname
+-----------+-----------+---------
+     16774 | on        | public
+     16774 | off       | public
 `))
 				m.noIndexes()
 				m.noFKs()
 				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
@@ -127,7 +132,9 @@ func TestDriver_InspectTable(t *testing.T) {
 					{Name: "c21", Type: &schema.ColumnType{Raw: "xml", Type: &XMLType{T: "xml"}}},
 					{Name: "c22", Type: &schema.ColumnType{Raw: "ARRAY", Null: true, Type: &ArrayType{T: "int4[]"}}},
 					{Name: "c23", Type: &schema.ColumnType{Raw: "USER-DEFINED", Null: true, Type: &UserDefinedType{T: "ltree"}}},
-					{Name: "c24", Type: &schema.ColumnType{Raw: "state", Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+					{Name: "c24", Type: &schema.ColumnType{Raw: "state", Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}, Schema: &schema.Schema{Name: "public"}}}},
+					{Name: "c25", Type: &schema.ColumnType{Raw: "integer", Null: true, Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(c1 * 2)", Type: "STORED"}}},
+					{Name: "c26", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Start: 100, Increment: 1}}},
 				}, t.Columns)
 			},
 		},
@@ -139,16 +146,16 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.ExpectQuery(sqltest.Escape(columnsQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
- column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid
--------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------
- id          | bigint              | NO          |                                 |                          |                64 |             0 |                    |                | int8     | NO          |                     |         | b       |    20
- c1          | smallint            | NO          |                                 |                          |                16 |             0 |                    |                | int2     | NO          |                     |         | b       |    21
+ column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid  | generation_expression | identity_start | identity_increment | domain_name
+-------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------+-----------------------+----------------+--------------------+-------------
+ id          | bigint              | NO          |                                 |                          |                64 |             0 |                    |                | int8     | NO          |                     |         | b       |    20 |                       |                |                    |
+ c1          | smallint            | NO          |                                 |                          |                16 |             0 |                    |                | int2     | NO          |                     |         | b       |    21 |                       |                |                    |
 `))
 				m.ExpectQuery(sqltest.Escape(indexesQuery)).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-    index_name   | index_type  | column_name | primary | unique | constraint_type | predicate             |   expression              | asc | desc | nulls_first | nulls_last | comment
------------------+-------------+-------------+---------+--------+-----------------+-----------------------+---------------------------+-----+------+-------------+------------+-----------
+    index_name   | index_type  | column_name | primary | unique | constraint_type | predicate             |   expression              | asc | desc | nulls_first | nulls_last | comment   | included | nulls_not_distinct | deferrable | initially_deferred
+-----------------+-------------+-------------+---------+--------+-----------------+-----------------------+---------------------------+-----+------+-------------+------------+-----------+----------+--------------------+------------+--------------------
  idx             | hash        | left        | f       | f      |                 |                       | "left"((c11)::text, 100)  | f   | t    | t           | f          | boring
  idx1            | btree       | left        | f       | f      |                 | (id <> NULL::integer) | "left"((c11)::text, 100)  | f   | t    | t           | f          |
  t1_c1_key       | btree       | c1          | f       | t      | u               |                       |                           | f   | t    | t           | f          |
@@ -159,6 +166,8 @@ func TestDriver_InspectTable(t *testing.T) {
 `))
 				m.noFKs()
 				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
@@ -187,6 +196,58 @@ func TestDriver_InspectTable(t *testing.T) {
 				require.EqualValues(pk, t.PrimaryKey)
 			},
 		},
+		{
+			name: "unique constraints",
+			before: func(m mock) {
+				m.version("150000")
+				m.tableExists("public", "users", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid  | generation_expression | identity_start | identity_increment | domain_name
+-------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------+-----------------------+----------------+--------------------+-------------
+ id          | bigint              | NO          |                                 |                          |                64 |             0 |                    |                | int8     | NO          |                     |         | b       |    20 |                       |                |                    |
+ c1          | smallint            | NO          |                                 |                          |                16 |             0 |                    |                | int2     | NO          |                     |         | b       |    21 |                       |                |                    |
+`))
+				m.ExpectQuery(sqltest.Escape(indexesQueryNullsDistinct)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+    index_name   | index_type  | column_name | primary | unique | constraint_type | predicate | expression | asc | desc | nulls_first | nulls_last | comment | included | nulls_not_distinct | deferrable | initially_deferred
+-----------------+-------------+-------------+---------+--------+-----------------+-----------+------------+-----+------+-------------+------------+---------+----------+--------------------+------------+--------------------
+ users_c1_key    | btree       | c1          | f       | t      | u               |           |            | t   | f    | f           | t          |         | f        | t                  | t          | t
+ users_c1_key    | btree       | id          | f       | t      | u               |           |            |     |      |             |            |         | t        | t                  | t          | t
+ users_c1_idx    | btree       | c1          | f       | t      |                 |           |            | t   | f    | f           | t          |         | f        | f                  |            |
+ users_c1_idx    | btree       | id          | f       | t      |                 |           |            |     |      |             |            |         | t        | f                  |            |
+`))
+				m.noFKs()
+				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				columns := t.Columns
+				indexes := []*schema.Index{
+					{
+						Name:   "users_c1_key",
+						Unique: true,
+						Table:  t,
+						Attrs:  []schema.Attr{&IndexType{T: "btree"}, &ConType{T: "u"}, &IndexNullsDistinct{V: false}, &Deferrable{InitiallyDeferred: true}, &IndexInclude{Columns: columns[:1]}},
+						Parts:  []*schema.IndexPart{{SeqNo: 1, C: columns[1], Attrs: []schema.Attr{&IndexColumnProperty{Asc: true, NullsLast: true}}}},
+					},
+					{
+						Name:   "users_c1_idx",
+						Unique: true,
+						Table:  t,
+						Attrs:  []schema.Attr{&IndexType{T: "btree"}, &IndexInclude{Columns: columns[:1]}},
+						Parts:  []*schema.IndexPart{{SeqNo: 1, C: columns[1], Attrs: []schema.Attr{&IndexColumnProperty{Asc: true, NullsLast: true}}}},
+					},
+				}
+				require.EqualValues(indexes, t.Indexes)
+				require.True(uniqueConstraint(t.Indexes[0]))
+				require.False(uniqueConstraint(t.Indexes[1]))
+			},
+		},
 		{
 			name: "fks",
 			before: func(m mock) {
@@ -196,11 +257,11 @@ func TestDriver_InspectTable(t *testing.T) {
 					WithArgs("public", "users").
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
- column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid
--------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------
- id          | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    20
- oid         | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    21
- uid         | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    21
+ column_name |      data_type      | is_nullable |         column_default          | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype |  oid  | generation_expression | identity_start | identity_increment | domain_name
+-------------+---------------------+-------------+---------------------------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-------+-----------------------+----------------+--------------------+-------------
+ id          | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    20 |                       |                |                    |
+ oid         | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    21 |                       |                |                    |
+ uid         | integer             | NO          |                                 |                          |                32 |             0 |                    |                | int      | NO          |                     |         | b       |    21 |                       |                |                    |
 `))
 				m.noIndexes()
 				m.ExpectQuery(sqltest.Escape(fksQuery)).
@@ -214,15 +275,25 @@ func TestDriver_InspectTable(t *testing.T) {
  multi_column    | users      | oid         | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE
  self_reference  | users      | uid         | public       | users                 | id                     | public                 | NO ACTION   | CASCADE
 
+`))
+				m.ExpectQuery(sqltest.Escape(fkAttrsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ constraint_name | deferrable | initially_deferred | match_type
+-----------------+------------+--------------------+------------
+ multi_column    | t          | t                  | FULL
+ self_reference  | f          | f                  | SIMPLE
 `))
 				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
 				require.Equal("users", t.Name)
 				require.Equal("public", t.Schema.Name)
 				fks := []*schema.ForeignKey{
-					{Symbol: "multi_column", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}}, RefColumns: []*schema.Column{{Name: "gid"}, {Name: "xid"}}},
+					{Symbol: "multi_column", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "t1", Schema: &schema.Schema{Name: "public"}}, RefColumns: []*schema.Column{{Name: "gid"}, {Name: "xid"}}, Attrs: []schema.Attr{&Deferrable{InitiallyDeferred: true}, &MatchType{T: "FULL"}}},
 					{Symbol: "self_reference", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: t},
 				}
 				columns := []*schema.Column{
@@ -246,11 +317,11 @@ func TestDriver_InspectTable(t *testing.T) {
 					WithArgs("public", "users").
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
- column_name | data_type | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype | oid
--------------+-----------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-----
- c1          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23
- c2          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23
- c3          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23
+ column_name | data_type | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype | oid | generation_expression | identity_start | identity_increment | domain_name
+-------------+-----------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-----+-----------------------+----------------+--------------------+-------------
+ c1          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23 |                       |                |                    |
+ c2          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23 |                       |                |                    |
+ c3          | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23 |                       |                |                    |
 `))
 				m.noIndexes()
 				m.noFKs()
@@ -268,7 +339,8 @@ func TestDriver_InspectTable(t *testing.T) {
  users_check1       | (((c2 + c1) + c3) > 10) | c1          | {2,1,3}        | f
  users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1,3}        | f
 `))
-				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
@@ -288,6 +360,212 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.Attrs)
 			},
 		},
+		{
+			name: "exclusion constraints",
+			before: func(m mock) {
+				m.version("130000")
+				m.tableExists("public", "users", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ column_name | data_type | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype | oid | generation_expression | identity_start | identity_increment | domain_name
+-------------+-----------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-----+-----------------------+----------------+--------------------+-------------
+ room        | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23 |                       |                |                    |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				m.ExpectQuery(sqltest.Escape(excludesQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ constraint_name | index_type |        element         | operator |  predicate   | deferrable | initially_deferred
+-----------------+------------+------------------------+----------+--------------+------------+--------------------
+ no_overlap      | gist       | room                   | =        | (room > 0)   | t          | f
+ no_overlap      | gist       | tsrange(start_at, end_at) | &&    | (room > 0)   | t          | f
+`))
+				m.noPolicies()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]schema.Attr{
+					&Exclude{
+						Name: "no_overlap",
+						T:    "gist",
+						Elems: []*ExcludeElem{
+							{C: t.Columns[0], Op: "="},
+							{X: &schema.RawExpr{X: "tsrange(start_at, end_at)"}, Op: "&&"},
+						},
+						Where: "(room > 0)",
+						Attrs: []schema.Attr{&Deferrable{}},
+					},
+				}, t.Attrs)
+			},
+		},
+		{
+			name: "partitions",
+			before: func(m mock) {
+				m.version("130000")
+				m.ExpectQuery(sqltest.Escape(tableQuery)).
+					WithArgs("users").
+					WillReturnRows(sqltest.Rows(`
+ table_schema | table_comment |          partition_key          | row_security | force_row_security
+--------------+---------------+---------------------------------+--------------+--------------------
+ public       |               | RANGE (created_at, lower(name)) | f            | f
+`))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ column_name | data_type | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype | oid | generation_expression | identity_start | identity_increment | domain_name
+-------------+-----------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-----+-----------------------+----------------+--------------------+-------------
+ created_at  | date      | NO          |                |                          |                   |               |                    |                | date     | NO          |                     |         | b       | 1082 |                      |                |                    |
+ name        | text      | NO          |                |                          |                   |               |                    |                | text     | NO          |                     |         | b       |  25 |                       |                |                    |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				m.noExcludes()
+				m.ExpectQuery(sqltest.Escape(partitionsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ partition_name |                      partition_bound
+----------------+------------------------------------------------------------
+ users_2021_01  | FOR VALUES FROM ('2021-01-01', 'a') TO ('2021-02-01', 'a')
+ users_default  | DEFAULT
+`))
+				m.noPolicies()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]schema.Attr{
+					&Partition{
+						T: "RANGE",
+						Parts: []*PartitionPart{
+							{C: t.Columns[0]},
+							{X: &schema.RawExpr{X: "lower(name)"}},
+						},
+						Defs: []*PartitionDef{
+							{Name: "users_2021_01", Bound: "FOR VALUES FROM ('2021-01-01', 'a') TO ('2021-02-01', 'a')"},
+							{Name: "users_default", Bound: "DEFAULT"},
+						},
+					},
+				}, t.Attrs)
+			},
+		},
+		{
+			name: "policies",
+			before: func(m mock) {
+				m.version("130000")
+				m.ExpectQuery(sqltest.Escape(tableQuery)).
+					WithArgs("users").
+					WillReturnRows(sqltest.Rows(`
+ table_schema | table_comment | partition_key | row_security | force_row_security
+--------------+---------------+---------------+--------------+--------------------
+ public       |               |               | t            | f
+`))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ column_name | data_type | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name | udt_name | is_identity | identity_generation | comment | typtype | oid | generation_expression | identity_start | identity_increment | domain_name
+-------------+-----------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+----------+-------------+---------------------+---------+---------+-----+-----------------------+----------------+--------------------+-------------
+ tenant_id   | integer   | NO          |                |                          |                32 |             0 |                    |                | int4     | NO          |                     |         | b       |  23 |                       |                |                    |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				m.noExcludes()
+				m.ExpectQuery(sqltest.Escape(policiesQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ policy_name | policy_as   | policy_for | role_name | policy_using                                                   | policy_check
+-------------+-------------+------------+-----------+----------------------------------------------------------------+--------------
+ admins      | RESTRICTIVE | DELETE     | PUBLIC    |                                                                |
+ tenant      | PERMISSIVE  | ALL        | app       | (tenant_id = (current_setting('app.tenant'::text))::integer)   | (tenant_id > 0)
+ tenant      | PERMISSIVE  | ALL        | reporter  | (tenant_id = (current_setting('app.tenant'::text))::integer)   | (tenant_id > 0)
+`))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]schema.Attr{
+					&RowSecurity{Enabled: true},
+					&Policy{Name: "admins", As: "RESTRICTIVE", For: "DELETE", To: []string{"PUBLIC"}},
+					&Policy{Name: "tenant", As: "PERMISSIVE", For: "ALL", To: []string{"app", "reporter"}, Using: "(tenant_id = (current_setting('app.tenant'::text))::integer)", Check: "(tenant_id > 0)"},
+				}, t.Attrs)
+			},
+		},
+		{
+			name: "user-defined types",
+			before: func(m mock) {
+				m.version("130000")
+				m.tableExists("public", "users", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ column_name |  data_type   | is_nullable | column_default | character_maximum_length | numeric_precision | numeric_scale | character_set_name | collation_name |  udt_name  | is_identity | identity_generation | comment | typtype |  oid  | generation_expression | identity_start | identity_increment | domain_name
+-------------+--------------+-------------+----------------+--------------------------+-------------------+---------------+--------------------+----------------+------------+-------------+---------------------+---------+---------+-------+-----------------------+----------------+--------------------+-------------
+ c1          | integer      | NO          |                |                          |                32 |             0 |                    |                | int4       | NO          |                     |         | d       | 16390 |                       |                |                    | posint
+ c2          | integer      | NO          |                |                          |                32 |             0 |                    |                | int4       | NO          |                     |         | d       | 16390 |                       |                |                    | posint
+ c3          | USER-DEFINED | NO          |                |                          |                   |               |                    |                | address    | NO          |                     |         | c       | 16400 |                       |                |                    |
+ c4          | USER-DEFINED | NO          |                |                          |                   |               |                    |                | floatrange | NO          |                     |         | r       | 16410 |                       |                |                    |
+`))
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(domainsQuery, "$1"))).
+					WithArgs(16390).
+					WillReturnRows(sqltest.Rows(`
+  oid  | typnotnull | typdefault |   conname   |  expression
+-------+------------+------------+-------------+---------------
+ 16390 | t          | 1          | positive    | (VALUE > 0)
+ 16390 | t          | 1          | under_limit | (VALUE < 100)
+`))
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(compositesQuery, "$1"))).
+					WithArgs(16400).
+					WillReturnRows(sqltest.Rows(`
+  oid  | attribute_name |     data_type     | character_maximum_length | numeric_precision | numeric_scale | attribute_udt_name
+-------+----------------+-------------------+--------------------------+-------------------+---------------+--------------------
+ 16400 | street         | character varying |                      255 |                   |               | varchar
+ 16400 | zip            | integer           |                          |                32 |             0 | int4
+`))
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(rangesQuery, "$1"))).
+					WithArgs(16410).
+					WillReturnRows(sqltest.Rows(`
+ rngtypid |     subtype
+----------+------------------
+    16410 | double precision
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				m.noExcludes()
+				m.noPolicies()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				domain := func() *DomainType {
+					return &DomainType{
+						T:       "posint",
+						ID:      16390,
+						Base:    &schema.IntegerType{T: "integer"},
+						NotNull: true,
+						Default: &schema.RawExpr{X: "1"},
+						Checks: []*Check{
+							{Name: "positive", Clause: "(VALUE > 0)"},
+							{Name: "under_limit", Clause: "(VALUE < 100)"},
+						},
+					}
+				}
+				require.EqualValues([]*schema.Column{
+					{Name: "c1", Type: &schema.ColumnType{Raw: "posint", Type: domain()}},
+					{Name: "c2", Type: &schema.ColumnType{Raw: "posint", Type: domain()}},
+					{Name: "c3", Type: &schema.ColumnType{Raw: "USER-DEFINED", Type: &CompositeType{
+						T:  "address",
+						ID: 16400,
+						Fields: []*schema.Column{
+							{Name: "street", Type: &schema.ColumnType{Raw: "character varying", Type: &schema.StringType{T: "character varying", Size: 255}, Null: true}},
+							{Name: "zip", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}, Null: true}},
+						},
+					}}},
+					{Name: "c4", Type: &schema.ColumnType{Raw: "USER-DEFINED", Type: &RangeType{T: "floatrange", ID: 16410, Subtype: &schema.FloatType{T: "double precision"}}}},
+				}, t.Columns)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -315,9 +593,35 @@ func TestDriver_Realm(t *testing.T) {
 --------------------
  test
  public
+`))
+	mk.ExpectQuery(sqltest.Escape(extensionsQuery+" WHERE t2.nspname IN ($1, $2) ORDER BY t1.extname")).
+		WithArgs("test", "public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name |   name    | version
+-------------+-----------+---------
+ public      | citext    | 1.6
+ public      | uuid-ossp | 1.1
+`))
+	mk.ExpectQuery(sqltest.Escape(schemaEnumsQuery+"IN ($1, $2) ORDER BY t1.typname")).
+		WithArgs("test", "public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | name
+-------------+------
+ public      | mood
 `))
 	mk.tables("test")
+	mk.grants("test")
 	mk.tables("public")
+	mk.ExpectQuery(sqltest.Escape(grantsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_name | column_name | grantee | privilege_type | is_grantable
+------------+-------------+---------+----------------+--------------
+        nil | nil         | PUBLIC  | USAGE          | NO
+        nil | nil         | app     | CREATE         | YES
+        nil | nil         | app     | USAGE          | YES
+`))
+	mk.roles("app", "reporter")
 	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -328,6 +632,13 @@ func TestDriver_Realm(t *testing.T) {
 				},
 				{
 					Name: "public",
+					Attrs: []schema.Attr{
+						&Extension{Name: "citext", Version: "1.6"},
+						&Extension{Name: "uuid-ossp", Version: "1.1"},
+						&Enum{T: "mood"},
+						&schema.Grant{Grantee: "PUBLIC", Privileges: []string{"USAGE"}},
+						&schema.Grant{Grantee: "app", Privileges: []string{"CREATE", "USAGE"}, GrantOption: true},
+					},
 				},
 			},
 			// Server default configuration.
@@ -338,6 +649,8 @@ func TestDriver_Realm(t *testing.T) {
 				&CType{
 					V: "en_US.utf8",
 				},
+				&schema.Role{Name: "app"},
+				&schema.Role{Name: "reporter"},
 			},
 		}
 		r.Schemas[0].Realm = r
@@ -352,9 +665,24 @@ func TestDriver_Realm(t *testing.T) {
 --------------------
  test
  public
+`))
+	mk.ExpectQuery(sqltest.Escape(extensionsQuery+" WHERE t2.nspname IN ($1, $2) ORDER BY t1.extname")).
+		WithArgs("test", "public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | name | version
+-------------+------+---------
+`))
+	mk.ExpectQuery(sqltest.Escape(schemaEnumsQuery+"IN ($1, $2) ORDER BY t1.typname")).
+		WithArgs("test", "public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | name
+-------------+------
 `))
 	mk.tables("test")
+	mk.grants("test")
 	mk.tables("public")
+	mk.grants("public")
+	mk.roles()
 	realm, err = drv.InspectRealm(context.Background(), &schema.InspectRealmOption{Schemas: []string{"test", "public"}})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -383,6 +711,20 @@ func TestDriver_Realm(t *testing.T) {
 	}(), realm)
 }
 
+func TestConn_SupportsNullsDistinct(t *testing.T) {
+	for v, ok := range map[string]bool{
+		"10.00.00": false,
+		"14.05.00": false,
+		"15.00.00": true,
+		"15.00.02": true,
+		"16.01.00": true,
+	} {
+		c := conn{version: v}
+		require.Equal(t, ok, c.supportsNullsDistinct(), v)
+	}
+	require.Equal(t, "v15.0.2", conn{version: "15.00.02"}.semVersion())
+}
+
 type mock struct {
 	sqlmock.Sqlmock
 }
@@ -399,9 +741,9 @@ func (m mock) version(version string) {
 }
 
 func (m mock) tableExists(schema, table string, exists bool) {
-	rows := sqlmock.NewRows([]string{"table_schema", "table_comment"})
+	rows := sqlmock.NewRows([]string{"table_schema", "table_comment", "partition_key", "row_security", "force_row_security"})
 	if exists {
-		rows.AddRow(schema, nil)
+		rows.AddRow(schema, nil, nil, false, false)
 	}
 	m.ExpectQuery(sqltest.Escape(tableQuery)).
 		WithArgs(table).
@@ -409,9 +751,9 @@ func (m mock) tableExists(schema, table string, exists bool) {
 }
 
 func (m mock) tableExistsInSchema(schema, table string, exists bool) {
-	rows := sqlmock.NewRows([]string{"table_schema", "table_comment"})
+	rows := sqlmock.NewRows([]string{"table_schema", "table_comment", "partition_key", "row_security", "force_row_security"})
 	if exists {
-		rows.AddRow(schema, nil)
+		rows.AddRow(schema, nil, nil, false, false)
 	}
 	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
 		WithArgs(table, schema).
@@ -433,6 +775,31 @@ func (m mock) noChecks() {
 		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "expression", "column_name", "column_indexes"}))
 }
 
+func (m mock) noExcludes() {
+	m.ExpectQuery(sqltest.Escape(excludesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "index_type", "element", "operator", "predicate", "deferrable", "initially_deferred"}))
+}
+
+func (m mock) noPolicies() {
+	m.ExpectQuery(sqltest.Escape(policiesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"policy_name", "policy_as", "policy_for", "role_name", "policy_using", "policy_check"}))
+}
+
+func (m mock) grants(schema string) {
+	m.ExpectQuery(sqltest.Escape(grantsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "column_name", "grantee", "privilege_type", "is_grantable"}))
+}
+
+func (m mock) roles(names ...string) {
+	rows := sqlmock.NewRows([]string{"rolname"})
+	for i := range names {
+		rows.AddRow(names[i])
+	}
+	m.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(rows)
+}
+
 func (m mock) tables(schema string, names ...string) {
 	rows := sqlmock.NewRows([]string{"table_name"})
 	for i := range names {
@@ -6,7 +6,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"ariga.io/atlas/sql/internal/sqlx"
@@ -14,11 +16,23 @@ import (
 )
 
 // A migrate provides migration capabilities for schema elements.
-type migrate struct{ conn }
+type migrate struct {
+	conn
+	// created holds the names of the schemas that are
+	// created by the changes that are being executed.
+	created map[string]bool
+	// empty indicates that the changes are executed on an
+	// empty database, and objects do not exist in it yet.
+	empty bool
+	// types holds the types that were created by the executed
+	// changes, keyed by their schema and name.
+	types map[string]bool
+}
 
 // Exec executes the changes on the database. An error is returned
 // if one of the operations fail, or a change is not supported.
 func (m *migrate) Exec(ctx context.Context, changes []schema.Change) error {
+	m = &migrate{conn: m.conn, created: make(map[string]bool), types: make(map[string]bool)}
 	planned, err := m.topLevel(ctx, changes)
 	if err != nil {
 		return err
@@ -27,6 +41,15 @@ func (m *migrate) Exec(ctx context.Context, changes []schema.Change) error {
 	if err != nil {
 		return err
 	}
+	// Enum types can be shared by multiple columns and tables.
+	// Therefore, they are altered once before the tables are modified.
+	if err := m.alterEnums(ctx, planned); err != nil {
+		return err
+	}
+	// The same applies for domain, composite and range types.
+	if err := m.alterUserTypes(ctx, planned); err != nil {
+		return err
+	}
 	for _, c := range planned {
 		switch c := c.(type) {
 		case *schema.AddTable:
@@ -42,7 +65,12 @@ func (m *migrate) Exec(ctx context.Context, changes []schema.Change) error {
 			return err
 		}
 	}
-	return nil
+	if err := m.dropEnums(ctx, changes); err != nil {
+		return err
+	}
+	// Extensions are dropped after the tables
+	// and the types that may depend on them.
+	return m.dropExtensions(ctx, changes)
 }
 
 // topLevel executes first the changes for creating or dropping schemas (top-level schema elements).
@@ -51,17 +79,38 @@ func (m *migrate) topLevel(ctx context.Context, changes []schema.Change) ([]sche
 	for _, c := range changes {
 		switch c := c.(type) {
 		case *schema.AddSchema:
-			b := Build("CREATE SCHEMA").Ident(c.S.Name)
+			b := Build("CREATE SCHEMA")
 			if sqlx.Has(c.Extra, &schema.IfNotExists{}) {
 				b.P("IF NOT EXISTS")
 			}
+			b.Ident(c.S.Name)
 			if _, err := m.ExecContext(ctx, b.String()); err != nil {
 				return nil, fmt.Errorf("add schema: %w", err)
 			}
+			// A schema that is created with the IF NOT EXISTS clause may
+			// already exist, unless the database is known to be empty.
+			if sqlx.Has(c.Extra, &schema.Empty{}) {
+				m.empty = true
+			}
+			if m.empty || !sqlx.Has(c.Extra, &schema.IfNotExists{}) {
+				m.created[c.S.Name] = true
+			}
+			for _, e := range extensions(c.S.Attrs) {
+				if err := m.addExtension(ctx, c.S, e); err != nil {
+					return nil, err
+				}
+			}
+			if err := m.grants(ctx, c.S, nil); err != nil {
+				return nil, err
+			}
 		case *schema.DropSchema:
 			if _, err := m.ExecContext(ctx, Build("DROP SCHEMA").Ident(c.S.Name).String()); err != nil {
 				return nil, fmt.Errorf("drop schema: %w", err)
 			}
+		case *schema.ModifySchema:
+			if err := m.modifySchema(ctx, c); err != nil {
+				return nil, err
+			}
 		default:
 			planned = append(planned, c)
 		}
@@ -69,16 +118,97 @@ func (m *migrate) topLevel(ctx context.Context, changes []schema.Change) ([]sche
 	return planned, nil
 }
 
+// modifySchema executes the changes of the schema attributes, except dropping extensions
+// and enums that is executed after the tables that depend on them were migrated.
+func (m *migrate) modifySchema(ctx context.Context, modify *schema.ModifySchema) error {
+	for _, c := range modify.Changes {
+		if sqlx.IsGrantChange(c) {
+			if err := m.grant(ctx, modify.S, nil, c); err != nil {
+				return err
+			}
+			continue
+		}
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			switch a := c.A.(type) {
+			case *Extension:
+				if err := m.addExtension(ctx, modify.S, a); err != nil {
+					return err
+				}
+			case *schema.Role:
+				if _, err := m.ExecContext(ctx, Build("CREATE ROLE").Ident(a.Name).String()); err != nil {
+					return fmt.Errorf("create role: %w", err)
+				}
+			default:
+				return fmt.Errorf("unsupported schema attribute: %T", c.A)
+			}
+		case *schema.ModifyAttr:
+			e, ok := c.To.(*Extension)
+			if !ok {
+				return fmt.Errorf("unsupported schema attribute: %T", c.To)
+			}
+			b := Build("ALTER EXTENSION").Ident(e.Name).P("UPDATE TO").Lit(e.Version)
+			if _, err := m.ExecContext(ctx, b.String()); err != nil {
+				return fmt.Errorf("update extension: %w", err)
+			}
+		case *schema.DropAttr:
+			switch c.A.(type) {
+			case *Extension, *Enum:
+			default:
+				return fmt.Errorf("unsupported schema attribute: %T", c.A)
+			}
+		default:
+			return fmt.Errorf("unsupported schema change: %T", c)
+		}
+	}
+	return nil
+}
+
+// addExtension creates the given extension in the schema.
+func (m *migrate) addExtension(ctx context.Context, s *schema.Schema, e *Extension) error {
+	b := Build("CREATE EXTENSION").Ident(e.Name).P("WITH SCHEMA").Ident(s.Name)
+	if e.Version != "" {
+		b.P("VERSION").Lit(e.Version)
+	}
+	if _, err := m.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("create extension: %w", err)
+	}
+	return nil
+}
+
+// dropExtensions drops the extensions that were removed from their schemas.
+func (m *migrate) dropExtensions(ctx context.Context, changes []schema.Change) error {
+	for _, c := range changes {
+		modify, ok := c.(*schema.ModifySchema)
+		if !ok {
+			continue
+		}
+		for _, c := range modify.Changes {
+			drop, ok := c.(*schema.DropAttr)
+			if !ok {
+				continue
+			}
+			if e, ok := drop.A.(*Extension); ok {
+				if _, err := m.ExecContext(ctx, Build("DROP EXTENSION").Ident(e.Name).String()); err != nil {
+					return fmt.Errorf("drop extension: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // addTable builds and executes the query for creating a table in a schema.
 func (m *migrate) addTable(ctx context.Context, add *schema.AddTable) error {
-	// Create enum types before using them in the `CREATE TABLE` statement.
-	if err := m.addTypes(ctx, add.T.Columns...); err != nil {
+	// Create user-defined types before using them in the `CREATE TABLE` statement.
+	if err := m.addTypes(ctx, add.T, add.T.Columns...); err != nil {
 		return err
 	}
-	b := Build("CREATE TABLE").Table(add.T)
+	b := Build("CREATE TABLE")
 	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
 		b.P("IF NOT EXISTS")
 	}
+	b.Table(add.T)
 	b.Wrap(func(b *sqlx.Builder) {
 		b.MapComma(add.T.Columns, func(i int, b *sqlx.Builder) {
 			m.column(b, add.T.Columns[i])
@@ -91,14 +221,42 @@ func (m *migrate) addTable(ctx context.Context, add *schema.AddTable) error {
 			b.Comma()
 			m.fks(b, add.T.ForeignKeys...)
 		}
+		for _, idx := range add.T.Indexes {
+			if uniqueConstraint(idx) {
+				b.Comma()
+				m.unique(b, idx)
+			}
+		}
+		for _, e := range excludes(add.T.Attrs) {
+			b.Comma()
+			m.exclude(b, e)
+		}
 	})
+	p := partition(add.T.Attrs)
+	if p != nil {
+		b.P("PARTITION BY", p.T)
+		m.partitionParts(b, p.Parts)
+	}
 	if _, err := m.ExecContext(ctx, b.String()); err != nil {
 		return fmt.Errorf("create table: %w", err)
 	}
+	if p != nil {
+		for _, d := range p.Defs {
+			if err := m.addPartition(ctx, add.T, d); err != nil {
+				return err
+			}
+		}
+	}
 	if err := m.addIndexes(ctx, add.T, add.T.Indexes...); err != nil {
 		return err
 	}
-	return m.addComments(ctx, add.T)
+	if err := m.addComments(ctx, add.T); err != nil {
+		return err
+	}
+	if err := m.grants(ctx, add.T.Schema, add.T); err != nil {
+		return err
+	}
+	return m.addPolicies(ctx, add.T)
 }
 
 // dropTable builds and executes the query for dropping a table from a schema.
@@ -117,20 +275,63 @@ func (m *migrate) dropTable(ctx context.Context, drop *schema.DropTable) error {
 func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) error {
 	var (
 		changes     []schema.Change
+		partitions  []schema.Change
+		grants      []schema.Change
+		policies    []schema.Change
 		addI, dropI []*schema.Index
 	)
 	for _, change := range skipAutoChanges(modify.Changes) {
+		if isPartitionChange(change) {
+			partitions = append(partitions, change)
+			continue
+		}
+		if sqlx.IsGrantChange(change) {
+			grants = append(grants, change)
+			continue
+		}
+		if isPolicyChange(change) {
+			policies = append(policies, change)
+			continue
+		}
 		switch change := change.(type) {
 		case *schema.DropAttr:
-			return fmt.Errorf("unsupported change type: %T", change)
+			if !isExcludeChange(change) {
+				return fmt.Errorf("unsupported change type: %T", change)
+			}
+			changes = append(changes, change)
+		case *schema.ModifyAttr:
+			// Exclusion constraints cannot be altered. Therefore,
+			// they are dropped and added back with their new definition.
+			if isExcludeChange(change) {
+				changes = append(changes, &schema.DropAttr{A: change.From}, &schema.AddAttr{A: change.To})
+				continue
+			}
+			changes = append(changes, change)
+		// UNIQUE constraints are added and dropped using ALTER TABLE.
 		case *schema.AddIndex:
+			if uniqueConstraint(change.I) {
+				changes = append(changes, change)
+				continue
+			}
 			addI = append(addI, change.I)
 		case *schema.DropIndex:
+			if uniqueConstraint(change.I) {
+				changes = append(changes, change)
+				continue
+			}
 			dropI = append(dropI, change.I)
 		case *schema.ModifyIndex:
 			// Index modification requires rebuilding the index.
-			addI = append(addI, change.To)
-			dropI = append(dropI, change.From)
+			if uniqueConstraint(change.From) {
+				changes = append(changes, &schema.DropIndex{I: change.From})
+			} else {
+				dropI = append(dropI, change.From)
+			}
+			if uniqueConstraint(change.To) {
+				changes = append(changes, &schema.AddIndex{I: change.To})
+			} else {
+				addI = append(addI, change.To)
+			}
 		case *schema.ModifyForeignKey:
 			// Foreign-key modification is translated into 2 steps.
 			// Dropping the current foreign key and creating a new one.
@@ -140,28 +341,61 @@ func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) e
 				F: change.To,
 			})
 		case *schema.AddColumn:
-			if err := m.addTypes(ctx, change.C); err != nil {
+			if err := m.addTypes(ctx, modify.T, change.C); err != nil {
 				return err
 			}
 			changes = append(changes, change)
 		case *schema.ModifyColumn:
-			from, ok1 := change.From.Type.Type.(*schema.EnumType)
-			to, ok2 := change.To.Type.Type.(*schema.EnumType)
-			switch {
-			// Enum was added.
-			case !ok1 && ok2:
-				if err := m.addTypes(ctx, change.To); err != nil {
-					return err
+			// The expression of a generated column cannot be altered. Therefore,
+			// the column is recreated, as its values are computed by the database.
+			if change.Change.Is(schema.ChangeGenerated) && sqlx.Has(change.To.Attrs, &schema.GeneratedExpr{}) {
+				if !sqlx.Has(change.From.Attrs, &schema.GeneratedExpr{}) {
+					return fmt.Errorf("changing column %q to a generated column is not supported", change.From.Name)
 				}
-			// Enum was changed.
-			case ok1 && ok2 && from.T == to.T:
-				if err := m.alterType(ctx, from, to); err != nil {
-					return err
+				changes = append(changes, &schema.DropColumn{C: change.From}, &schema.AddColumn{C: change.To})
+				continue
+			}
+			if change.Change.Is(schema.ChangeType) {
+				from, to := change.From.Type.Type, change.To.Type.Type
+				n1, ok1 := userTypeName(from)
+				n2, ok2 := userTypeName(to)
+				switch {
+				// The type definition was changed, but the column still uses it.
+				// The type itself is altered by migrate.alterUserTypes.
+				case ok1 && ok2 && n1 == n2:
+					if change.Change == schema.ChangeType {
+						continue
+					}
+					c := *change
+					c.Change &= ^schema.ChangeType
+					change = &c
+				case ok2:
+					if err := m.addTypes(ctx, modify.T, change.To); err != nil {
+						return err
+					}
 				}
-			// Not an enum, or was dropped.
-			default:
-				changes = append(changes, change)
 			}
+			if change.Change.Is(schema.ChangeType) {
+				from, ok1 := change.From.Type.Type.(*schema.EnumType)
+				to, ok2 := change.To.Type.Type.(*schema.EnumType)
+				switch {
+				// Enum values were changed. The type itself
+				// is altered by migrate.alterEnums.
+				case ok1 && ok2 && sameEnum(modify.T, from, to):
+					if change.Change == schema.ChangeType {
+						continue
+					}
+					c := *change
+					c.Change &= ^schema.ChangeType
+					change = &c
+				// Enum was added, or replaced by another one.
+				case ok2:
+					if err := m.addTypes(ctx, modify.T, change.To); err != nil {
+						return err
+					}
+				}
+			}
+			changes = append(changes, change)
 		default:
 			changes = append(changes, change)
 		}
@@ -174,7 +408,317 @@ func (m *migrate) modifyTable(ctx context.Context, modify *schema.ModifyTable) e
 			return err
 		}
 	}
-	return m.addIndexes(ctx, modify.T, addI...)
+	if err := m.alterPartitions(ctx, modify.T, partitions); err != nil {
+		return err
+	}
+	if err := m.addIndexes(ctx, modify.T, addI...); err != nil {
+		return err
+	}
+	for _, c := range grants {
+		if err := m.grant(ctx, modify.T.Schema, modify.T, c); err != nil {
+			return err
+		}
+	}
+	return m.alterPolicies(ctx, modify.T, policies)
+}
+
+// grants executes the GRANT statements of a schema or a table that was created.
+func (m *migrate) grants(ctx context.Context, s *schema.Schema, t *schema.Table) error {
+	var attrs []schema.Attr
+	if t != nil {
+		attrs = t.Attrs
+	} else {
+		attrs = s.Attrs
+	}
+	for _, a := range attrs {
+		if g, ok := a.(*schema.Grant); ok {
+			if err := m.grant(ctx, s, t, &schema.AddAttr{A: g}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// grant executes the GRANT and REVOKE statements of a grant change on a schema or on a table.
+// The t argument is nil for schema grants. Note that revoking a privilege in PostgreSQL also
+// revokes its grant option.
+func (m *migrate) grant(ctx context.Context, s *schema.Schema, t *schema.Table, c schema.Change) error {
+	var stmts []string
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		g := c.A.(*schema.Grant)
+		stmts = append(stmts, m.privileges("GRANT", g, g.Privileges, s, t))
+	case *schema.DropAttr:
+		g := c.A.(*schema.Grant)
+		stmts = append(stmts, m.privileges("REVOKE", g, g.Privileges, s, t))
+	case *schema.ModifyAttr:
+		from, to := c.From.(*schema.Grant), c.To.(*schema.Grant)
+		grant, revoke := sqlx.PrivilegesChange(from, to)
+		if len(grant) > 0 {
+			stmts = append(stmts, m.privileges("GRANT", to, grant, s, t))
+		}
+		if len(revoke) > 0 {
+			stmts = append(stmts, m.privileges("REVOKE", from, revoke, s, t))
+		}
+	}
+	for _, stmt := range stmts {
+		if _, err := m.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("grant privileges: %w", err)
+		}
+	}
+	return nil
+}
+
+// privileges builds the GRANT or REVOKE statement of the given privileges.
+func (m *migrate) privileges(verb string, g *schema.Grant, privs []string, s *schema.Schema, t *schema.Table) string {
+	b := Build(verb)
+	b.MapComma(privs, func(i int, b *sqlx.Builder) {
+		b.P(privs[i])
+		if len(g.Columns) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(g.Columns, func(i int, b *sqlx.Builder) {
+					b.Ident(g.Columns[i].Name)
+				})
+			})
+		}
+	})
+	if t != nil {
+		b.P("ON TABLE").Table(t)
+	} else {
+		b.P("ON SCHEMA").Ident(s.Name)
+	}
+	if verb == "REVOKE" {
+		b.P("FROM")
+	} else {
+		b.P("TO")
+	}
+	if strings.EqualFold(g.Grantee, "PUBLIC") {
+		b.P("PUBLIC")
+	} else {
+		b.Ident(g.Grantee)
+	}
+	if verb == "GRANT" && g.GrantOption {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String()
+}
+
+// addPolicies enables the row-level security of a table that was
+// created, and creates its policies.
+func (m *migrate) addPolicies(ctx context.Context, t *schema.Table) error {
+	var changes []schema.Change
+	if r := rowSecurity(t.Attrs); r != nil && (r.Enabled || r.Force) {
+		changes = append(changes, &schema.AddAttr{A: r})
+	}
+	for _, p := range policies(t.Attrs) {
+		changes = append(changes, &schema.AddAttr{A: p})
+	}
+	return m.alterPolicies(ctx, t, changes)
+}
+
+// alterPolicies executes the changes of the row-level security settings and the policies of
+// a table. Policies are altered in place, unless their type or command were changed or their
+// expressions were removed, as these cannot be changed using ALTER POLICY.
+func (m *migrate) alterPolicies(ctx context.Context, t *schema.Table, changes []schema.Change) error {
+	var stmts []*sqlx.Builder
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			switch a := c.A.(type) {
+			case *RowSecurity:
+				stmts = append(stmts, m.rowSecurity(t, &RowSecurity{}, a))
+			case *Policy:
+				stmts = append(stmts, m.createPolicy(t, a))
+			}
+		case *schema.DropAttr:
+			switch a := c.A.(type) {
+			case *RowSecurity:
+				stmts = append(stmts, m.rowSecurity(t, a, &RowSecurity{}))
+			case *Policy:
+				stmts = append(stmts, Build("DROP POLICY").Ident(a.Name).P("ON").Table(t))
+			}
+		case *schema.ModifyAttr:
+			switch to := c.To.(type) {
+			case *RowSecurity:
+				stmts = append(stmts, m.rowSecurity(t, c.From.(*RowSecurity), to))
+			case *Policy:
+				from := c.From.(*Policy)
+				if !strings.EqualFold(policyAs(from), policyAs(to)) || !strings.EqualFold(policyFor(from), policyFor(to)) ||
+					from.Using != "" && to.Using == "" || from.Check != "" && to.Check == "" {
+					stmts = append(stmts, Build("DROP POLICY").Ident(from.Name).P("ON").Table(t), m.createPolicy(t, to))
+					continue
+				}
+				b := Build("ALTER POLICY").Ident(to.Name).P("ON").Table(t)
+				m.policyExprs(b, to)
+				stmts = append(stmts, b)
+			}
+		}
+	}
+	for _, b := range stmts {
+		if _, err := m.ExecContext(ctx, b.String()); err != nil {
+			return fmt.Errorf("alter policies of table %q: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// rowSecurity builds the ALTER TABLE statement for moving
+// the row-level security of a table from one state to the other.
+func (m *migrate) rowSecurity(t *schema.Table, from, to *RowSecurity) *sqlx.Builder {
+	var actions []string
+	switch {
+	case !from.Enabled && to.Enabled:
+		actions = append(actions, "ENABLE ROW LEVEL SECURITY")
+	case from.Enabled && !to.Enabled:
+		actions = append(actions, "DISABLE ROW LEVEL SECURITY")
+	}
+	switch {
+	case !from.Force && to.Force:
+		actions = append(actions, "FORCE ROW LEVEL SECURITY")
+	case from.Force && !to.Force:
+		actions = append(actions, "NO FORCE ROW LEVEL SECURITY")
+	}
+	b := Build("ALTER TABLE").Table(t)
+	b.MapComma(actions, func(i int, b *sqlx.Builder) {
+		b.P(actions[i])
+	})
+	return b
+}
+
+// createPolicy builds the CREATE POLICY statement of the given policy.
+func (m *migrate) createPolicy(t *schema.Table, p *Policy) *sqlx.Builder {
+	b := Build("CREATE POLICY").Ident(p.Name).P("ON").Table(t)
+	if p.As != "" {
+		b.P("AS", strings.ToUpper(p.As))
+	}
+	if p.For != "" {
+		b.P("FOR", strings.ToUpper(p.For))
+	}
+	m.policyExprs(b, p)
+	return b
+}
+
+// policyExprs writes the roles and the expressions of a policy.
+func (m *migrate) policyExprs(b *sqlx.Builder, p *Policy) {
+	roles := policyRoles(p)
+	b.P("TO")
+	b.MapComma(roles, func(i int, b *sqlx.Builder) {
+		if roles[i] == "PUBLIC" {
+			b.P(roles[i])
+		} else {
+			b.Ident(roles[i])
+		}
+	})
+	if p.Using != "" {
+		b.P("USING", sqlx.MayWrap(p.Using))
+	}
+	if p.Check != "" {
+		b.P("WITH CHECK", sqlx.MayWrap(p.Check))
+	}
+}
+
+// isPolicyChange reports if the given change modifies the
+// row-level security settings or the policies of a table.
+func isPolicyChange(c schema.Change) bool {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.DropAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	switch a.(type) {
+	case *RowSecurity, *Policy:
+		return true
+	}
+	return false
+}
+
+// alterPartitions executes the changes of the table partitions. A partition with a changed
+// bound is detached from its parent and attached back using the new bound. Note that
+// PostgreSQL does not support partitioning an existing table or changing its partition key.
+func (m *migrate) alterPartitions(ctx context.Context, t *schema.Table, changes []schema.Change) error {
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddAttr:
+			if d, ok := c.A.(*PartitionDef); ok {
+				if err := m.addPartition(ctx, t, d); err != nil {
+					return err
+				}
+				continue
+			}
+			return fmt.Errorf("partitioning existing table %q is not supported", t.Name)
+		case *schema.DropAttr:
+			if d, ok := c.A.(*PartitionDef); ok {
+				if _, err := m.ExecContext(ctx, Build("DROP TABLE").Table(partitionTable(t, d)).String()); err != nil {
+					return fmt.Errorf("drop partition: %w", err)
+				}
+				continue
+			}
+			return fmt.Errorf("removing the partitioning of table %q is not supported", t.Name)
+		case *schema.ModifyAttr:
+			d, ok := c.To.(*PartitionDef)
+			if !ok {
+				return fmt.Errorf("changing the partition key of table %q is not supported", t.Name)
+			}
+			if _, err := m.ExecContext(ctx, Build("ALTER TABLE").Table(t).P("DETACH PARTITION").Table(partitionTable(t, d)).String()); err != nil {
+				return fmt.Errorf("detach partition: %w", err)
+			}
+			if _, err := m.ExecContext(ctx, Build("ALTER TABLE").Table(t).P("ATTACH PARTITION").Table(partitionTable(t, d)).P(d.Bound).String()); err != nil {
+				return fmt.Errorf("attach partition: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// addPartition creates the given partition of the table.
+func (m *migrate) addPartition(ctx context.Context, t *schema.Table, d *PartitionDef) error {
+	b := Build("CREATE TABLE").Table(partitionTable(t, d)).P("PARTITION OF").Table(t).P(d.Bound)
+	if _, err := m.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("create partition: %w", err)
+	}
+	return nil
+}
+
+func (m *migrate) partitionParts(b *sqlx.Builder, parts []*PartitionPart) {
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(parts, func(i int, b *sqlx.Builder) {
+			switch p := parts[i]; {
+			case p.C != nil:
+				b.Ident(p.C.Name)
+			case p.X != nil:
+				b.WriteString(p.X.(*schema.RawExpr).X)
+			}
+		})
+	})
+}
+
+// partitionTable returns the table that represents the given partition.
+// Partitions are defined in the same schema as their parent table.
+func partitionTable(t *schema.Table, d *PartitionDef) *schema.Table {
+	return &schema.Table{Name: d.Name, Schema: t.Schema}
+}
+
+// isPartitionChange reports if the given change modifies the table partitioning.
+func isPartitionChange(c schema.Change) bool {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.DropAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	switch a.(type) {
+	case *Partition, *PartitionDef:
+		return true
+	}
+	return false
 }
 
 // alterTable modifies the given table by executing on it a list of changes in one SQL statement.
@@ -194,6 +738,20 @@ func (m *migrate) alterTable(ctx context.Context, t *schema.Table, changes []sch
 			m.fks(b, change.F)
 		case *schema.DropForeignKey:
 			b.P("DROP CONSTRAINT").Ident(change.F.Symbol)
+		case *schema.AddIndex:
+			b.P("ADD")
+			m.unique(b, change.I)
+		case *schema.DropIndex:
+			b.P("DROP CONSTRAINT").Ident(change.I.Name)
+		case *schema.AddAttr:
+			if e, ok := change.A.(*Exclude); ok {
+				b.P("ADD")
+				m.exclude(b, e)
+			}
+		case *schema.DropAttr:
+			if e, ok := change.A.(*Exclude); ok {
+				b.P("DROP CONSTRAINT").Ident(e.Name)
+			}
 		}
 	})
 	if _, err := m.ExecContext(ctx, b.String()); err != nil {
@@ -241,8 +799,16 @@ func (m *migrate) dropIndexes(ctx context.Context, indexes ...*schema.Index) err
 	return nil
 }
 
-func (m *migrate) addTypes(ctx context.Context, columns ...*schema.Column) error {
+// addTypes creates the user-defined types used by the given columns of
+// the table. Enums without a schema are created in the table schema.
+func (m *migrate) addTypes(ctx context.Context, t *schema.Table, columns ...*schema.Column) error {
 	for _, c := range columns {
+		if _, ok := userTypeName(c.Type.Type); ok {
+			if err := m.addUserType(ctx, c.Type.Type); err != nil {
+				return err
+			}
+			continue
+		}
 		e, ok := c.Type.Type.(*schema.EnumType)
 		if !ok {
 			continue
@@ -250,54 +816,478 @@ func (m *migrate) addTypes(ctx context.Context, columns ...*schema.Column) error
 		if e.T == "" {
 			return fmt.Errorf("missing enum name for column %q", c.Name)
 		}
+		if e.Schema == nil {
+			e.Schema = t.Schema
+		}
 		c.Type.Raw = e.T
-		if exists, err := m.enumExists(ctx, e.T); err != nil {
+		ns := enumSchema(t, e)
+		if exists, err := m.typeExists(ctx, ns, e.T, "e"); err != nil {
 			return err
 		} else if exists {
 			continue
 		}
-		b := Build("CREATE TYPE").Ident(e.T).P("AS ENUM")
+		if err := m.createEnum(ctx, ns, e.T, e.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *migrate) createEnum(ctx context.Context, ns, name string, values []string) error {
+	b := Build("CREATE TYPE").P(typeIdent(ns, name), "AS ENUM")
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(values, func(i int, b *sqlx.Builder) {
+			b.WriteString(enumValue(values[i]))
+		})
+	})
+	if _, err := m.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("create enum type %q: %w", name, err)
+	}
+	m.types[typeKey(ns, name)] = true
+	return nil
+}
+
+// alterEnums alters the enum types that were changed by the given changes.
+// A type that is used by multiple columns is altered only once.
+func (m *migrate) alterEnums(ctx context.Context, changes []schema.Change) error {
+	altered := make(map[string]bool)
+	for _, c := range changes {
+		modify, ok := c.(*schema.ModifyTable)
+		if !ok {
+			continue
+		}
+		for _, c := range modify.Changes {
+			change, ok := c.(*schema.ModifyColumn)
+			if !ok || !change.Change.Is(schema.ChangeType) {
+				continue
+			}
+			from, ok1 := change.From.Type.Type.(*schema.EnumType)
+			to, ok2 := change.To.Type.Type.(*schema.EnumType)
+			if !ok1 || !ok2 || !sameEnum(modify.T, from, to) {
+				continue
+			}
+			name := typeIdent(enumSchema(modify.T, to), to.T)
+			if altered[name] {
+				continue
+			}
+			altered[name] = true
+			if err := m.alterType(ctx, enumSchema(modify.T, to), from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// alterType alters the values of the given enum type. New values are added
+// in their position, values that were replaced in place are renamed, and
+// other changes (e.g. dropping or reordering values) recreate the type.
+func (m *migrate) alterType(ctx context.Context, ns string, from, to *schema.EnumType) error {
+	var (
+		stmts []*sqlx.Builder
+		name  = typeIdent(ns, to.T)
+	)
+	switch {
+	case sqlx.ValuesEqual(from.Values, to.Values):
+	case enumAppended(from.Values, to.Values):
+		for i, v := range to.Values {
+			if contains(from.Values, v) {
+				continue
+			}
+			b := Build("ALTER TYPE").P(name, "ADD VALUE", enumValue(v))
+			// Values are added after all existing values, unless
+			// they are followed by a value that already exists.
+			for _, next := range to.Values[i+1:] {
+				if contains(from.Values, next) {
+					b.P("BEFORE", enumValue(next))
+					break
+				}
+			}
+			stmts = append(stmts, b)
+		}
+	case enumRenamed(from.Values, to.Values):
+		for i := range from.Values {
+			if from.Values[i] != to.Values[i] {
+				stmts = append(stmts, Build("ALTER TYPE").P(name, "RENAME VALUE", enumValue(from.Values[i]), "TO", enumValue(to.Values[i])))
+			}
+		}
+	default:
+		return m.recreateEnum(ctx, ns, to)
+	}
+	for _, b := range stmts {
+		if _, err := m.ExecContext(ctx, b.String()); err != nil {
+			return fmt.Errorf("alter enum type %q: %w", to.T, err)
+		}
+	}
+	return nil
+}
+
+// recreateEnum replaces the given enum type with a new one that holds its new
+// values, and converts the columns that use it to the new type. The conversion
+// fails in case a removed value is still in use by one of the rows. Column
+// defaults cannot be converted automatically, and therefore, they are dropped
+// before the conversion and restored after it. Other dependents of the type
+// (e.g. views) are not converted, and fail the drop of the old type.
+func (m *migrate) recreateEnum(ctx context.Context, ns string, e *schema.EnumType) error {
+	rows, err := m.QueryContext(ctx, enumColumnsQuery, typeIdent(ns, e.T))
+	if err != nil {
+		return fmt.Errorf("query enum %q columns: %w", e.T, err)
+	}
+	var columns []*schema.Table
+	for rows.Next() {
+		var (
+			tns, table, column string
+			def                sql.NullString
+		)
+		if err := rows.Scan(&tns, &table, &column, &def); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan enum %q columns: %w", e.T, err)
+		}
+		c := &schema.Column{Name: column}
+		// The default expression references the type by its name,
+		// and therefore, it is resolved to the new type on restore.
+		if def.Valid {
+			c.Default = &schema.RawExpr{X: def.String}
+		}
+		columns = append(columns, &schema.Table{
+			Name:    table,
+			Schema:  &schema.Schema{Name: tns},
+			Columns: []*schema.Column{c},
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	old := e.T + "_old"
+	if _, err := m.ExecContext(ctx, Build("ALTER TYPE").P(typeIdent(ns, e.T), "RENAME TO").Ident(old).String()); err != nil {
+		return fmt.Errorf("rename enum type %q: %w", e.T, err)
+	}
+	if err := m.createEnum(ctx, ns, e.T, e.Values); err != nil {
+		return err
+	}
+	for _, t := range columns {
+		c := t.Columns[0]
+		if c.Default != nil {
+			b := Build("ALTER TABLE").Table(t).P("ALTER COLUMN").Ident(c.Name).P("DROP DEFAULT")
+			if _, err := m.ExecContext(ctx, b.String()); err != nil {
+				return fmt.Errorf("drop default of column %q: %w", c.Name, err)
+			}
+		}
+		b := Build("ALTER TABLE").Table(t).P("ALTER COLUMN").Ident(c.Name).P("TYPE", typeIdent(ns, e.T), "USING", enumCast(c.Name, typeIdent(ns, e.T)))
+		if _, err := m.ExecContext(ctx, b.String()); err != nil {
+			return fmt.Errorf("convert column %q to enum type %q: %w", c.Name, e.T, err)
+		}
+		if c.Default != nil {
+			b := Build("ALTER TABLE").Table(t).P("ALTER COLUMN").Ident(c.Name).P("SET DEFAULT", c.Default.(*schema.RawExpr).X)
+			if _, err := m.ExecContext(ctx, b.String()); err != nil {
+				return fmt.Errorf("restore default of column %q: %w", c.Name, err)
+			}
+		}
+	}
+	if _, err := m.ExecContext(ctx, Build("DROP TYPE").P(typeIdent(ns, old)).String()); err != nil {
+		return fmt.Errorf("drop enum type %q: %w", old, err)
+	}
+	return nil
+}
+
+// dropEnums drops the enum types that were used by dropped or modified columns, or
+// were dropped from their schemas, and are no longer referenced by any column in
+// the database.
+func (m *migrate) dropEnums(ctx context.Context, changes []schema.Change) error {
+	var (
+		names []string
+		seen  = make(map[string]bool)
+	)
+	add := func(ns, name string) {
+		if name := typeIdent(ns, name); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	unused := func(t *schema.Table, e *schema.EnumType) {
+		if e.T != "" {
+			add(enumSchema(t, e), e.T)
+		}
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.ModifySchema:
+			for _, change := range c.Changes {
+				if d, ok := change.(*schema.DropAttr); ok {
+					if e, ok := d.A.(*Enum); ok {
+						add(c.S.Name, e.T)
+					}
+				}
+			}
+		case *schema.DropTable:
+			for _, col := range c.T.Columns {
+				if e, ok := col.Type.Type.(*schema.EnumType); ok {
+					unused(c.T, e)
+				}
+			}
+		case *schema.ModifyTable:
+			for _, change := range c.Changes {
+				switch change := change.(type) {
+				case *schema.DropColumn:
+					if e, ok := change.C.Type.Type.(*schema.EnumType); ok {
+						unused(c.T, e)
+					}
+				case *schema.ModifyColumn:
+					from, ok := change.From.Type.Type.(*schema.EnumType)
+					if !ok || !change.Change.Is(schema.ChangeType) {
+						continue
+					}
+					if to, ok := change.To.Type.Type.(*schema.EnumType); !ok || !sameEnum(c.T, from, to) {
+						unused(c.T, from)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		var drop bool
+		if err := m.QueryRowContext(ctx, enumUnusedQuery, name).Scan(&drop); err != nil {
+			return fmt.Errorf("check enum %s usage: %w", name, err)
+		}
+		if !drop {
+			continue
+		}
+		if _, err := m.ExecContext(ctx, Build("DROP TYPE").P(name).String()); err != nil {
+			return fmt.Errorf("drop enum type %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// addUserType creates the given domain, composite or range type
+// if it does not exist in the database.
+func (m *migrate) addUserType(ctx context.Context, t schema.Type) error {
+	var (
+		b       *sqlx.Builder
+		typtype string
+	)
+	switch t := t.(type) {
+	case *DomainType:
+		if t.Base == nil {
+			return fmt.Errorf("missing base type for domain %q", t.T)
+		}
+		// The base type can be a user-defined type as well.
+		if _, ok := userTypeName(t.Base); ok {
+			if err := m.addUserType(ctx, t.Base); err != nil {
+				return err
+			}
+		}
+		typtype = "d"
+		b = Build("CREATE DOMAIN").Ident(t.T).P("AS", mustFormat(t.Base))
+		if x, ok := t.Default.(*schema.RawExpr); ok {
+			b.P("DEFAULT", x.X)
+		}
+		if t.NotNull {
+			b.P("NOT NULL")
+		}
+		for _, c := range t.Checks {
+			m.domainCheck(b, c)
+		}
+	case *CompositeType:
+		for _, f := range t.Fields {
+			if _, ok := userTypeName(f.Type.Type); ok {
+				if err := m.addUserType(ctx, f.Type.Type); err != nil {
+					return err
+				}
+			}
+		}
+		typtype = "c"
+		b = Build("CREATE TYPE").Ident(t.T).P("AS")
 		b.Wrap(func(b *sqlx.Builder) {
-			b.MapComma(e.Values, func(i int, b *sqlx.Builder) {
-				b.WriteString("'" + e.Values[i] + "'")
+			b.MapComma(t.Fields, func(i int, b *sqlx.Builder) {
+				b.Ident(t.Fields[i].Name).P(mustFormat(t.Fields[i].Type.Type))
 			})
 		})
-		if _, err := m.ExecContext(ctx, b.String()); err != nil {
-			return fmt.Errorf("create enum type: %w", err)
+	case *RangeType:
+		if t.Subtype == nil {
+			return fmt.Errorf("missing subtype for range %q", t.T)
 		}
+		typtype = "r"
+		b = Build("CREATE TYPE").Ident(t.T).P("AS RANGE")
+		b.Wrap(func(b *sqlx.Builder) {
+			b.WriteString("SUBTYPE = " + mustFormat(t.Subtype))
+		})
+	default:
+		return fmt.Errorf("unexpected user-defined type: %T", t)
+	}
+	name, _ := userTypeName(t)
+	if name == "" {
+		return fmt.Errorf("missing name for %T", t)
+	}
+	if exists, err := m.typeExists(ctx, "", name, typtype); err != nil || exists {
+		return err
+	}
+	if _, err := m.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("create type %q: %w", name, err)
 	}
+	m.types[typeKey("", name)] = true
 	return nil
 }
 
-func (m *migrate) alterType(ctx context.Context, from, to *schema.EnumType) error {
-	if len(from.Values) > len(to.Values) {
-		return fmt.Errorf("dropping enum (%q) value is not supported", from.T)
+// alterUserTypes alters the domain, composite and range types that were changed,
+// but are still used by the modified columns. Each type is altered only once.
+func (m *migrate) alterUserTypes(ctx context.Context, changes []schema.Change) error {
+	altered := make(map[string]bool)
+	for _, c := range changes {
+		modify, ok := c.(*schema.ModifyTable)
+		if !ok {
+			continue
+		}
+		for _, c := range modify.Changes {
+			change, ok := c.(*schema.ModifyColumn)
+			if !ok || !change.Change.Is(schema.ChangeType) {
+				continue
+			}
+			from, to := change.From.Type.Type, change.To.Type.Type
+			n1, ok1 := userTypeName(from)
+			n2, ok2 := userTypeName(to)
+			if !ok1 || !ok2 || n1 != n2 || altered[n2] {
+				continue
+			}
+			altered[n2] = true
+			if err := m.alterUserType(ctx, from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// alterUserType alters the definition of the given domain, composite or range type.
+func (m *migrate) alterUserType(ctx context.Context, from, to schema.Type) error {
+	switch from := from.(type) {
+	case *DomainType:
+		return m.alterDomain(ctx, from, to.(*DomainType))
+	case *CompositeType:
+		return m.alterComposite(ctx, from, to.(*CompositeType))
+	case *RangeType:
+		return fmt.Errorf("changing the subtype of range type %q is not supported", from.T)
+	default:
+		return fmt.Errorf("unexpected user-defined type: %T", from)
+	}
+}
+
+func (m *migrate) alterDomain(ctx context.Context, from, to *DomainType) error {
+	if !typesMatch(from.Base, to.Base) {
+		return fmt.Errorf("changing the base type of domain %q is not supported", from.T)
+	}
+	var stmts []*sqlx.Builder
+	x1, ok1 := from.Default.(*schema.RawExpr)
+	x2, ok2 := to.Default.(*schema.RawExpr)
+	switch {
+	case ok1 && !ok2:
+		stmts = append(stmts, Build("ALTER DOMAIN").Ident(to.T).P("DROP DEFAULT"))
+	case ok2 && (!ok1 || trimCast(x1.X) != trimCast(x2.X)):
+		stmts = append(stmts, Build("ALTER DOMAIN").Ident(to.T).P("SET DEFAULT", x2.X))
+	}
+	switch {
+	case from.NotNull && !to.NotNull:
+		stmts = append(stmts, Build("ALTER DOMAIN").Ident(to.T).P("DROP NOT NULL"))
+	case !from.NotNull && to.NotNull:
+		stmts = append(stmts, Build("ALTER DOMAIN").Ident(to.T).P("SET NOT NULL"))
+	}
+	// Modified checks are dropped and then added back.
+	for _, c1 := range from.Checks {
+		if c2, ok := checkNamed(to.Checks, c1.Name); !ok || sqlx.UnwrapExpr(c1.Clause) != sqlx.UnwrapExpr(c2.Clause) {
+			stmts = append(stmts, Build("ALTER DOMAIN").Ident(to.T).P("DROP CONSTRAINT").Ident(c1.Name))
+		}
 	}
-	for i := range from.Values {
-		if from.Values[i] != to.Values[i] {
-			return fmt.Errorf("replacing or reordering enum (%q) value is not supported: %q != %q", to.T, to.Values, from.Values)
+	for _, c2 := range to.Checks {
+		if c1, ok := checkNamed(from.Checks, c2.Name); !ok || sqlx.UnwrapExpr(c1.Clause) != sqlx.UnwrapExpr(c2.Clause) {
+			b := Build("ALTER DOMAIN").Ident(to.T).P("ADD")
+			m.domainCheck(b, c2)
+			stmts = append(stmts, b)
 		}
 	}
-	for _, v := range to.Values[len(from.Values):] {
-		b := Build("ALTER TYPE").Ident(from.T).P("ADD VALUE", "'"+v+"'")
+	for _, b := range stmts {
 		if _, err := m.ExecContext(ctx, b.String()); err != nil {
-			return fmt.Errorf("adding a new value %q for enum %q: %w", v, to.T, err)
+			return fmt.Errorf("alter domain %q: %w", to.T, err)
+		}
+	}
+	return nil
+}
+
+func (m *migrate) alterComposite(ctx context.Context, from, to *CompositeType) error {
+	var changes []schema.Change
+	for _, f1 := range from.Fields {
+		if f2, ok := compositeField(to, f1.Name); !ok {
+			changes = append(changes, &schema.DropColumn{C: f1})
+		} else if !typesMatch(f1.Type.Type, f2.Type.Type) {
+			changes = append(changes, &schema.ModifyColumn{From: f1, To: f2, Change: schema.ChangeType})
+		}
+	}
+	for _, f2 := range to.Fields {
+		if _, ok := compositeField(from, f2.Name); !ok {
+			changes = append(changes, &schema.AddColumn{C: f2})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	b := Build("ALTER TYPE").Ident(to.T)
+	b.MapComma(changes, func(i int, b *sqlx.Builder) {
+		switch change := changes[i].(type) {
+		case *schema.AddColumn:
+			b.P("ADD ATTRIBUTE").Ident(change.C.Name).P(mustFormat(change.C.Type.Type))
+		case *schema.DropColumn:
+			b.P("DROP ATTRIBUTE").Ident(change.C.Name)
+		case *schema.ModifyColumn:
+			b.P("ALTER ATTRIBUTE").Ident(change.To.Name).P("TYPE", mustFormat(change.To.Type.Type))
 		}
+	})
+	if _, err := m.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("alter type %q: %w", to.T, err)
 	}
 	return nil
 }
 
-func (m *migrate) enumExists(ctx context.Context, name string) (bool, error) {
-	rows, err := m.QueryContext(ctx, "SELECT * FROM pg_type WHERE typname = $1 AND typtype = 'e'", name)
+func (m *migrate) domainCheck(b *sqlx.Builder, c *Check) {
+	if c.Name != "" {
+		b.P("CONSTRAINT").Ident(c.Name)
+	}
+	b.P("CHECK", sqlx.MayWrap(c.Clause))
+}
+
+// typeExists reports if the given type exists in the schema. Types
+// without a schema are resolved in the current schema. Types that were
+// created by the executed changes exist, and other types in schemas that
+// are created by the executed changes, or in an empty database, do not.
+func (m *migrate) typeExists(ctx context.Context, ns, name, typtype string) (bool, error) {
+	if m.types[typeKey(ns, name)] {
+		return true, nil
+	}
+	if m.empty || ns != "" && m.created[ns] {
+		return false, nil
+	}
+	query, args := typeExistsQuery+"current_schema()", []interface{}{name, typtype}
+	if ns != "" {
+		query, args = typeExistsQuery+"$3", append(args, ns)
+	}
+	rows, err := m.QueryContext(ctx, query, args...)
 	if err != nil {
-		return false, fmt.Errorf("check index existance: %w", err)
+		return false, fmt.Errorf("check type existence: %w", err)
 	}
 	defer rows.Close()
 	return rows.Next(), rows.Err()
 }
 
+// typeKey returns the key of the type in the created types.
+func typeKey(ns, name string) string {
+	return ns + "." + name
+}
+
 func (m *migrate) addIndexes(ctx context.Context, t *schema.Table, indexes ...*schema.Index) error {
 	for _, idx := range indexes {
+		// UNIQUE constraints are defined in the table definition.
+		if uniqueConstraint(idx) {
+			continue
+		}
 		b := Build("CREATE")
 		if idx.Unique {
 			b.P("UNIQUE")
@@ -322,8 +1312,9 @@ func (m *migrate) column(b *sqlx.Builder, c *schema.Column) {
 		b.P("NOT")
 	}
 	b.P("NULL")
-	if x, ok := c.Default.(*schema.RawExpr); ok {
-		b.P("DEFAULT", x.X)
+	// Generated columns cannot have a DEFAULT value.
+	if x, ok := sqlx.DefaultValue(c.Default); ok && !sqlx.Has(c.Attrs, &schema.GeneratedExpr{}) {
+		b.P("DEFAULT", x)
 	}
 	for _, attr := range c.Attrs {
 		switch attr := attr.(type) {
@@ -331,10 +1322,14 @@ func (m *migrate) column(b *sqlx.Builder, c *schema.Column) {
 		case *schema.Collation:
 			b.P("COLLATE").Ident(attr.V)
 		case *Identity:
-			if attr.Generation == "" {
-				attr.Generation = "BY DEFAULT"
+			id := identity(attr)
+			b.P("GENERATED", id.Generation, "AS IDENTITY")
+			if id.Start != 1 || id.Increment != 1 {
+				b.P(fmt.Sprintf("(START WITH %d INCREMENT BY %d)", id.Start, id.Increment))
 			}
-			b.P("GENERATED", attr.Generation, "AS IDENTITY")
+		case *schema.GeneratedExpr:
+			// PostgreSQL supports only stored generated columns.
+			b.P("GENERATED ALWAYS AS", sqlx.MayWrap(attr.Expr), "STORED")
 		default:
 			panic(fmt.Sprintf("unexpected column attribute: %T", attr))
 		}
@@ -350,6 +1345,10 @@ func (m *migrate) alterColumn(b *sqlx.Builder, c *schema.ModifyColumn) {
 			if collate := (schema.Collation{}); sqlx.Has(c.To.Attrs, &collate) {
 				b.P("COLLATE", collate.V)
 			}
+			// Values are not implicitly cast to enum types.
+			if _, ok := c.To.Type.Type.(*schema.EnumType); ok {
+				b.P("USING", enumCast(c.To.Name, mustFormat(c.To.Type.Type)))
+			}
 			k &= ^schema.ChangeType
 		case k.Is(schema.ChangeNull) && c.To.Type.Null:
 			b.P("DROP NOT NULL")
@@ -361,12 +1360,41 @@ func (m *migrate) alterColumn(b *sqlx.Builder, c *schema.ModifyColumn) {
 			b.P("DROP DEFAULT")
 			k &= ^schema.ChangeDefault
 		case k.Is(schema.ChangeDefault) && c.To.Default != nil:
-			x, ok := c.To.Default.(*schema.RawExpr)
+			x, ok := sqlx.DefaultValue(c.To.Default)
 			if !ok {
 				panic(fmt.Sprintf("unexpected column default: %T", c.To.Default))
 			}
-			b.P("SET DEFAULT", x.X)
+			b.P("SET DEFAULT", x)
 			k &= ^schema.ChangeDefault
+		case k.Is(schema.ChangeAttr):
+			toI, fromI := &Identity{}, &Identity{}
+			switch toHas, fromHas := sqlx.Has(c.To.Attrs, toI), sqlx.Has(c.From.Attrs, fromI); {
+			case toHas && !fromHas:
+				id := identity(toI)
+				b.P("ADD GENERATED", id.Generation, "AS IDENTITY")
+				if id.Start != 1 || id.Increment != 1 {
+					b.P(fmt.Sprintf("(START WITH %d INCREMENT BY %d)", id.Start, id.Increment))
+				}
+			case !toHas && fromHas:
+				b.P("DROP IDENTITY")
+			case toHas && fromHas:
+				from, to := identity(fromI), identity(toI)
+				if from.Generation != to.Generation {
+					b.P("SET GENERATED", to.Generation)
+				}
+				if from.Start != to.Start {
+					b.P("SET START WITH", strconv.FormatInt(to.Start, 10))
+				}
+				if from.Increment != to.Increment {
+					b.P("SET INCREMENT BY", strconv.FormatInt(to.Increment, 10))
+				}
+			}
+			k &= ^schema.ChangeAttr
+		case k.Is(schema.ChangeGenerated):
+			// Generation expressions can only be dropped (converting
+			// the column to a regular one). See migrate.modifyTable.
+			b.P("DROP EXPRESSION")
+			k &= ^schema.ChangeGenerated
 		default:
 			panic(fmt.Sprintf("unexpected column change: %d", k))
 		}
@@ -376,6 +1404,23 @@ func (m *migrate) alterColumn(b *sqlx.Builder, c *schema.ModifyColumn) {
 	}
 }
 
+// identity returns a copy of the identity attribute
+// with its default values set.
+func identity(id *Identity) *Identity {
+	c := *id
+	if c.Generation == "" {
+		c.Generation = "BY DEFAULT"
+	}
+	c.Generation = strings.ToUpper(c.Generation)
+	if c.Start == 0 {
+		c.Start = 1
+	}
+	if c.Increment == 0 {
+		c.Increment = 1
+	}
+	return &c
+}
+
 func (m *migrate) indexParts(b *sqlx.Builder, parts []*schema.IndexPart) {
 	b.Wrap(func(b *sqlx.Builder) {
 		b.MapComma(parts, func(i int, b *sqlx.Builder) {
@@ -416,6 +1461,10 @@ func (m *migrate) partAttr(b *sqlx.Builder, attr schema.Attr) {
 }
 
 func (m *migrate) indexAttrs(b *sqlx.Builder, attrs []schema.Attr) {
+	m.include(b, attrs)
+	if !nullsDistinct(attrs) {
+		b.P("NULLS NOT DISTINCT")
+	}
 	// Avoid appending the default method.
 	if t := (IndexType{}); sqlx.Has(attrs, &t) && strings.ToLower(t.T) != "btree" {
 		b.P("USING").P(t.T)
@@ -425,13 +1474,40 @@ func (m *migrate) indexAttrs(b *sqlx.Builder, attrs []schema.Attr) {
 	}
 	for _, attr := range attrs {
 		switch attr.(type) {
-		case *schema.Comment, *ConType, *IndexType, *IndexPredicate:
+		case *schema.Comment, *ConType, *IndexType, *IndexPredicate, *IndexInclude, *IndexNullsDistinct, *Deferrable:
 		default:
 			panic(fmt.Sprintf("unexpected index attribute: %T", attr))
 		}
 	}
 }
 
+// include writes the INCLUDE clause of an index, if it has non-key columns.
+func (m *migrate) include(b *sqlx.Builder, attrs []schema.Attr) {
+	if inc, ok := indexInclude(attrs); ok && len(inc.Columns) > 0 {
+		b.P("INCLUDE")
+		b.Wrap(func(b *sqlx.Builder) {
+			b.MapComma(inc.Columns, func(i int, b *sqlx.Builder) {
+				b.Ident(inc.Columns[i].Name)
+			})
+		})
+	}
+}
+
+// unique writes the definition of a UNIQUE constraint.
+func (m *migrate) unique(b *sqlx.Builder, idx *schema.Index) {
+	b.P("CONSTRAINT").Ident(idx.Name).P("UNIQUE")
+	if !nullsDistinct(idx.Attrs) {
+		b.P("NULLS NOT DISTINCT")
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(idx.Parts, func(i int, b *sqlx.Builder) {
+			b.Ident(idx.Parts[i].C.Name)
+		})
+	})
+	m.include(b, idx.Attrs)
+	m.deferrable(b, idx.Attrs)
+}
+
 func (m *migrate) fks(b *sqlx.Builder, fks ...*schema.ForeignKey) {
 	b.MapComma(fks, func(i int, b *sqlx.Builder) {
 		fk := fks[i]
@@ -450,13 +1526,65 @@ func (m *migrate) fks(b *sqlx.Builder, fks ...*schema.ForeignKey) {
 				b.Ident(fk.RefColumns[i].Name)
 			})
 		})
+		if t := (MatchType{}); sqlx.Has(fk.Attrs, &t) && t.T != "" {
+			b.P("MATCH", strings.ToUpper(t.T))
+		}
 		if fk.OnUpdate != "" {
 			b.P("ON UPDATE", string(fk.OnUpdate))
 		}
 		if fk.OnDelete != "" {
 			b.P("ON DELETE", string(fk.OnDelete))
 		}
+		m.deferrable(b, fk.Attrs)
+	})
+}
+
+// exclude writes the definition of an exclusion constraint.
+func (m *migrate) exclude(b *sqlx.Builder, e *Exclude) {
+	b.P("CONSTRAINT").Ident(e.Name).P("EXCLUDE")
+	if e.T != "" {
+		b.P("USING", strings.ToLower(e.T))
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(e.Elems, func(i int, b *sqlx.Builder) {
+			switch el := e.Elems[i]; {
+			case el.C != nil:
+				b.Ident(el.C.Name)
+			case el.X != nil:
+				b.WriteString(sqlx.MayWrap(el.X.(*schema.RawExpr).X))
+			}
+			b.P("WITH", e.Elems[i].Op)
+		})
 	})
+	if e.Where != "" {
+		b.P("WHERE", sqlx.MayWrap(e.Where))
+	}
+	m.deferrable(b, e.Attrs)
+}
+
+// deferrable writes the DEFERRABLE options of a constraint, if it is deferrable.
+func (m *migrate) deferrable(b *sqlx.Builder, attrs []schema.Attr) {
+	if d := (Deferrable{}); sqlx.Has(attrs, &d) {
+		b.P("DEFERRABLE")
+		if d.InitiallyDeferred {
+			b.P("INITIALLY DEFERRED")
+		}
+	}
+}
+
+// isExcludeChange reports if the given change modifies an exclusion constraint.
+func isExcludeChange(c schema.Change) bool {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.DropAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	_, ok := a.(*Exclude)
+	return ok
 }
 
 // Build instantiates a new builder and writes the given phrase to it.
@@ -499,3 +1627,89 @@ func skipAutoChanges(changes []schema.Change) []schema.Change {
 	}
 	return changes
 }
+
+const (
+	// Query to check if a type exists in a schema.
+	// The schema placeholder is filled by the caller.
+	typeExistsQuery = "SELECT t1.oid FROM pg_catalog.pg_type AS t1 JOIN pg_catalog.pg_namespace AS t2 ON t2.oid = t1.typnamespace WHERE t1.typname = $1 AND t1.typtype = $2 AND t2.nspname = "
+
+	// Query to list the table columns that use an enum type.
+	enumColumnsQuery = "SELECT t3.nspname, t2.relname, t1.attname, pg_catalog.pg_get_expr(t4.adbin, t4.adrelid) FROM pg_catalog.pg_attribute AS t1 JOIN pg_catalog.pg_class AS t2 ON t2.oid = t1.attrelid JOIN pg_catalog.pg_namespace AS t3 ON t3.oid = t2.relnamespace LEFT JOIN pg_catalog.pg_attrdef AS t4 ON t4.adrelid = t1.attrelid AND t4.adnum = t1.attnum WHERE t1.atttypid = to_regtype($1) AND t2.relkind IN ('r', 'p') AND NOT t1.attisdropped ORDER BY t3.nspname, t2.relname, t1.attnum"
+
+	// Query to check if an enum type exists, and is not referenced by any column.
+	enumUnusedQuery = "SELECT to_regtype($1) IS NOT NULL AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend WHERE refobjid = to_regtype($1) AND deptype = 'n')"
+)
+
+// enumSchema returns the schema name of the enum. Enums
+// without a schema are resolved to the table schema.
+func enumSchema(t *schema.Table, e *schema.EnumType) string {
+	switch {
+	case e.Schema != nil:
+		return e.Schema.Name
+	case t.Schema != nil:
+		return t.Schema.Name
+	default:
+		return ""
+	}
+}
+
+// sameEnum reports if the two enums, used by the
+// given table, refer to the same database type.
+func sameEnum(t *schema.Table, e1, e2 *schema.EnumType) bool {
+	return e1.T == e2.T && enumSchema(t, e1) == enumSchema(t, e2)
+}
+
+// enumAppended reports if the new enum values were only added to
+// the existing ones, and the existing values kept their order.
+func enumAppended(from, to []string) bool {
+	i := 0
+	for _, v := range to {
+		if i < len(from) && from[i] == v {
+			i++
+		} else if contains(from, v) {
+			return false
+		}
+	}
+	return i == len(from)
+}
+
+// enumRenamed reports if the enum values were only renamed in place.
+func enumRenamed(from, to []string) bool {
+	if len(from) != len(to) {
+		return false
+	}
+	for i := range from {
+		if from[i] != to[i] && (contains(to, from[i]) || contains(from, to[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// typeIdent returns the quoted type name, prefixed with its schema if exists.
+func typeIdent(ns, name string) string {
+	b := &sqlx.Builder{QuoteChar: '"'}
+	if ns != "" {
+		b.Table(&schema.Table{Name: name, Schema: &schema.Schema{Name: ns}})
+	} else {
+		b.Ident(name)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func enumValue(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+func enumCast(column, typ string) string {
+	return fmt.Sprintf("%q::text::%s", column, typ)
+}
+
+func contains(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
+}
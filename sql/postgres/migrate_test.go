@@ -0,0 +1,801 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_Exec(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "users" ("id" integer NOT NULL GENERATED ALWAYS AS IDENTITY (START WITH 100 INCREMENT BY 1), "a" integer NOT NULL, "b" integer NOT NULL GENERATED ALWAYS AS (a * 2) STORED, "c" text NOT NULL DEFAULT 'unknown')`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "posts" ALTER COLUMN "id" DROP DEFAULT, ALTER COLUMN "id" ADD GENERATED BY DEFAULT AS IDENTITY, ALTER COLUMN "a" SET GENERATED ALWAYS SET INCREMENT BY 2, ALTER COLUMN "b" DROP IDENTITY, ALTER COLUMN "c" DROP EXPRESSION, DROP COLUMN "d", ADD COLUMN "d" integer NOT NULL GENERATED ALWAYS AS (a * 3) STORED`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	users := &schema.Table{
+		Name: "users",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Start: 100}}},
+			{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+			{Name: "b", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "a * 2"}}},
+			{Name: "c", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}, Default: &schema.Literal{V: `"unknown"`}},
+		},
+	}
+	posts := &schema.Table{
+		Name: "posts",
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{}}},
+			{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Increment: 2}}},
+			{Name: "b", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+			{Name: "c", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+			{Name: "d", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(a * 3)"}}},
+		},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddTable{T: users},
+		&schema.ModifyTable{
+			T: posts,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From: &schema.Column{
+						Name:    "id",
+						Type:    &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}},
+						Default: &SeqFuncExpr{X: "nextval('posts_id_seq'::regclass)"},
+					},
+					To:     posts.Columns[0],
+					Change: schema.ChangeDefault | schema.ChangeAttr,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Start: 1, Increment: 1}}},
+					To:     posts.Columns[1],
+					Change: schema.ChangeAttr,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "b", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT"}}},
+					To:     posts.Columns[2],
+					Change: schema.ChangeAttr,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "c", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(a * 2)", Type: "STORED"}}},
+					To:     posts.Columns[3],
+					Change: schema.ChangeGenerated,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "d", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "(a * 2)", Type: "STORED"}}},
+					To:     posts.Columns[4],
+					Change: schema.ChangeGenerated,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Columns cannot be converted to generated columns.
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: posts,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "d", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+					To:     posts.Columns[4],
+					Change: schema.ChangeGenerated,
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestMigrate_UserTypes(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	typeExists := func(name, typtype string, exists bool) {
+		rows := sqlmock.NewRows([]string{"oid"})
+		if exists {
+			rows.AddRow(1)
+		}
+		mk.ExpectQuery(sqltest.Escape(typeExistsQuery+"current_schema()")).
+			WithArgs(name, typtype).
+			WillReturnRows(rows)
+	}
+	typeExists("posint", "d", false)
+	mk.ExpectExec(sqltest.Escape(`CREATE DOMAIN "posint" AS integer DEFAULT 1 NOT NULL CONSTRAINT "positive" CHECK (VALUE > 0)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	typeExists("address", "c", false)
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "address" AS ("street" character varying(255), "zip" integer)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	typeExists("floatrange", "r", true)
+	// Types that were created are not checked again.
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "users" ("c1" posint NOT NULL, "c2" address NOT NULL, "c3" floatrange NOT NULL, "c4" address NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	domain := &DomainType{
+		T:       "posint",
+		Base:    &schema.IntegerType{T: "integer"},
+		NotNull: true,
+		Default: &schema.RawExpr{X: "1"},
+		Checks:  []*Check{{Name: "positive", Clause: "(VALUE > 0)"}},
+	}
+	address := &CompositeType{
+		T: "address",
+		Fields: []*schema.Column{
+			{Name: "street", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar", Size: 255}}},
+			{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}},
+		},
+	}
+	users := &schema.Table{
+		Name: "users",
+		Columns: []*schema.Column{
+			{Name: "c1", Type: &schema.ColumnType{Type: domain}},
+			{Name: "c2", Type: &schema.ColumnType{Type: address}},
+			{Name: "c3", Type: &schema.ColumnType{Type: &RangeType{T: "floatrange", Subtype: &schema.FloatType{T: "double precision"}}}},
+			{Name: "c4", Type: &schema.ColumnType{Type: address}},
+		},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+
+	// Types are altered once, even if they are used by multiple columns.
+	mk.ExpectExec(sqltest.Escape(`ALTER DOMAIN "posint" DROP DEFAULT`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER DOMAIN "posint" DROP NOT NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER DOMAIN "posint" DROP CONSTRAINT "positive"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER DOMAIN "posint" ADD CONSTRAINT "positive" CHECK (VALUE >= 1)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TYPE "address" DROP ATTRIBUTE "street", ALTER ATTRIBUTE "zip" TYPE bigint, ADD ATTRIBUTE "city" text`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "users" ALTER COLUMN "c2" DROP NOT NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	address2 := &CompositeType{
+		T: "address",
+		Fields: []*schema.Column{
+			{Name: "zip", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "bigint"}}},
+			{Name: "city", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+		},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From: users.Columns[0],
+					To: &schema.Column{
+						Name: "c1",
+						Type: &schema.ColumnType{Type: &DomainType{
+							T:      "posint",
+							Base:   &schema.IntegerType{T: "integer"},
+							Checks: []*Check{{Name: "positive", Clause: "VALUE >= 1"}},
+						}},
+					},
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From: users.Columns[1],
+					To: &schema.Column{
+						Name: "c2",
+						Type: &schema.ColumnType{Type: address2, Null: true},
+					},
+					Change: schema.ChangeType | schema.ChangeNull,
+				},
+				&schema.ModifyColumn{
+					From:   users.Columns[3],
+					To:     &schema.Column{Name: "c4", Type: &schema.ColumnType{Type: address2}},
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Range subtypes cannot be altered.
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   users.Columns[2],
+					To:     &schema.Column{Name: "c3", Type: &schema.ColumnType{Type: &RangeType{T: "floatrange", Subtype: &schema.DecimalType{T: "numeric"}}}},
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestMigrate_Enums(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	ok := func() driver.Result { return sqlmock.NewResult(0, 0) }
+	mk.ExpectQuery(sqltest.Escape(typeExistsQuery+"$3")).
+		WithArgs("state", "e", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"oid"}))
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "public"."state" AS ENUM ('on', 'off')`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."logs" ("state" "public"."state" NOT NULL)`)).
+		WillReturnResult(ok())
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddTable{
+			T: &schema.Table{
+				Name:   "logs",
+				Schema: &schema.Schema{Name: "public"},
+				Columns: []*schema.Column{
+					{Name: "state", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Enums in schemas that are created by the changes are created without checking their existence.
+	mk.ExpectExec(sqltest.Escape(`CREATE SCHEMA "test"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "test"."state" AS ENUM ('on', 'off')`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "test"."logs" ("state" "test"."state" NOT NULL)`)).
+		WillReturnResult(ok())
+	test := &schema.Schema{Name: "test"}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddSchema{S: test},
+		&schema.AddTable{
+			T: &schema.Table{
+				Name:   "logs",
+				Schema: test,
+				Columns: []*schema.Column{
+					{Name: "state", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Schemas that are created with IF NOT EXISTS may exist, and their enums are checked.
+	mk.ExpectExec(sqltest.Escape(`CREATE SCHEMA IF NOT EXISTS "test"`)).
+		WillReturnResult(ok())
+	mk.ExpectQuery(sqltest.Escape(typeExistsQuery+"$3")).
+		WithArgs("state", "e", "test").
+		WillReturnRows(sqlmock.NewRows([]string{"oid"}).AddRow(1))
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "test"."logs" ("state" "test"."state" NOT NULL)`)).
+		WillReturnResult(ok())
+	logs := &schema.Table{
+		Name:   "logs",
+		Schema: test,
+		Columns: []*schema.Column{
+			{Name: "state", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+			{Name: "age", Type: &schema.ColumnType{Type: &DomainType{T: "posint", Base: &schema.IntegerType{T: "integer"}}}},
+		},
+	}
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddSchema{S: test, Extra: []schema.Clause{&schema.IfNotExists{}}},
+		&schema.AddTable{T: &schema.Table{Name: "logs", Schema: test, Columns: logs.Columns[:1]}},
+	})
+	require.NoError(t, err)
+
+	// Types are created without checking their existence when planning for an empty database.
+	mk.ExpectExec(sqltest.Escape(`CREATE SCHEMA IF NOT EXISTS "test"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "test"."state" AS ENUM ('on', 'off')`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE DOMAIN "posint" AS integer`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "test"."logs" ("state" "test"."state" NOT NULL, "age" posint NOT NULL)`)).
+		WillReturnResult(ok())
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddSchema{S: test, Extra: []schema.Clause{&schema.IfNotExists{}, &schema.Empty{}}},
+		&schema.AddTable{T: logs},
+	})
+	require.NoError(t, err)
+
+	// Types that are shared by multiple tables are created once.
+	mk.ExpectExec(sqltest.Escape(`CREATE SCHEMA "test"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "test"."state" AS ENUM ('on', 'off')`)).
+		WillReturnResult(ok())
+	mk.ExpectQuery(sqltest.Escape(typeExistsQuery+"current_schema()")).
+		WithArgs("posint", "d").
+		WillReturnRows(sqlmock.NewRows([]string{"oid"}))
+	mk.ExpectExec(sqltest.Escape(`CREATE DOMAIN "posint" AS integer`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "test"."logs" ("state" "test"."state" NOT NULL, "age" posint NOT NULL)`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "test"."events" ("state" "test"."state" NOT NULL, "age" posint NOT NULL)`)).
+		WillReturnResult(ok())
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddSchema{S: test},
+		&schema.AddTable{T: logs},
+		&schema.AddTable{T: &schema.Table{Name: "events", Schema: test, Columns: []*schema.Column{
+			{Name: "state", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "off"}}}},
+			{Name: "age", Type: &schema.ColumnType{Type: &DomainType{T: "posint", Base: &schema.IntegerType{T: "integer"}}}},
+		}}},
+	})
+	require.NoError(t, err)
+
+	// Enum types are altered once, before the tables are modified.
+	mk.ExpectExec(sqltest.Escape(`ALTER TYPE "public"."state" ADD VALUE 'unknown' BEFORE 'off'`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TYPE "public"."state" ADD VALUE 'broken'`)).
+		WillReturnResult(ok())
+	mk.ExpectQuery(sqltest.Escape(enumColumnsQuery)).
+		WithArgs(`"public"."day"`).
+		WillReturnRows(sqltest.Rows(`
+ nspname | relname | attname | pg_get_expr
+---------+---------+---------+----------------
+ public  | users   | day     | 'sunday'::day
+ public  | events  | day     |
+`))
+	mk.ExpectExec(sqltest.Escape(`ALTER TYPE "public"."day" RENAME TO "day_old"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "public"."day" AS ENUM ('sunday', 'tuesday')`)).
+		WillReturnResult(ok())
+	// Defaults are dropped before the conversion, and restored after it.
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" ALTER COLUMN "day" DROP DEFAULT`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" ALTER COLUMN "day" TYPE "public"."day" USING "day"::text::"public"."day"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" ALTER COLUMN "day" SET DEFAULT 'sunday'::day`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."events" ALTER COLUMN "day" TYPE "public"."day" USING "day"::text::"public"."day"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`DROP TYPE "public"."day_old"`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TYPE "public"."level" RENAME VALUE 'warn' TO 'warning'`)).
+		WillReturnResult(ok())
+	mk.ExpectQuery(sqltest.Escape(typeExistsQuery+"$3")).
+		WithArgs("kind", "e", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"oid"}))
+	mk.ExpectExec(sqltest.Escape(`CREATE TYPE "public"."kind" AS ENUM ('a', 'b')`)).
+		WillReturnResult(ok())
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" ALTER COLUMN "level" SET NOT NULL, DROP COLUMN "mood", ALTER COLUMN "kind" TYPE "public"."kind" USING "kind"::text::"public"."kind"`)).
+		WillReturnResult(ok())
+	// Enums that are no longer in use are dropped.
+	mk.ExpectQuery(sqltest.Escape(enumUnusedQuery)).
+		WithArgs(`"public"."mood"`).
+		WillReturnRows(sqlmock.NewRows([]string{"unused"}).AddRow(true))
+	mk.ExpectExec(sqltest.Escape(`DROP TYPE "public"."mood"`)).
+		WillReturnResult(ok())
+	var (
+		public = &schema.Schema{Name: "public"}
+		enum   = func(name string, values ...string) *schema.ColumnType {
+			return &schema.ColumnType{Type: &schema.EnumType{T: name, Values: values, Schema: public}}
+		}
+		users = &schema.Table{Name: "users", Schema: public}
+	)
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "s1", Type: enum("state", "on", "off")},
+					To:     &schema.Column{Name: "s1", Type: enum("state", "on", "unknown", "off", "broken")},
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "s2", Type: enum("state", "on", "off")},
+					To:     &schema.Column{Name: "s2", Type: &schema.ColumnType{Type: &schema.EnumType{T: "state", Values: []string{"on", "unknown", "off", "broken"}}}},
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "day", Type: enum("day", "sunday", "monday", "tuesday")},
+					To:     &schema.Column{Name: "day", Type: enum("day", "sunday", "tuesday")},
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "level", Type: enum("level", "info", "warn")},
+					To:     &schema.Column{Name: "level", Type: enum("level", "info", "warning")},
+					Change: schema.ChangeType | schema.ChangeNull,
+				},
+				&schema.DropColumn{
+					C: &schema.Column{Name: "mood", Type: enum("mood", "happy", "sad")},
+				},
+				&schema.ModifyColumn{
+					From:   &schema.Column{Name: "kind", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+					To:     &schema.Column{Name: "kind", Type: &schema.ColumnType{Type: &schema.EnumType{T: "kind", Values: []string{"a", "b"}}}},
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Extensions(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	public := &schema.Schema{Name: "public"}
+	users := &schema.Table{
+		Name:    "users",
+		Schema:  public,
+		Columns: []*schema.Column{{Name: "email", Type: &schema.ColumnType{Type: &UserDefinedType{T: "citext"}}}},
+	}
+	mk.ExpectExec(sqltest.Escape(`CREATE EXTENSION "citext" WITH SCHEMA "public"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER EXTENSION "postgis" UPDATE TO '3.2.0'`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."users" ("email" citext NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`DROP TABLE "public"."pets"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`DROP EXTENSION "uuid-ossp"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{
+			S: public,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: &Extension{Name: "citext"}},
+				&schema.DropAttr{A: &Extension{Name: "uuid-ossp", Version: "1.1"}},
+				&schema.ModifyAttr{From: &Extension{Name: "postgis", Version: "3.1.4"}, To: &Extension{Name: "postgis", Version: "3.2.0"}},
+			},
+		},
+		&schema.AddTable{T: users},
+		&schema.DropTable{T: &schema.Table{Name: "pets", Schema: public}},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape(`CREATE SCHEMA "app"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE EXTENSION "pgcrypto" WITH SCHEMA "app" VERSION '1.3'`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.AddSchema{S: &schema.Schema{Name: "app", Attrs: []schema.Attr{&Extension{Name: "pgcrypto", Version: "1.3"}}}},
+	})
+	require.NoError(t, err)
+
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{S: public, Changes: []schema.Change{&schema.AddAttr{A: &schema.Comment{Text: "c"}}}},
+	})
+	require.EqualError(t, err, "unsupported schema attribute: *schema.Comment")
+}
+
+func TestMigrate_SchemaEnums(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	public := &schema.Schema{Name: "public"}
+	// Enums are dropped after the tables, and only if they are no longer in use.
+	mk.ExpectExec(sqltest.Escape(`DROP TABLE "public"."logs"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectQuery(sqltest.Escape(enumUnusedQuery)).
+		WithArgs(`"public"."mood"`).
+		WillReturnRows(sqlmock.NewRows([]string{"unused"}).AddRow(true))
+	mk.ExpectExec(sqltest.Escape(`DROP TYPE "public"."mood"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectQuery(sqltest.Escape(enumUnusedQuery)).
+		WithArgs(`"public"."state"`).
+		WillReturnRows(sqlmock.NewRows([]string{"unused"}).AddRow(false))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{
+			S: public,
+			Changes: []schema.Change{
+				&schema.DropAttr{A: &Enum{T: "mood"}},
+				&schema.DropAttr{A: &Enum{T: "state"}},
+			},
+		},
+		&schema.DropTable{
+			T: &schema.Table{
+				Name:   "logs",
+				Schema: public,
+				Columns: []*schema.Column{
+					{Name: "mood", Type: &schema.ColumnType{Type: &schema.EnumType{T: "mood", Values: []string{"happy"}}}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Grants(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	public := &schema.Schema{Name: "public"}
+	users := &schema.Table{
+		Name:   "users",
+		Schema: public,
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "bigint"}}},
+			{Name: "email", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+		},
+	}
+	users.Attrs = []schema.Attr{
+		&schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "INSERT"}},
+		&schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: users.Columns[:1]},
+	}
+	mk.ExpectExec(sqltest.Escape(`CREATE ROLE "app"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`GRANT USAGE ON SCHEMA "public" TO "app" WITH GRANT OPTION`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`REVOKE CREATE ON SCHEMA "public" FROM PUBLIC`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."users" ("id" bigint NOT NULL, "email" text NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`GRANT SELECT, INSERT ON TABLE "public"."users" TO "app"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`GRANT SELECT ("id") ON TABLE "public"."users" TO "reporter"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifySchema{
+			S: public,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: &schema.Role{Name: "app"}},
+				&schema.AddAttr{A: &schema.Grant{Grantee: "app", Privileges: []string{"USAGE"}, GrantOption: true}},
+				&schema.DropAttr{A: &schema.Grant{Grantee: "PUBLIC", Privileges: []string{"CREATE"}}},
+			},
+		},
+		&schema.AddTable{T: users},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape(`GRANT UPDATE ON TABLE "public"."users" TO "app"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`REVOKE INSERT ON TABLE "public"."users" FROM "app"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`REVOKE SELECT ("id") ON TABLE "public"."users" FROM "reporter"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{
+					From: &schema.Grant{Grantee: "app", Privileges: []string{"INSERT", "SELECT"}},
+					To:   &schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "UPDATE"}},
+				},
+				&schema.DropAttr{A: users.Attrs[1]},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Partitions(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	s := &schema.Schema{Name: "public"}
+	logs := &schema.Table{
+		Name:   "logs",
+		Schema: s,
+		Columns: []*schema.Column{
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+			{Name: "name", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+		},
+	}
+	jan := &PartitionDef{Name: "logs_2021_01", Bound: "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')"}
+	logs.Attrs = []schema.Attr{
+		&Partition{
+			T:     "RANGE",
+			Parts: []*PartitionPart{{C: logs.Columns[0]}, {X: &schema.RawExpr{X: "lower(name)"}}},
+			Defs:  []*PartitionDef{jan},
+		},
+	}
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."logs" ("created_at" date NOT NULL, "name" text NOT NULL) PARTITION BY RANGE ("created_at", lower(name))`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."logs_2021_01" PARTITION OF "public"."logs" FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: logs}})
+	require.NoError(t, err)
+
+	feb := &PartitionDef{Name: "logs_2021_02", Bound: "FOR VALUES FROM ('2021-02-01') TO ('2021-03-01')"}
+	def := &PartitionDef{Name: "logs_default", Bound: "DEFAULT"}
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."logs_2021_02" PARTITION OF "public"."logs" FOR VALUES FROM ('2021-02-01') TO ('2021-03-01')`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`DROP TABLE "public"."logs_2021_01"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_default"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_default" DEFAULT`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: logs,
+			Changes: []schema.Change{
+				&schema.AddAttr{A: feb},
+				&schema.DropAttr{A: jan},
+				&schema.ModifyAttr{From: &PartitionDef{Name: "logs_default", Bound: "FOR VALUES FROM (MINVALUE) TO ('2021-01-01')"}, To: def},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T:       logs,
+			Changes: []schema.Change{&schema.ModifyAttr{From: logs.Attrs[0], To: &Partition{T: "HASH"}}},
+		},
+	})
+	require.EqualError(t, err, `changing the partition key of table "logs" is not supported`)
+}
+
+func TestMigrate_Policies(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	users := &schema.Table{
+		Name:   "users",
+		Schema: &schema.Schema{Name: "public"},
+		Columns: []*schema.Column{
+			{Name: "tenant_id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+		},
+	}
+	tenant := &Policy{Name: "tenant", To: []string{"app"}, Using: "tenant_id = current_setting('app.tenant')::int"}
+	users.Attrs = []schema.Attr{
+		&RowSecurity{Enabled: true, Force: true},
+		tenant,
+		&Policy{Name: "deny_delete", As: "RESTRICTIVE", For: "DELETE", Using: "false"},
+	}
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."users" ("tenant_id" integer NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE POLICY "tenant" ON "public"."users" TO "app" USING (tenant_id = current_setting('app.tenant')::int)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE POLICY "deny_delete" ON "public"."users" AS RESTRICTIVE FOR DELETE TO PUBLIC USING (false)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" NO FORCE ROW LEVEL SECURITY`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER POLICY "tenant" ON "public"."users" TO "app", "reporter" USING (tenant_id = current_setting('app.tenant')::int) WITH CHECK (tenant_id > 0)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`DROP POLICY "deny_delete" ON "public"."users"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE POLICY "deny_delete" ON "public"."users" AS RESTRICTIVE FOR UPDATE TO PUBLIC USING (false)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`DROP POLICY "old" ON "public"."users"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{From: users.Attrs[0], To: &RowSecurity{Enabled: true}},
+				&schema.ModifyAttr{From: tenant, To: &Policy{Name: "tenant", To: []string{"reporter", "app"}, Using: tenant.Using, Check: "tenant_id > 0"}},
+				&schema.ModifyAttr{From: users.Attrs[2], To: &Policy{Name: "deny_delete", As: "RESTRICTIVE", For: "UPDATE", Using: "false"}},
+				&schema.DropAttr{A: &Policy{Name: "old"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" DISABLE ROW LEVEL SECURITY`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.DropAttr{A: &RowSecurity{Enabled: true}}}},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_Constraints(t *testing.T) {
+	migrate, mk, err := newMigrate("130000")
+	require.NoError(t, err)
+	s := &schema.Schema{Name: "public"}
+	rooms := &schema.Table{Name: "rooms", Schema: s, Columns: []*schema.Column{{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}}}}
+	bookings := &schema.Table{
+		Name:   "bookings",
+		Schema: s,
+		Columns: []*schema.Column{
+			{Name: "room_id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "during", Type: &schema.ColumnType{Type: &UserDefinedType{T: "tsrange"}}},
+		},
+	}
+	bookings.ForeignKeys = []*schema.ForeignKey{
+		{
+			Symbol:     "room_fk",
+			Table:      bookings,
+			Columns:    bookings.Columns[:1],
+			RefTable:   rooms,
+			RefColumns: rooms.Columns,
+			OnDelete:   schema.Cascade,
+			Attrs:      []schema.Attr{&MatchType{T: "FULL"}, &Deferrable{InitiallyDeferred: true}},
+		},
+	}
+	overlap := &Exclude{
+		Name:  "no_overlap",
+		T:     "GIST",
+		Elems: []*ExcludeElem{{C: bookings.Columns[0], Op: "="}, {C: bookings.Columns[1], Op: "&&"}},
+		Where: "room_id > 0",
+		Attrs: []schema.Attr{&Deferrable{}},
+	}
+	bookings.Attrs = []schema.Attr{overlap}
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."bookings" ("room_id" integer NOT NULL, "during" tsrange NOT NULL, CONSTRAINT "room_fk" FOREIGN KEY ("room_id") REFERENCES "public"."rooms" ("id") MATCH FULL ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED, CONSTRAINT "no_overlap" EXCLUDE USING gist ("room_id" WITH =, "during" WITH &&) WHERE (room_id > 0) DEFERRABLE)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: bookings}})
+	require.NoError(t, err)
+
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."bookings" DROP CONSTRAINT "no_overlap", ADD CONSTRAINT "no_overlap" EXCLUDE USING gist ((lower(during)) WITH =), DROP CONSTRAINT "old", DROP CONSTRAINT "room_fk", ADD CONSTRAINT "room_fk" FOREIGN KEY ("room_id") REFERENCES "public"."rooms" ("id")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: bookings,
+			Changes: []schema.Change{
+				&schema.ModifyAttr{From: overlap, To: &Exclude{Name: "no_overlap", T: "gist", Elems: []*ExcludeElem{{X: &schema.RawExpr{X: "lower(during)"}, Op: "="}}}},
+				&schema.DropAttr{A: &Exclude{Name: "old"}},
+				&schema.ModifyForeignKey{
+					From:   bookings.ForeignKeys[0],
+					To:     &schema.ForeignKey{Symbol: "room_fk", Table: bookings, Columns: bookings.Columns[:1], RefTable: rooms, RefColumns: rooms.Columns},
+					Change: schema.ChangeAttr | schema.ChangeDeleteAction,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMigrate_UniqueConstraints(t *testing.T) {
+	migrate, mk, err := newMigrate("150000")
+	require.NoError(t, err)
+	s := &schema.Schema{Name: "public"}
+	users := &schema.Table{
+		Name:   "users",
+		Schema: s,
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "email", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}, Null: true}},
+		},
+	}
+	users.Indexes = []*schema.Index{
+		{
+			Name:   "users_email_key",
+			Unique: true,
+			Table:  users,
+			Parts:  []*schema.IndexPart{{C: users.Columns[1]}},
+			Attrs:  []schema.Attr{&ConType{T: "u"}, &IndexNullsDistinct{}, &IndexInclude{Columns: users.Columns[:1]}, &Deferrable{}},
+		},
+		{
+			Name:   "users_id_idx",
+			Unique: true,
+			Table:  users,
+			Parts:  []*schema.IndexPart{{C: users.Columns[0]}},
+			Attrs:  []schema.Attr{&IndexInclude{Columns: users.Columns[1:]}, &IndexNullsDistinct{}},
+		},
+	}
+	mk.ExpectExec(sqltest.Escape(`CREATE TABLE "public"."users" ("id" integer NOT NULL, "email" text NULL, CONSTRAINT "users_email_key" UNIQUE NULLS NOT DISTINCT ("email") INCLUDE ("id") DEFERRABLE)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE UNIQUE INDEX "users_id_idx" ON "public"."users" ("id") INCLUDE ("email") NULLS NOT DISTINCT`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+
+	email := &schema.Index{Name: "users_email_key", Unique: true, Table: users, Parts: users.Indexes[0].Parts}
+	id := &schema.Index{Name: "users_id_key", Unique: true, Table: users, Parts: users.Indexes[1].Parts, Attrs: []schema.Attr{&ConType{T: "u"}}}
+	mk.ExpectExec(sqltest.Escape(`DROP INDEX "users_id_idx"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`ALTER TABLE "public"."users" DROP CONSTRAINT "users_email_key", ADD CONSTRAINT "users_id_key" UNIQUE ("id")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mk.ExpectExec(sqltest.Escape(`CREATE UNIQUE INDEX "users_email_key" ON "public"."users" ("email")`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = migrate.Exec(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				// Replace the UNIQUE constraint with a unique index.
+				&schema.ModifyIndex{From: users.Indexes[0], To: email, Change: schema.ChangeAttr},
+				&schema.DropIndex{I: users.Indexes[1]},
+				&schema.AddIndex{I: id},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func newMigrate(version string) (schema.Execer, *mock, error) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+	mk := &mock{m}
+	mk.version(version)
+	drv, err := Open(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	return drv, mk, nil
+}
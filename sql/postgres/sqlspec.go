@@ -10,14 +10,118 @@ import (
 
 	"ariga.io/atlas/schema/schemaspec"
 	"ariga.io/atlas/sql/internal/specutil"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlspec"
 )
 
-type doc struct {
-	Tables  []*sqlspec.Table  `spec:"table"`
-	Schemas []*sqlspec.Schema `spec:"schema"`
-}
+type (
+	doc struct {
+		Tables     []*sqlspec.Table  `spec:"table"`
+		Schemas    []*sqlspec.Schema `spec:"schema"`
+		Extensions []*extensionSpec  `spec:"extension"`
+		Domains    []*domainSpec     `spec:"domain"`
+		Composites []*compositeSpec  `spec:"composite"`
+		Ranges     []*rangeSpec      `spec:"range"`
+		Roles      []*sqlspec.Role   `spec:"role"`
+	}
+
+	// extensionSpec holds the specification of an extension.
+	extensionSpec struct {
+		Name    string          `spec:",name"`
+		Schema  *schemaspec.Ref `spec:"schema"`
+		Version string          `spec:"version"`
+	}
+
+	// domainSpec holds the specification of a domain type.
+	domainSpec struct {
+		Name    string             `spec:",name"`
+		Type    string             `spec:"type"`
+		NotNull bool               `spec:"not_null"`
+		Default string             `spec:"default"`
+		Checks  []*domainCheckSpec `spec:"check"`
+	}
+
+	// domainCheckSpec holds the specification of a domain check constraint.
+	domainCheckSpec struct {
+		Name string `spec:",name"`
+		Expr string `spec:"expr"`
+	}
+
+	// compositeSpec holds the specification of a composite type.
+	compositeSpec struct {
+		Name   string       `spec:",name"`
+		Fields []*fieldSpec `spec:"field"`
+	}
+
+	// fieldSpec holds the specification of a composite type attribute.
+	fieldSpec struct {
+		Name string `spec:",name"`
+		Type string `spec:"type"`
+	}
+
+	// rangeSpec holds the specification of a range type.
+	rangeSpec struct {
+		Name    string `spec:",name"`
+		Subtype string `spec:"subtype"`
+	}
+
+	// identitySpec holds the specification of an identity column.
+	identitySpec struct {
+		Generated string `spec:"generated"`
+		Start     int    `spec:"start"`
+		Increment int    `spec:"increment"`
+	}
+
+	// partitionSpec holds the specification of a partitioned table. The partition
+	// key is defined either by a list of columns or by a raw SQL expression.
+	partitionSpec struct {
+		Type    string              `spec:"type"`
+		Columns []*schemaspec.Ref   `spec:"columns"`
+		Expr    string              `spec:"expr"`
+		Parts   []*partitionDefSpec `spec:"part"`
+	}
+
+	// partitionDefSpec holds the specification of a table partition.
+	partitionDefSpec struct {
+		Name  string `spec:",name"`
+		Bound string `spec:"bound"`
+	}
+
+	// excludeSpec holds the specification of an exclusion constraint.
+	excludeSpec struct {
+		Name              string             `spec:",name"`
+		Type              string             `spec:"type"`
+		Elems             []*excludeElemSpec `spec:"on"`
+		Where             string             `spec:"where"`
+		Deferrable        bool               `spec:"deferrable"`
+		InitiallyDeferred bool               `spec:"initially_deferred"`
+	}
+
+	// excludeElemSpec holds the specification of an exclusion constraint element.
+	// An element is defined either by a column reference or by a raw SQL expression.
+	excludeElemSpec struct {
+		Column *schemaspec.Ref `spec:"column"`
+		Expr   string          `spec:"expr"`
+		Op     string          `spec:"op"`
+	}
+
+	// rowSecuritySpec holds the row-level security settings of a table.
+	rowSecuritySpec struct {
+		Enabled bool `spec:"enabled"`
+		Force   bool `spec:"force"`
+	}
+
+	// policySpec holds the specification of a row-level security policy.
+	policySpec struct {
+		Name  string   `spec:",name"`
+		As    string   `spec:"as"`
+		For   string   `spec:"for"`
+		To    []string `spec:"to"`
+		Using string   `spec:"using"`
+		Check string   `spec:"check"`
+	}
+)
 
 // UnmarshalSpec unmarshals an Atlas DDL document using an unmarshaler into v.
 func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{}) error {
@@ -36,10 +140,85 @@ func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{
 	if err != nil {
 		return fmt.Errorf("postgres: failed converting to *schema.Schema: %w", err)
 	}
+	// Foreign keys are linked after all tables were converted,
+	// and therefore, their attributes are converted separately.
+	for _, ts := range d.Tables {
+		t, ok := conv.Table(ts.Name)
+		if !ok {
+			continue
+		}
+		for _, fs := range ts.ForeignKeys {
+			fk, ok := t.ForeignKey(fs.Symbol)
+			if !ok {
+				continue
+			}
+			if fk.Attrs, err = convertFKAttrs(fs); err != nil {
+				return fmt.Errorf("postgres: failed reading foreign key %q of table %q: %w", fs.Symbol, ts.Name, err)
+			}
+		}
+	}
+	// Documents contain a single schema, and therefore, all extensions are
+	// installed in it. Extensions that reference another schema are rejected.
+	for _, e := range d.Extensions {
+		if e.Schema != nil {
+			name, err := specutil.SchemaName(e.Schema)
+			if err != nil {
+				return fmt.Errorf("postgres: failed reading schema of extension %q: %w", e.Name, err)
+			}
+			if name != conv.Name {
+				return fmt.Errorf("postgres: extension %q references schema %q, but the document describes schema %q", e.Name, name, conv.Name)
+			}
+		}
+		conv.Attrs = append(conv.Attrs, &Extension{Name: e.Name, Version: e.Version})
+	}
+	if err := convertUserTypes(&d, conv); err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	grants, err := specutil.Grants(&d.Schemas[0].Extra, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: failed reading grants of schema %q: %w", conv.Name, err)
+	}
+	conv.Attrs = append(conv.Attrs, specutil.Roles(d.Roles)...)
+	conv.Attrs = append(conv.Attrs, grants...)
 	*s = *conv
 	return nil
 }
 
+// ValidateSpec validates an Atlas DDL document without a database connection. Unlike
+// UnmarshalSpec, it does not stop on the first error, and reports all errors found in
+// the document.
+func ValidateSpec(data []byte, unmarshaler schemaspec.Unmarshaler) error {
+	var d doc
+	// Evaluation diagnostics do not stop the validation, as the document
+	// is still decoded from the resources that were evaluated.
+	evalErr := unmarshaler.UnmarshalSpec(data, &d)
+	if evalErr != nil && !specutil.Partial(evalErr, d.Schemas) {
+		return evalErr
+	}
+	if len(d.Schemas) != 1 {
+		return fmt.Errorf("postgres: expecting document to contain a single schema, got %d", len(d.Schemas))
+	}
+	err := specutil.Validate(d.Schemas, d.Tables, specutil.ValidateOptions{
+		EvalErr:       evalErr,
+		ConvertColumn: convertColumn,
+		Registry:      TypeRegistry,
+		Unknown: func(t schema.Type) bool {
+			_, ok := t.(*UserDefinedType)
+			return ok
+		},
+		Compatible: fkTypesCompatible,
+	})
+	if err != nil {
+		return err
+	}
+	// Run the full conversion to catch errors that are not covered by the validation.
+	var s schema.Schema
+	return UnmarshalSpec(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		*v.(*doc) = d
+		return nil
+	}), &s)
+}
+
 // MarshalSpec marshals v into an Atlas DDL document using a schemaspec.Marshaler.
 func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error) {
 	var (
@@ -53,17 +232,346 @@ func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed converting schema to spec: %w", err)
 	}
-	return marshaler.MarshalSpec(&doc{
-		Tables:  tables,
-		Schemas: []*sqlspec.Schema{spec},
-	})
+	exts := make([]*extensionSpec, 0, len(s.Attrs))
+	for _, e := range extensions(s.Attrs) {
+		es := &extensionSpec{Name: e.Name, Version: e.Version}
+		if s.Name != "" {
+			es.Schema = specutil.SchemaRef(s.Name)
+		}
+		exts = append(exts, es)
+	}
+	d := &doc{
+		Tables:     tables,
+		Schemas:    []*sqlspec.Schema{spec},
+		Extensions: exts,
+		Roles:      specutil.FromRoles(s),
+	}
+	if err := userTypeSpecs(s, d); err != nil {
+		return nil, fmt.Errorf("failed converting user-defined types to spec: %w", err)
+	}
+	return marshaler.MarshalSpec(d)
+}
+
+// convertUserTypes converts the domain, composite and range types defined in
+// the document, and resolves the columns that reference them by their names.
+func convertUserTypes(d *doc, s *schema.Schema) error {
+	types := make(map[string]schema.Type)
+	for _, ds := range d.Domains {
+		base, err := parseRawType(ds.Type)
+		if err != nil {
+			return fmt.Errorf("failed reading type of domain %q: %w", ds.Name, err)
+		}
+		t := &DomainType{T: ds.Name, Base: base, NotNull: ds.NotNull}
+		if ds.Default != "" {
+			t.Default = &schema.RawExpr{X: ds.Default}
+		}
+		for _, c := range ds.Checks {
+			t.Checks = append(t.Checks, &Check{Name: c.Name, Clause: c.Expr})
+		}
+		types[ds.Name] = t
+	}
+	for _, cs := range d.Composites {
+		t := &CompositeType{T: cs.Name}
+		for _, f := range cs.Fields {
+			ft, err := parseRawType(f.Type)
+			if err != nil {
+				return fmt.Errorf("failed reading type of field %q in composite %q: %w", f.Name, cs.Name, err)
+			}
+			t.Fields = append(t.Fields, &schema.Column{Name: f.Name, Type: &schema.ColumnType{Type: ft}})
+		}
+		types[cs.Name] = t
+	}
+	for _, rs := range d.Ranges {
+		sub, err := parseRawType(rs.Subtype)
+		if err != nil {
+			return fmt.Errorf("failed reading subtype of range %q: %w", rs.Name, err)
+		}
+		types[rs.Name] = &RangeType{T: rs.Name, Subtype: sub}
+	}
+	// Types can reference each other (e.g. a domain over a composite type).
+	resolve := func(t schema.Type) schema.Type {
+		if u, ok := t.(*UserDefinedType); ok {
+			if ut, ok := types[u.T]; ok {
+				return ut
+			}
+		}
+		return t
+	}
+	for _, t := range types {
+		switch t := t.(type) {
+		case *DomainType:
+			t.Base = resolve(t.Base)
+		case *CompositeType:
+			for _, f := range t.Fields {
+				f.Type.Type = resolve(f.Type.Type)
+			}
+		}
+	}
+	for _, ts := range d.Tables {
+		t, ok := s.Table(ts.Name)
+		if !ok {
+			continue
+		}
+		for _, cs := range ts.Columns {
+			c, ok := t.Column(cs.Name)
+			if !ok {
+				continue
+			}
+			c.Type.Type = resolve(c.Type.Type)
+			// Types that are not defined in the document are expected to exist in the
+			// database. Unless, their name is a typo of a builtin type (e.g. varchr).
+			if u, ok := c.Type.Type.(*UserDefinedType); ok {
+				name := u.T
+				if i := strings.IndexAny(name, "( ["); i != -1 {
+					name = name[:i]
+				}
+				if typ, ok := TypeRegistry.Typo(name); ok {
+					return schemaspec.WithPos(cs.AttrRange("type"), fmt.Errorf("unknown type %q for column %s.%s, did you mean %q?", cs.Type, t.Name, c.Name, typ))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// userTypeSpecs appends the specs of the domain, composite and range types
+// that are used by the schema columns to the document.
+func userTypeSpecs(s *schema.Schema, d *doc) error {
+	seen := make(map[string]bool)
+	var add func(schema.Type) error
+	add = func(t schema.Type) error {
+		name, ok := userTypeName(t)
+		if !ok || seen[name] {
+			return nil
+		}
+		seen[name] = true
+		switch t := t.(type) {
+		case *DomainType:
+			if err := add(t.Base); err != nil {
+				return err
+			}
+			base, err := FormatType(t.Base)
+			if err != nil {
+				return err
+			}
+			ds := &domainSpec{Name: t.T, Type: base, NotNull: t.NotNull}
+			if x, ok := t.Default.(*schema.RawExpr); ok {
+				ds.Default = x.X
+			}
+			for _, c := range t.Checks {
+				ds.Checks = append(ds.Checks, &domainCheckSpec{Name: c.Name, Expr: c.Clause})
+			}
+			d.Domains = append(d.Domains, ds)
+		case *CompositeType:
+			cs := &compositeSpec{Name: t.T}
+			for _, f := range t.Fields {
+				if err := add(f.Type.Type); err != nil {
+					return err
+				}
+				ft, err := FormatType(f.Type.Type)
+				if err != nil {
+					return err
+				}
+				cs.Fields = append(cs.Fields, &fieldSpec{Name: f.Name, Type: ft})
+			}
+			d.Composites = append(d.Composites, cs)
+		case *RangeType:
+			sub, err := FormatType(t.Subtype)
+			if err != nil {
+				return err
+			}
+			d.Ranges = append(d.Ranges, &rangeSpec{Name: t.T, Subtype: sub})
+		}
+		return nil
+	}
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			if err := add(c.Type.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // convertTable converts a sqlspec.Table to a schema.Table. Table conversion is done without converting
 // ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
 // at this point. Instead, the linking is done by the convertSchema function.
 func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, error) {
-	return specutil.Table(spec, parent, convertColumn, convertPrimaryKey, convertIndex)
+	t, err := specutil.Table(spec, parent, convertColumn, convertPrimaryKey, convertIndex)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range spec.Extra.Children {
+		switch r.Type {
+		case "partition":
+			p, err := convertPartition(r, t)
+			if err != nil {
+				return nil, fmt.Errorf("postgres: failed reading partition of table %q: %w", spec.Name, err)
+			}
+			t.Attrs = append(t.Attrs, p)
+		case "row_security":
+			var rs rowSecuritySpec
+			if err := r.As(&rs); err != nil {
+				return nil, fmt.Errorf("postgres: failed reading row_security of table %q: %w", spec.Name, err)
+			}
+			t.Attrs = append(t.Attrs, &RowSecurity{Enabled: rs.Enabled, Force: rs.Force})
+		case "policy":
+			p, err := convertPolicy(r)
+			if err != nil {
+				return nil, fmt.Errorf("postgres: failed reading policy of table %q: %w", spec.Name, err)
+			}
+			t.Attrs = append(t.Attrs, p)
+		case "exclude":
+			e, err := convertExclude(r, t)
+			if err != nil {
+				return nil, fmt.Errorf("postgres: failed reading exclusion constraint of table %q: %w", spec.Name, err)
+			}
+			t.Attrs = append(t.Attrs, e)
+		}
+	}
+	grants, err := specutil.Grants(&spec.Extra, t)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: failed reading grants of table %q: %w", spec.Name, err)
+	}
+	t.Attrs = append(t.Attrs, grants...)
+	return t, nil
+}
+
+// convertPartition converts a "partition" resource of a table into a Partition attribute.
+func convertPartition(r *schemaspec.Resource, t *schema.Table) (*Partition, error) {
+	var ps partitionSpec
+	if err := r.As(&ps); err != nil {
+		return nil, err
+	}
+	p := &Partition{T: strings.ToUpper(ps.Type)}
+	switch {
+	case len(ps.Columns) > 0 && ps.Expr != "":
+		return nil, errors.New("partition key must be defined by either columns or expr")
+	case ps.Expr != "":
+		p.Parts = append(p.Parts, &PartitionPart{X: &schema.RawExpr{X: ps.Expr}})
+	default:
+		for _, ref := range ps.Columns {
+			c, err := specutil.ColumnByRef(t, ref)
+			if err != nil {
+				return nil, err
+			}
+			p.Parts = append(p.Parts, &PartitionPart{C: c})
+		}
+	}
+	for _, d := range ps.Parts {
+		p.Defs = append(p.Defs, &PartitionDef{Name: d.Name, Bound: d.Bound})
+	}
+	return p, nil
+}
+
+// convertExclude converts an "exclude" resource of a table into an Exclude attribute.
+func convertExclude(r *schemaspec.Resource, t *schema.Table) (*Exclude, error) {
+	var es excludeSpec
+	if err := r.As(&es); err != nil {
+		return nil, err
+	}
+	e := &Exclude{Name: es.Name, T: es.Type, Where: es.Where}
+	if len(es.Elems) == 0 {
+		return nil, fmt.Errorf("missing elements for exclusion constraint %q", es.Name)
+	}
+	for _, el := range es.Elems {
+		switch {
+		case el.Op == "":
+			return nil, fmt.Errorf("missing operator for element of exclusion constraint %q", es.Name)
+		case el.Column != nil && el.Expr != "":
+			return nil, fmt.Errorf("element of exclusion constraint %q must be defined by either column or expr", es.Name)
+		case el.Expr != "":
+			e.Elems = append(e.Elems, &ExcludeElem{X: &schema.RawExpr{X: el.Expr}, Op: el.Op})
+		case el.Column != nil:
+			c, err := specutil.ColumnByRef(t, el.Column)
+			if err != nil {
+				return nil, err
+			}
+			e.Elems = append(e.Elems, &ExcludeElem{C: c, Op: el.Op})
+		default:
+			return nil, fmt.Errorf("missing column or expr for element of exclusion constraint %q", es.Name)
+		}
+	}
+	if es.Deferrable || es.InitiallyDeferred {
+		e.Attrs = append(e.Attrs, &Deferrable{InitiallyDeferred: es.InitiallyDeferred})
+	}
+	return e, nil
+}
+
+// convertFKAttrs converts the extension attributes of a foreign key: deferrable,
+// initially_deferred and match. Note that an initially deferred constraint is
+// implicitly deferrable.
+func convertFKAttrs(spec *sqlspec.ForeignKey) ([]schema.Attr, error) {
+	var attrs []schema.Attr
+	d, err := convertDeferrable(&spec.Extra)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		attrs = append(attrs, d)
+	}
+	if a, ok := spec.Extra.Attr("match"); ok {
+		v, err := a.String()
+		if err != nil {
+			return nil, err
+		}
+		switch v = strings.ToUpper(v); v {
+		case "SIMPLE", "FULL", "PARTIAL":
+			attrs = append(attrs, &MatchType{T: v})
+		default:
+			return nil, fmt.Errorf("unexpected match type %q", v)
+		}
+	}
+	return attrs, nil
+}
+
+// convertDeferrable converts the deferrable and initially_deferred attributes
+// of a constraint resource. A nil Deferrable is returned if the constraint is
+// not deferrable.
+func convertDeferrable(r *schemaspec.Resource) (*Deferrable, error) {
+	var (
+		deferred bool
+		d        = &Deferrable{}
+	)
+	if a, ok := r.Attr("initially_deferred"); ok {
+		v, err := a.Bool()
+		if err != nil {
+			return nil, err
+		}
+		d.InitiallyDeferred, deferred = v, v
+	}
+	if a, ok := r.Attr("deferrable"); ok {
+		v, err := a.Bool()
+		if err != nil {
+			return nil, err
+		}
+		deferred = deferred || v
+	}
+	if !deferred {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// convertPolicy converts a "policy" resource of a table into a Policy attribute.
+func convertPolicy(r *schemaspec.Resource) (*Policy, error) {
+	var ps policySpec
+	if err := r.As(&ps); err != nil {
+		return nil, err
+	}
+	p := &Policy{Name: ps.Name, As: strings.ToUpper(ps.As), For: strings.ToUpper(ps.For), To: ps.To, Using: ps.Using, Check: ps.Check}
+	switch p.As {
+	case "", "PERMISSIVE", "RESTRICTIVE":
+	default:
+		return nil, fmt.Errorf("unexpected policy type %q for policy %q", ps.As, ps.Name)
+	}
+	switch p.For {
+	case "", "ALL", "SELECT", "INSERT", "UPDATE", "DELETE":
+	default:
+		return nil, fmt.Errorf("unexpected policy command %q for policy %q", ps.For, ps.Name)
+	}
+	return p, nil
 }
 
 // convertPrimaryKey converts a sqlspec.PrimaryKey to a schema.Index.
@@ -71,14 +579,92 @@ func convertPrimaryKey(spec *sqlspec.PrimaryKey, parent *schema.Table) (*schema.
 	return specutil.PrimaryKey(spec, parent)
 }
 
-// convertIndex converts an sqlspec.Index to a schema.Index.
+// convertIndex converts an sqlspec.Index to a schema.Index. Unique indexes
+// that are set as constraints are created using the UNIQUE constraint syntax.
 func convertIndex(spec *sqlspec.Index, parent *schema.Table) (*schema.Index, error) {
-	return specutil.Index(spec, parent)
+	idx, err := specutil.Index(spec, parent)
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := spec.Extra.Attr("constraint"); ok {
+		v, err := a.Bool()
+		if err != nil {
+			return nil, err
+		}
+		if v && !idx.Unique {
+			return nil, fmt.Errorf("index %q must be unique to be defined as a constraint", idx.Name)
+		}
+		if v {
+			idx.Attrs = append(idx.Attrs, &ConType{T: "u"})
+		}
+	}
+	if a, ok := spec.Extra.Attr("include"); ok {
+		refs, ok := a.V.(*schemaspec.ListValue)
+		if !ok {
+			return nil, fmt.Errorf("expect list of column references for attribute %q of index %q", a.K, idx.Name)
+		}
+		inc := &IndexInclude{}
+		for _, v := range refs.V {
+			ref, ok := v.(*schemaspec.Ref)
+			if !ok {
+				return nil, fmt.Errorf("expect column reference for attribute %q of index %q", a.K, idx.Name)
+			}
+			c, err := specutil.ColumnByRef(parent, ref)
+			if err != nil {
+				return nil, err
+			}
+			inc.Columns = append(inc.Columns, c)
+		}
+		idx.Attrs = append(idx.Attrs, inc)
+	}
+	if a, ok := spec.Extra.Attr("nulls_distinct"); ok {
+		v, err := a.Bool()
+		if err != nil {
+			return nil, err
+		}
+		if !v {
+			idx.Attrs = append(idx.Attrs, &IndexNullsDistinct{V: false})
+		}
+	}
+	d, err := convertDeferrable(&spec.Extra)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		if !uniqueConstraint(idx) {
+			return nil, fmt.Errorf("index %q must be defined as a constraint to be deferrable", idx.Name)
+		}
+		idx.Attrs = append(idx.Attrs, d)
+	}
+	return idx, nil
 }
 
 // convertColumn converts a sqlspec.Column into a schema.Column.
-func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
-	return specutil.Column(spec, convertColumnType)
+func convertColumn(spec *sqlspec.Column, t *schema.Table) (*schema.Column, error) {
+	c, err := specutil.Column(spec, convertColumnType)
+	if err != nil {
+		return nil, err
+	}
+	// Enum types are created separately from the table. Hence, portable
+	// enums (that have no name) are named after their table and column.
+	if e, ok := c.Type.Type.(*schema.EnumType); ok && e.T == "" && t != nil {
+		e.T = fmt.Sprintf("%s_%s", t.Name, spec.Name)
+	}
+	for _, r := range spec.Extra.Children {
+		if r.Type != "identity" {
+			continue
+		}
+		var id identitySpec
+		if err := r.As(&id); err != nil {
+			return nil, fmt.Errorf("postgres: failed reading identity of column %q: %w", spec.Name, err)
+		}
+		c.Attrs = append(c.Attrs, &Identity{
+			Generation: strings.ToUpper(id.Generated),
+			Start:      int64(id.Start),
+			Increment:  int64(id.Increment),
+		})
+	}
+	return c, nil
 }
 
 // convertColumnType converts a sqlspec.Column into a concrete Postgres schema.Type.
@@ -107,24 +693,23 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 	}
 }
 
+// convertInteger converts a portable integer type into a Postgres integer type.
+// Since Postgres does not support 1-byte integers nor unsigned integers, these
+// types are mapped to the smallest signed type that can hold their values.
 func convertInteger(spec *sqlspec.Column) (schema.Type, error) {
-	if strings.HasPrefix(spec.Type, "u") {
-		return nil, fmt.Errorf("unsigned integers currently not supported")
-	}
-	typ := &schema.IntegerType{}
 	switch sqlspec.Type(spec.Type) {
-	case sqlspec.TypeInt8:
-		return nil, fmt.Errorf("8-bit integers not supported")
-	case sqlspec.TypeInt16:
-		typ.T = tSmallInt
-	case sqlspec.TypeInt:
-		typ.T = tInteger
-	case sqlspec.TypeInt64:
-		typ.T = tBigInt
+	case sqlspec.TypeInt8, sqlspec.TypeUint8, sqlspec.TypeInt16:
+		return &schema.IntegerType{T: tSmallInt}, nil
+	case sqlspec.TypeInt, sqlspec.TypeUint16:
+		return &schema.IntegerType{T: tInteger}, nil
+	case sqlspec.TypeUint, sqlspec.TypeInt64:
+		return &schema.IntegerType{T: tBigInt}, nil
+	case sqlspec.TypeUint64:
+		// The maximum value of an unsigned 64-bit integer has 20 digits.
+		return &schema.DecimalType{T: tNumeric, Precision: 20}, nil
 	default:
 		return nil, fmt.Errorf("unknown integer column type %q", spec.Type)
 	}
-	return typ, nil
 }
 
 func convertString(spec *sqlspec.Column) (schema.Type, error) {
@@ -219,12 +804,179 @@ func parseRawType(typ string) (schema.Type, error) {
 
 // schemaSpec converts from a concrete Postgres schema to Atlas specification.
 func schemaSpec(schem *schema.Schema) (*sqlspec.Schema, []*sqlspec.Table, error) {
-	return specutil.FromSchema(schem, tableSpec)
+	sc, t, err := specutil.FromSchema(schem, tableSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+	sc.Extra.Children = append(sc.Extra.Children, specutil.FromGrants(schem.Attrs, nil)...)
+	return sc, t, nil
 }
 
 // tableSpec converts from a concrete Postgres sqlspec.Table to a schema.Table.
 func tableSpec(tab *schema.Table) (*sqlspec.Table, error) {
-	return specutil.FromTable(tab, columnSpec, specutil.FromPrimaryKey, specutil.FromIndex, specutil.FromForeignKey)
+	ts, err := specutil.FromTable(tab, columnSpec, specutil.FromPrimaryKey, indexSpec, foreignKeySpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range excludes(tab.Attrs) {
+		ts.Extra.Children = append(ts.Extra.Children, excludeResource(e, tab))
+	}
+	if p := partition(tab.Attrs); p != nil {
+		r, err := partitionResource(p, tab)
+		if err != nil {
+			return nil, err
+		}
+		ts.Extra.Children = append(ts.Extra.Children, r)
+	}
+	ts.Extra.Children = append(ts.Extra.Children, specutil.FromGrants(tab.Attrs, tab)...)
+	if r := rowSecurity(tab.Attrs); r != nil && (r.Enabled || r.Force) {
+		rs := &schemaspec.Resource{Type: "row_security"}
+		rs.SetAttr(specutil.LitAttr("enabled", strconv.FormatBool(r.Enabled)))
+		if r.Force {
+			rs.SetAttr(specutil.LitAttr("force", "true"))
+		}
+		ts.Extra.Children = append(ts.Extra.Children, rs)
+	}
+	for _, p := range policies(tab.Attrs) {
+		ts.Extra.Children = append(ts.Extra.Children, policyResource(p))
+	}
+	return ts, nil
+}
+
+// indexSpec converts a schema.Index into a sqlspec.Index, and sets
+// its constraint, include, nulls_distinct and deferrable attributes, if they were set.
+func indexSpec(idx *schema.Index) (*sqlspec.Index, error) {
+	spec, err := specutil.FromIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	if uniqueConstraint(idx) {
+		spec.Extra.SetAttr(specutil.LitAttr("constraint", "true"))
+	}
+	if inc, ok := indexInclude(idx.Attrs); ok && len(inc.Columns) > 0 {
+		columns := make([]schemaspec.Value, 0, len(inc.Columns))
+		for _, c := range inc.Columns {
+			columns = append(columns, specutil.ColumnRef(c, idx.Table))
+		}
+		spec.Extra.SetAttr(&schemaspec.Attr{K: "include", V: &schemaspec.ListValue{V: columns}})
+	}
+	if !nullsDistinct(idx.Attrs) {
+		spec.Extra.SetAttr(specutil.LitAttr("nulls_distinct", "false"))
+	}
+	deferrableSpec(&spec.Extra, idx.Attrs)
+	return spec, nil
+}
+
+// deferrableSpec sets the deferrable and initially_deferred
+// attributes of a constraint resource, if it is deferrable.
+func deferrableSpec(r *schemaspec.Resource, attrs []schema.Attr) {
+	if d := (Deferrable{}); sqlx.Has(attrs, &d) {
+		r.SetAttr(specutil.LitAttr("deferrable", "true"))
+		if d.InitiallyDeferred {
+			r.SetAttr(specutil.LitAttr("initially_deferred", "true"))
+		}
+	}
+}
+
+// foreignKeySpec converts a schema.ForeignKey into a sqlspec.ForeignKey
+// and sets its DEFERRABLE options and its match type, if they were set.
+func foreignKeySpec(fk *schema.ForeignKey) (*sqlspec.ForeignKey, error) {
+	spec, err := specutil.FromForeignKey(fk)
+	if err != nil {
+		return nil, err
+	}
+	deferrableSpec(&spec.Extra, fk.Attrs)
+	if m := (MatchType{}); sqlx.Has(fk.Attrs, &m) && matchType(m) != "SIMPLE" {
+		spec.Extra.SetAttr(specutil.StrAttr("match", m.T))
+	}
+	return spec, nil
+}
+
+// excludeResource converts an Exclude attribute of a table into an "exclude" resource.
+func excludeResource(e *Exclude, t *schema.Table) *schemaspec.Resource {
+	r := &schemaspec.Resource{Type: "exclude", Name: e.Name}
+	if e.T != "" {
+		r.SetAttr(specutil.StrAttr("type", e.T))
+	}
+	for _, el := range e.Elems {
+		c := &schemaspec.Resource{Type: "on"}
+		switch {
+		case el.C != nil:
+			c.SetAttr(&schemaspec.Attr{K: "column", V: specutil.ColumnRef(el.C, t)})
+		case el.X != nil:
+			c.SetAttr(specutil.StrAttr("expr", el.X.(*schema.RawExpr).X))
+		}
+		c.SetAttr(specutil.StrAttr("op", el.Op))
+		r.Children = append(r.Children, c)
+	}
+	if e.Where != "" {
+		r.SetAttr(specutil.StrAttr("where", e.Where))
+	}
+	if d := (Deferrable{}); sqlx.Has(e.Attrs, &d) {
+		r.SetAttr(specutil.LitAttr("deferrable", "true"))
+		if d.InitiallyDeferred {
+			r.SetAttr(specutil.LitAttr("initially_deferred", "true"))
+		}
+	}
+	return r
+}
+
+// policyResource converts a Policy attribute of a table into a "policy" resource.
+// Options that are set to their default values are omitted.
+func policyResource(p *Policy) *schemaspec.Resource {
+	r := &schemaspec.Resource{Type: "policy", Name: p.Name}
+	if as := policyAs(p); as != "PERMISSIVE" {
+		r.SetAttr(specutil.StrAttr("as", as))
+	}
+	if cmd := policyFor(p); cmd != "ALL" {
+		r.SetAttr(specutil.StrAttr("for", cmd))
+	}
+	if roles := policyRoles(p); len(roles) != 1 || roles[0] != "PUBLIC" {
+		quoted := make([]string, len(p.To))
+		for i := range p.To {
+			quoted[i] = strconv.Quote(p.To[i])
+		}
+		r.SetAttr(specutil.ListAttr("to", quoted...))
+	}
+	if p.Using != "" {
+		r.SetAttr(specutil.StrAttr("using", p.Using))
+	}
+	if p.Check != "" {
+		r.SetAttr(specutil.StrAttr("check", p.Check))
+	}
+	return r
+}
+
+// partitionResource converts a Partition attribute of a table into a "partition" resource.
+func partitionResource(p *Partition, t *schema.Table) (*schemaspec.Resource, error) {
+	r := &schemaspec.Resource{Type: "partition"}
+	r.SetAttr(specutil.StrAttr("type", p.T))
+	columns := make([]schemaspec.Value, 0, len(p.Parts))
+	exprs := make([]string, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		switch {
+		case part.C != nil:
+			columns = append(columns, specutil.ColumnRef(part.C, t))
+			exprs = append(exprs, strconv.Quote(part.C.Name))
+		case part.X != nil:
+			exprs = append(exprs, part.X.(*schema.RawExpr).X)
+		}
+	}
+	// Keys that contain expressions are written as a raw expression.
+	if len(columns) == len(p.Parts) {
+		r.SetAttr(&schemaspec.Attr{K: "columns", V: &schemaspec.ListValue{V: columns}})
+	} else {
+		r.SetAttr(specutil.StrAttr("expr", strings.Join(exprs, ", ")))
+	}
+	for _, d := range p.Defs {
+		c := &schemaspec.Resource{}
+		if err := c.Scan(&partitionDefSpec{Name: d.Name, Bound: d.Bound}); err != nil {
+			return nil, err
+		}
+		c.Type = "part"
+		r.Children = append(r.Children, c)
+	}
+	return r, nil
 }
 
 // columnSpec converts from a concrete Postgres schema.Column into a sqlspec.Column.
@@ -233,14 +985,28 @@ func columnSpec(col *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &sqlspec.Column{
+	spec := &sqlspec.Column{
 		Name: col.Name,
 		Type: ct.Type,
 		Null: col.Type.Null,
+		As:   specutil.FromGeneratedExpr(col, "STORED"),
 		DefaultExtension: schemaspec.DefaultExtension{
 			Extra: schemaspec.Resource{Attrs: ct.DefaultExtension.Extra.Attrs},
 		},
-	}, nil
+	}
+	if i := (&Identity{}); sqlx.Has(col.Attrs, i) {
+		id := identity(i)
+		r := &schemaspec.Resource{Type: "identity"}
+		if err := r.Scan(&identitySpec{
+			Generated: id.Generation,
+			Start:     int(id.Start),
+			Increment: int(id.Increment),
+		}); err != nil {
+			return nil, err
+		}
+		spec.Extra.Children = append(spec.Extra.Children, r)
+	}
+	return spec, nil
 }
 
 // columnTypeSpec converts from a concrete Postgres schema.Type into sqlspec.Column Type.
@@ -285,6 +1051,14 @@ func columnTypeSpec(t schema.Type) (*sqlspec.Column, error) {
 		return &sqlspec.Column{Type: t.T}, nil
 	case *UserDefinedType:
 		return &sqlspec.Column{Type: t.T}, nil
+	// Domain, composite and range types are referenced by their names,
+	// and their definitions are marshaled as top-level blocks.
+	case *DomainType:
+		return &sqlspec.Column{Type: t.T}, nil
+	case *CompositeType:
+		return &sqlspec.Column{Type: t.T}, nil
+	case *RangeType:
+		return &sqlspec.Column{Type: t.T}, nil
 	case *XMLType:
 		return &sqlspec.Column{Type: t.T}, nil
 	default:
@@ -421,3 +1195,20 @@ var TypeRegistry = specutil.NewRegistry(
 	specutil.TypeSpec(tUUID),
 	specutil.TypeSpec(tMoney),
 )
+
+// fkTypesCompatible reports if a foreign-key column of type t1 can reference a column
+// of type t2. Integer types (including serials) of different sizes are comparable in
+// PostgreSQL, and therefore considered compatible.
+func fkTypesCompatible(t1, t2 schema.Type) bool {
+	isInt := func(t schema.Type) bool {
+		switch t.(type) {
+		case *schema.IntegerType, *SerialType:
+			return true
+		}
+		return false
+	}
+	if isInt(t1) || isInt(t2) {
+		return isInt(t1) && isInt(t2)
+	}
+	return reflect.TypeOf(t1) == reflect.TypeOf(t2)
+}
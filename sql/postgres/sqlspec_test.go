@@ -165,6 +165,530 @@ table "accounts" {
 	require.EqualValues(t, exp, &s)
 }
 
+func TestMarshalSpec_IdentityColumn(t *testing.T) {
+	s := &schema.Schema{
+		Name: "test",
+		Tables: []*schema.Table{
+			{
+				Name: "users",
+				Columns: []*schema.Column{
+					{
+						Name: "id",
+						Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}},
+						Attrs: []schema.Attr{
+							&Identity{Generation: "ALWAYS", Start: 100, Increment: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+	s.Tables[0].Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = "int"
+    identity {
+      generated = "ALWAYS"
+      start     = 100
+      increment = 1
+    }
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&Identity{Generation: "ALWAYS", Start: 100, Increment: 1}}, users.Columns[0].Attrs)
+}
+
+func TestMarshalSpec_Extension(t *testing.T) {
+	s := &schema.Schema{
+		Name: "public",
+		Attrs: []schema.Attr{
+			&Extension{Name: "citext", Version: "1.6"},
+			&Extension{Name: "uuid-ossp", Version: "1.1"},
+		},
+	}
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `schema "public" {
+}
+extension "citext" {
+  schema  = schema.public
+  version = "1.6"
+}
+extension "uuid-ossp" {
+  schema  = schema.public
+  version = "1.1"
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	require.Equal(t, s.Attrs, s2.Attrs)
+
+	s2 = schema.Schema{}
+	err = UnmarshalSpec([]byte(`
+schema "public" {}
+extension "pgcrypto" {}
+`), schemahcl.Unmarshal, &s2)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{&Extension{Name: "pgcrypto"}}, s2.Attrs)
+
+	err = UnmarshalSpec([]byte(`
+schema "public" {}
+schema "other" {}
+extension "pgcrypto" {
+  schema = schema.other
+}
+`), schemahcl.Unmarshal, &s2)
+	require.EqualError(t, err, "postgres: expecting document to contain a single schema, got 2")
+	err = UnmarshalSpec(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		*v.(*doc) = doc{
+			Schemas:    []*sqlspec.Schema{{Name: "public"}},
+			Extensions: []*extensionSpec{{Name: "pgcrypto", Schema: specutil.SchemaRef("other")}},
+		}
+		return nil
+	}), &s2)
+	require.EqualError(t, err, `postgres: extension "pgcrypto" references schema "other", but the document describes schema "public"`)
+}
+
+func TestMarshalSpec_UserTypes(t *testing.T) {
+	var (
+		domain = &DomainType{
+			T:       "posint",
+			Base:    &schema.IntegerType{T: tInteger},
+			NotNull: true,
+			Default: &schema.RawExpr{X: "1"},
+			Checks:  []*Check{{Name: "positive", Clause: "(VALUE > 0)"}},
+		}
+		address = &CompositeType{
+			T: "address",
+			Fields: []*schema.Column{
+				{Name: "street", Type: &schema.ColumnType{Type: &schema.StringType{T: tText}}},
+				{Name: "zip", Type: &schema.ColumnType{Type: domain}},
+			},
+		}
+		floatrange = &RangeType{T: "floatrange", Subtype: &schema.FloatType{T: tDouble}}
+		users      = &schema.Table{
+			Name: "users",
+			Columns: []*schema.Column{
+				{Name: "age", Type: &schema.ColumnType{Type: domain}},
+				{Name: "address", Type: &schema.ColumnType{Type: address}},
+				{Name: "scores", Type: &schema.ColumnType{Type: floatrange}},
+			},
+		}
+		s = &schema.Schema{Name: "public", Tables: []*schema.Table{users}}
+	)
+	users.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "age" {
+    null = false
+    type = "posint"
+  }
+  column "address" {
+    null = false
+    type = "address"
+  }
+  column "scores" {
+    null = false
+    type = "floatrange"
+  }
+}
+schema "public" {
+}
+domain "posint" {
+  type     = "integer"
+  not_null = true
+  default  = "1"
+  check "positive" {
+    expr = "(VALUE > 0)"
+  }
+}
+composite "address" {
+  field "street" {
+    type = "text"
+  }
+  field "zip" {
+    type = "posint"
+  }
+}
+range "floatrange" {
+  subtype = "double precision"
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users2, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Equal(t, domain, users2.Columns[0].Type.Type)
+	require.Equal(t, address, users2.Columns[1].Type.Type)
+	require.Equal(t, floatrange, users2.Columns[2].Type.Type)
+}
+
+func TestMarshalSpec_Grants(t *testing.T) {
+	users := &schema.Table{
+		Name: "users",
+		Columns: []*schema.Column{
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+		},
+	}
+	users.Attrs = []schema.Attr{
+		&schema.Grant{Grantee: "app", Privileges: []string{"SELECT", "INSERT"}},
+		&schema.Grant{Grantee: "reporter", Privileges: []string{"SELECT"}, Columns: users.Columns},
+	}
+	r := &schema.Realm{Attrs: []schema.Attr{&schema.Role{Name: "app"}, &schema.Role{Name: "admin"}, &schema.Role{Name: "reporter"}}}
+	s := &schema.Schema{
+		Name:   "public",
+		Realm:  r,
+		Tables: []*schema.Table{users},
+		Attrs: []schema.Attr{
+			&schema.Grant{Grantee: "app", Privileges: []string{"USAGE"}, GrantOption: true},
+		},
+	}
+	users.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "created_at" {
+    null = false
+    type = "date"
+  }
+  grant "app" {
+    privileges = ["SELECT", "INSERT", ]
+  }
+  grant "reporter" {
+    privileges = ["SELECT", ]
+    columns    = [table.users.column.created_at, ]
+  }
+}
+schema "public" {
+  grant "app" {
+    privileges   = ["USAGE", ]
+    grant_option = true
+  }
+}
+role "app" {
+}
+role "reporter" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	require.Equal(t, []schema.Attr{
+		&schema.Role{Name: "app"},
+		&schema.Role{Name: "reporter"},
+		&schema.Grant{Grantee: "app", Privileges: []string{"USAGE"}, GrantOption: true},
+	}, s2.Attrs)
+	require.Equal(t, users.Attrs[0], s2.Tables[0].Attrs[0])
+	require.Equal(t, []*schema.Column{s2.Tables[0].Columns[0]}, s2.Tables[0].Attrs[1].(*schema.Grant).Columns)
+
+	err = UnmarshalSpec([]byte(`
+schema "public" {
+  grant "app" {
+    privileges = ["USAGE"]
+    columns    = [table.users.column.created_at]
+  }
+}
+`), schemahcl.Unmarshal, &s2)
+	require.EqualError(t, err, `postgres: failed reading grants of schema "public": specutil: unexpected columns in schema grant of grantee "app"`)
+}
+
+func TestMarshalSpec_Partition(t *testing.T) {
+	logs := &schema.Table{
+		Name: "logs",
+		Columns: []*schema.Column{
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+			{Name: "name", Type: &schema.ColumnType{Type: &schema.StringType{T: "text"}}},
+		},
+	}
+	logs.Attrs = []schema.Attr{
+		&Partition{
+			T:     "RANGE",
+			Parts: []*PartitionPart{{C: logs.Columns[0]}},
+			Defs: []*PartitionDef{
+				{Name: "logs_2021_01", Bound: "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')"},
+				{Name: "logs_default", Bound: "DEFAULT"},
+			},
+		},
+	}
+	s := &schema.Schema{Name: "test", Tables: []*schema.Table{logs}}
+	logs.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "logs" {
+  schema = schema.test
+  column "created_at" {
+    null = false
+    type = "date"
+  }
+  column "name" {
+    null = false
+    type = "string"
+    size = 0
+  }
+  partition {
+    type    = "RANGE"
+    columns = [table.logs.column.created_at, ]
+    part "logs_2021_01" {
+      bound = "FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')"
+    }
+    part "logs_default" {
+      bound = "DEFAULT"
+    }
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	logs2, ok := s2.Table("logs")
+	require.True(t, ok)
+	p := partition(logs2.Attrs)
+	require.NotNil(t, p)
+	require.Equal(t, "RANGE", p.T)
+	require.Len(t, p.Parts, 1)
+	require.Equal(t, logs2.Columns[0], p.Parts[0].C)
+	require.Equal(t, logs.Attrs[0].(*Partition).Defs, p.Defs)
+
+	// Partition keys with expressions.
+	logs.Attrs[0].(*Partition).Parts = append(logs.Attrs[0].(*Partition).Parts, &PartitionPart{X: &schema.RawExpr{X: "lower(name)"}})
+	buf, err = MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `expr = "\"created_at\", lower(name)"`)
+	s2 = schema.Schema{}
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	p = partition(s2.Tables[0].Attrs)
+	require.Equal(t, []*PartitionPart{{X: &schema.RawExpr{X: `"created_at", lower(name)`}}}, p.Parts)
+}
+
+func TestMarshalSpec_Policies(t *testing.T) {
+	users := &schema.Table{
+		Name: "users",
+		Columns: []*schema.Column{
+			{Name: "created_at", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+		},
+		Attrs: []schema.Attr{
+			&RowSecurity{Enabled: true, Force: true},
+			&Policy{Name: "recent", As: "PERMISSIVE", For: "ALL", To: []string{"PUBLIC"}, Using: "(created_at > '2021-01-01'::date)"},
+			&Policy{Name: "readonly", As: "RESTRICTIVE", For: "SELECT", To: []string{"app", "reporter"}, Using: "true", Check: "false"},
+		},
+	}
+	s := &schema.Schema{Name: "test", Tables: []*schema.Table{users}}
+	users.Schema = s
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "created_at" {
+    null = false
+    type = "date"
+  }
+  row_security {
+    enabled = true
+    force   = true
+  }
+  policy "recent" {
+    using = "(created_at > '2021-01-01'::date)"
+  }
+  policy "readonly" {
+    as    = "RESTRICTIVE"
+    for   = "SELECT"
+    to    = ["app", "reporter", ]
+    using = "true"
+    check = "false"
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users2, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Equal(t, &RowSecurity{Enabled: true, Force: true}, rowSecurity(users2.Attrs))
+	require.Equal(t, []*Policy{
+		{Name: "recent", Using: "(created_at > '2021-01-01'::date)"},
+		{Name: "readonly", As: "RESTRICTIVE", For: "SELECT", To: []string{"app", "reporter"}, Using: "true", Check: "false"},
+	}, policies(users2.Attrs))
+	require.Empty(t, policyChanges(users.Attrs, users2.Attrs))
+
+	err = UnmarshalSpec([]byte(`
+schema "test" {}
+table "users" {
+  schema = schema.test
+  policy "p" {
+    for = "TRUNCATE"
+  }
+}
+`), schemahcl.Unmarshal, &s2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unexpected policy command "TRUNCATE" for policy "p"`)
+}
+
+func TestMarshalSpec_Constraints(t *testing.T) {
+	s := &schema.Schema{Name: "test"}
+	rooms := &schema.Table{Name: "rooms", Schema: s, Columns: []*schema.Column{{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}}}}
+	bookings := &schema.Table{Name: "bookings", Schema: s, Columns: []*schema.Column{{Name: "room_id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}}}}
+	bookings.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "room_fk", Table: bookings, Columns: bookings.Columns, RefTable: rooms, RefColumns: rooms.Columns, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, Attrs: []schema.Attr{&Deferrable{InitiallyDeferred: true}, &MatchType{T: "FULL"}}},
+	}
+	bookings.Attrs = []schema.Attr{
+		&Exclude{
+			Name:  "no_overlap",
+			T:     "gist",
+			Elems: []*ExcludeElem{{C: bookings.Columns[0], Op: "="}, {X: &schema.RawExpr{X: "tsrange(start_at, end_at)"}, Op: "&&"}},
+			Where: "(room_id > 0)",
+			Attrs: []schema.Attr{&Deferrable{}},
+		},
+	}
+	s.Tables = []*schema.Table{bookings, rooms}
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "bookings" {
+  schema = schema.test
+  column "room_id" {
+    null = false
+    type = "int"
+  }
+  foreign_key "room_fk" {
+    columns            = [table.bookings.column.room_id, ]
+    ref_columns        = [table.rooms.column.id, ]
+    on_update          = "NO ACTION"
+    on_delete          = "CASCADE"
+    deferrable         = true
+    initially_deferred = true
+    match              = "FULL"
+  }
+  exclude "no_overlap" {
+    type       = "gist"
+    where      = "(room_id > 0)"
+    deferrable = true
+    on {
+      column = table.bookings.column.room_id
+      op     = "="
+    }
+    on {
+      expr = "tsrange(start_at, end_at)"
+      op   = "&&"
+    }
+  }
+}
+table "rooms" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = "int"
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	bookings2, ok := s2.Table("bookings")
+	require.True(t, ok)
+	require.Equal(t, bookings.ForeignKeys[0].Attrs, bookings2.ForeignKeys[0].Attrs)
+	e := excludes(bookings2.Attrs)
+	require.Len(t, e, 1)
+	require.Equal(t, bookings2.Columns[0], e[0].Elems[0].C)
+	require.Empty(t, excludeChanges(bookings.Attrs, bookings2.Attrs))
+}
+
+func TestMarshalSpec_UniqueConstraints(t *testing.T) {
+	s := &schema.Schema{Name: "test"}
+	users := &schema.Table{
+		Name:   "users",
+		Schema: s,
+		Columns: []*schema.Column{
+			{Name: "id", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+			{Name: "code", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}},
+		},
+	}
+	users.Indexes = []*schema.Index{
+		{Name: "users_code_key", Unique: true, Table: users, Parts: []*schema.IndexPart{{C: users.Columns[1]}}, Attrs: []schema.Attr{&ConType{T: "u"}, &IndexInclude{Columns: users.Columns[:1]}, &IndexNullsDistinct{}, &Deferrable{}}},
+		{Name: "users_id_idx", Unique: true, Table: users, Parts: []*schema.IndexPart{{C: users.Columns[0]}}},
+	}
+	s.Tables = []*schema.Table{users}
+	buf, err := MarshalSpec(s, schemahcl.Marshal)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = "int"
+  }
+  column "code" {
+    null = false
+    type = "int"
+  }
+  index "users_code_key" {
+    unique         = true
+    columns        = [table.users.column.code, ]
+    constraint     = true
+    include        = [table.users.column.id, ]
+    nulls_distinct = false
+    deferrable     = true
+  }
+  index "users_id_idx" {
+    unique  = true
+    columns = [table.users.column.id, ]
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var s2 schema.Schema
+	require.NoError(t, UnmarshalSpec(buf, schemahcl.Unmarshal, &s2))
+	users2, ok := s2.Table("users")
+	require.True(t, ok)
+	require.Len(t, users2.Indexes, 2)
+	require.True(t, uniqueConstraint(users2.Indexes[0]))
+	require.False(t, uniqueConstraint(users2.Indexes[1]))
+	require.False(t, (&diff{}).IndexAttrChanged(users.Indexes[0].Attrs, users2.Indexes[0].Attrs))
+	require.False(t, (&diff{}).IndexAttrChanged(users.Indexes[1].Attrs, users2.Indexes[1].Attrs))
+
+	err = UnmarshalSpec([]byte(`
+schema "test" {}
+table "users" {
+  schema = schema.test
+  column "id" {
+    type = "int"
+  }
+  index "users_id_idx" {
+    columns    = [table.users.column.id]
+    deferrable = true
+  }
+}
+`), schemahcl.Unmarshal, &s2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `index "users_id_idx" must be defined as a constraint to be deferrable`)
+}
+
 func TestUnmarshalSpecColumnTypes(t *testing.T) {
 	for _, tt := range []struct {
 		spec     *sqlspec.Column
@@ -177,6 +701,26 @@ func TestUnmarshalSpecColumnTypes(t *testing.T) {
 				Unsigned: false,
 			},
 		},
+		{
+			spec:     specutil.NewCol("int8", "int8"),
+			expected: &schema.IntegerType{T: tSmallInt},
+		},
+		{
+			spec:     specutil.NewCol("uint8", "uint8"),
+			expected: &schema.IntegerType{T: tSmallInt},
+		},
+		{
+			spec:     specutil.NewCol("uint16", "uint16"),
+			expected: &schema.IntegerType{T: tInteger},
+		},
+		{
+			spec:     specutil.NewCol("uint", "uint"),
+			expected: &schema.IntegerType{T: tBigInt},
+		},
+		{
+			spec:     specutil.NewCol("uint64", "uint64"),
+			expected: &schema.DecimalType{T: tNumeric, Precision: 20},
+		},
 		{
 			spec: specutil.NewCol("string_varchar", "string", specutil.LitAttr("size", "255")),
 			expected: &schema.StringType{
@@ -208,7 +752,7 @@ func TestUnmarshalSpecColumnTypes(t *testing.T) {
 		},
 		{
 			spec:     specutil.NewCol("enum", "enum", specutil.ListAttr("values", `"a"`, `"b"`, `"c"`)),
-			expected: &schema.EnumType{Values: []string{"a", "b", "c"}},
+			expected: &schema.EnumType{T: "table_enum", Values: []string{"a", "b", "c"}},
 		},
 		{
 			spec:     specutil.NewCol("bool", "boolean"),
@@ -272,33 +816,6 @@ func TestUnmarshalSpecColumnTypes(t *testing.T) {
 	}
 }
 
-func TestNotSupportedUnmarshalSpecColumnTypes(t *testing.T) {
-	for _, tt := range []struct {
-		spec        *sqlspec.Column
-		expectedErr string
-	}{
-		{
-			spec:        specutil.NewCol("uint", "uint"),
-			expectedErr: "postgres: failed converting to *schema.Schema: unsigned integers currently not supported",
-		},
-		{
-			spec:        specutil.NewCol("int8", "int8"),
-			expectedErr: "postgres: failed converting to *schema.Schema: 8-bit integers not supported",
-		},
-
-		{
-			spec:        specutil.NewCol("uint64", "uint64"),
-			expectedErr: "postgres: failed converting to *schema.Schema: unsigned integers currently not supported",
-		},
-	} {
-		t.Run(tt.spec.Name, func(t *testing.T) {
-			var s schema.Schema
-			err := UnmarshalSpec(hcl(tt.spec), schemahcl.Unmarshal, &s)
-			require.Equal(t, tt.expectedErr, err.Error())
-		})
-	}
-}
-
 // hcl returns an Atlas HCL document containing the column spec.
 func hcl(c *sqlspec.Column) []byte {
 	buf, err := schemahcl.Marshal(c)
@@ -317,6 +834,36 @@ table "table" {
 	return []byte(body)
 }
 
+func TestUnmarshalSpec_UnknownType(t *testing.T) {
+	var s schema.Schema
+	err := UnmarshalSpec([]byte(`
+schema "s" {}
+table "users" {
+  schema = schema.s
+  column "name" {
+    type = "varchr(255)"
+  }
+  column "email" {
+    type = "citext"
+  }
+}
+`), hclState, &s)
+	require.EqualError(t, err, `postgres: 6:12: unknown type "varchr(255)" for column users.name, did you mean "varchar"?`)
+
+	// Types that are not defined in the document are expected to exist in the database.
+	err = UnmarshalSpec([]byte(`
+schema "s" {}
+table "users" {
+  schema = schema.s
+  column "email" {
+    type = "citext"
+  }
+}
+`), hclState, &s)
+	require.NoError(t, err)
+	require.Equal(t, &UserDefinedType{T: "citext"}, s.Tables[0].Columns[0].Type.Type)
+}
+
 func TestMarshalSpecColumnType(t *testing.T) {
 	for _, tt := range []struct {
 		schem    schema.Type
@@ -474,6 +1021,26 @@ func TestMarshalSpecColumnType(t *testing.T) {
 			},
 			expected: specutil.NewCol("bitvar8", "bit varying(8)"),
 		},
+		{
+			schem: &DomainType{
+				T:    "posint",
+				Base: &schema.IntegerType{T: tInteger},
+			},
+			expected: specutil.NewCol("domain", "posint"),
+		},
+		{
+			schem: &CompositeType{
+				T: "address",
+			},
+			expected: specutil.NewCol("composite", "address"),
+		},
+		{
+			schem: &RangeType{
+				T:       "floatrange",
+				Subtype: &schema.FloatType{T: tDouble},
+			},
+			expected: specutil.NewCol("range", "floatrange"),
+		},
 	} {
 		t.Run(tt.expected.Name, func(t *testing.T) {
 			s := schema.Schema{
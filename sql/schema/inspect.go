@@ -8,6 +8,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"path"
+	"strings"
 )
 
 // A NotExistError wraps another error to retain its original text
@@ -41,6 +44,13 @@ type (
 	InspectOptions struct {
 		// Tables to inspect. Empty means all tables in the schema.
 		Tables []string
+
+		// Include and Exclude hold glob patterns (see path.Match) of tables to
+		// include in or exclude from the result. Tables are matched by patterns
+		// in the form of "table" or "schema.table", and columns are matched by
+		// patterns in the form of "schema.table.column". See FilterSchema.
+		Include []string
+		Exclude []string
 	}
 
 	// InspectTableOptions describes options for TableInspector.
@@ -53,6 +63,11 @@ type (
 	InspectRealmOption struct {
 		// Schemas to inspect. Empty means all tables in the schema.
 		Schemas []string
+
+		// Include and Exclude hold glob patterns of tables and columns
+		// to filter in all schemas. See InspectOptions for details.
+		Include []string
+		Exclude []string
 	}
 
 	// Inspector is the interface implemented by the different database
@@ -70,3 +85,176 @@ type (
 		InspectRealm(ctx context.Context, opts *InspectRealmOption) (*Realm, error)
 	}
 )
+
+// FilterSchema removes from the schema the tables and columns that do not match any of the
+// include patterns (if any), or match one of the exclude patterns. Patterns are matched using
+// path.Match, and are in one of the following forms: "table", "schema.table" or
+// "schema.table.column". Column patterns filter only columns, and indexes and foreign keys
+// that use removed columns are removed as well. Foreign keys that reference removed tables
+// or columns are also removed.
+func FilterSchema(s *Schema, include, exclude []string) error {
+	in, err := parsePatterns(include)
+	if err != nil {
+		return err
+	}
+	ex, err := parsePatterns(exclude)
+	if err != nil {
+		return err
+	}
+	var (
+		tables         = s.Tables[:0]
+		removedTables  = make(map[*Table]bool)
+		removedColumns = make(map[*Column]bool)
+	)
+	for _, t := range s.Tables {
+		if !in.tableIncluded(s.Name, t.Name) || ex.matchTable(s.Name, t.Name) {
+			removedTables[t] = true
+			continue
+		}
+		filterColumns(s, t, in, ex, removedColumns)
+		tables = append(tables, t)
+	}
+	s.Tables = tables
+	for _, t := range s.Tables {
+		filterForeignKeys(t, removedTables, removedColumns)
+	}
+	return nil
+}
+
+type (
+	// pattern is a parsed table or column pattern.
+	pattern struct {
+		schema, table, column string
+	}
+	// patterns holds the parsed table and column patterns.
+	patterns struct {
+		tables, columns []pattern
+	}
+)
+
+// parsePatterns parses and validates the given patterns.
+func parsePatterns(ps []string) (*patterns, error) {
+	var r patterns
+	for _, p := range ps {
+		parts := strings.Split(p, ".")
+		for _, part := range parts {
+			if _, err := path.Match(part, ""); err != nil || part == "" {
+				return nil, fmt.Errorf("invalid pattern %q", p)
+			}
+		}
+		switch len(parts) {
+		case 1:
+			r.tables = append(r.tables, pattern{schema: "*", table: parts[0]})
+		case 2:
+			r.tables = append(r.tables, pattern{schema: parts[0], table: parts[1]})
+		case 3:
+			r.columns = append(r.columns, pattern{schema: parts[0], table: parts[1], column: parts[2]})
+		default:
+			return nil, fmt.Errorf("invalid pattern %q: expect table, schema.table or schema.table.column", p)
+		}
+	}
+	return &r, nil
+}
+
+// tableIncluded reports if the table is included by the table patterns.
+// All tables are included if there are no table patterns.
+func (p *patterns) tableIncluded(schema, table string) bool {
+	return len(p.tables) == 0 || p.matchTable(schema, table)
+}
+
+// matchTable reports if the table matches one of the table patterns.
+func (p *patterns) matchTable(schema, table string) bool {
+	for _, tp := range p.tables {
+		if match(tp.schema, schema) && match(tp.table, table) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableColumns returns the column patterns of the given table.
+func (p *patterns) tableColumns(schema, table string) []string {
+	var columns []string
+	for _, cp := range p.columns {
+		if match(cp.schema, schema) && match(cp.table, table) {
+			columns = append(columns, cp.column)
+		}
+	}
+	return columns
+}
+
+// filterColumns removes the columns of the table that are filtered by the column
+// patterns, along with its indexes that use them, and records them in removed.
+func filterColumns(s *Schema, t *Table, include, exclude *patterns, removed map[*Column]bool) {
+	in, ex := include.tableColumns(s.Name, t.Name), exclude.tableColumns(s.Name, t.Name)
+	if len(in) == 0 && len(ex) == 0 {
+		return
+	}
+	// A new slice is allocated, as other elements (e.g. foreign
+	// keys) may share the backing array of the table columns.
+	columns := make([]*Column, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		if len(in) > 0 && !matchAny(in, c.Name) || matchAny(ex, c.Name) {
+			removed[c] = true
+			continue
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == len(t.Columns) {
+		return
+	}
+	t.Columns = columns
+	uses := func(idx *Index) bool {
+		for _, p := range idx.Parts {
+			if p.C != nil && removed[p.C] {
+				return true
+			}
+		}
+		return false
+	}
+	if t.PrimaryKey != nil && uses(t.PrimaryKey) {
+		t.PrimaryKey = nil
+	}
+	indexes := t.Indexes[:0]
+	for _, idx := range t.Indexes {
+		if !uses(idx) {
+			indexes = append(indexes, idx)
+		}
+	}
+	t.Indexes = indexes
+}
+
+// filterForeignKeys removes the foreign keys of the table that use removed
+// columns, or reference removed tables or columns.
+func filterForeignKeys(t *Table, tables map[*Table]bool, columns map[*Column]bool) {
+	fks := t.ForeignKeys[:0]
+	for _, fk := range t.ForeignKeys {
+		used := tables[fk.RefTable]
+		for _, c := range fk.Columns {
+			used = used || columns[c]
+		}
+		for _, c := range fk.RefColumns {
+			used = used || columns[c]
+		}
+		if !used {
+			fks = append(fks, fk)
+		}
+	}
+	t.ForeignKeys = fks
+}
+
+// matchAny reports if the name matches one of the patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if match(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports if the name matches the pattern.
+func match(pattern, name string) bool {
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
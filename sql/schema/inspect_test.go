@@ -0,0 +1,95 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema_test
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterSchema(t *testing.T) {
+	newSchema := func() *schema.Schema {
+		s := &schema.Schema{Name: "app"}
+		users := &schema.Table{Name: "users", Schema: s}
+		users.Columns = []*schema.Column{{Name: "id"}, {Name: "name"}, {Name: "_tmp_flag"}}
+		users.PrimaryKey = &schema.Index{Parts: []*schema.IndexPart{{C: users.Columns[0]}}}
+		users.Indexes = []*schema.Index{
+			{Name: "users_name", Table: users, Parts: []*schema.IndexPart{{C: users.Columns[1]}}},
+			{Name: "users_flag", Table: users, Parts: []*schema.IndexPart{{C: users.Columns[2]}}},
+		}
+		pets := &schema.Table{Name: "pets", Schema: s}
+		pets.Columns = []*schema.Column{{Name: "id"}, {Name: "owner_id"}}
+		pets.ForeignKeys = []*schema.ForeignKey{
+			{Symbol: "owner", Table: pets, Columns: pets.Columns[1:], RefTable: users, RefColumns: users.Columns[:1]},
+		}
+		s.Tables = []*schema.Table{
+			users,
+			pets,
+			{Name: "_users_new", Schema: s},
+			{Name: "_pets_gho", Schema: s},
+			{Name: "tmp_import", Schema: s},
+		}
+		return s
+	}
+	names := func(s *schema.Schema) []string {
+		var names []string
+		for _, t := range s.Tables {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+
+	s := newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, nil))
+	require.Len(t, s.Tables, 5)
+
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, []string{"tmp_*", "*._*_gho", "_*_new"}))
+	require.Equal(t, []string{"users", "pets"}, names(s))
+
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, []string{"app.*s"}, []string{"other.users"}))
+	require.Equal(t, []string{"users", "pets"}, names(s))
+
+	// Columns are filtered along with the indexes and foreign keys that use them.
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, []string{"*.users._tmp_*", "*.pets.owner_id"}))
+	users, pets := s.Tables[0], s.Tables[1]
+	require.Len(t, users.Columns, 2)
+	require.Len(t, users.Indexes, 1)
+	require.Equal(t, "users_name", users.Indexes[0].Name)
+	require.NotNil(t, users.PrimaryKey)
+	require.Len(t, pets.Columns, 1)
+	require.Empty(t, pets.ForeignKeys)
+
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, []string{"app.users.name"}, nil))
+	users = s.Tables[0]
+	require.Len(t, s.Tables, 5)
+	require.Len(t, users.Columns, 1)
+	require.Nil(t, users.PrimaryKey)
+	require.Len(t, s.Tables[1].Columns, 2)
+
+	// Foreign keys that reference removed tables or columns are removed.
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, []string{"users"}))
+	require.Equal(t, []string{"pets", "_users_new", "_pets_gho", "tmp_import"}, names(s))
+	require.Empty(t, s.Tables[0].ForeignKeys)
+
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, []string{"app.users.id"}))
+	require.Len(t, s.Tables[1].Columns, 2)
+	require.Empty(t, s.Tables[1].ForeignKeys)
+
+	s = newSchema()
+	require.NoError(t, schema.FilterSchema(s, nil, []string{"app.users.name"}))
+	require.Len(t, s.Tables[1].ForeignKeys, 1)
+
+	require.Error(t, schema.FilterSchema(newSchema(), []string{"a.b.c.d"}, nil))
+	require.Error(t, schema.FilterSchema(newSchema(), nil, []string{"users["}))
+	require.Error(t, schema.FilterSchema(newSchema(), nil, []string{"app..id"}))
+}
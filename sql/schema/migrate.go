@@ -147,6 +147,11 @@ type (
 	// IfNotExists represents a clause in a schema change that is commonly
 	// supported by multiple statements (e.g. CREATE TABLE or CREATE SCHEMA).
 	IfNotExists struct{}
+
+	// Empty represents a clause in an AddSchema change that reports that the
+	// changes are executed on an empty database (e.g. when they are only planned),
+	// and therefore, drivers do not check the database for existing objects.
+	Empty struct{}
 )
 
 // A ChangeKind describes a change kind that can be combined
@@ -177,6 +182,8 @@ const (
 	ChangeType
 	// ChangeDefault describe a column default change.
 	ChangeDefault
+	// ChangeGenerated describe a change to the generated expression of a column.
+	ChangeGenerated
 
 	// Index specific changes.
 
@@ -207,6 +214,13 @@ func (k ChangeKind) Is(c ChangeKind) bool {
 	return k == c || k&c != 0
 }
 
+// WithDiffOptions returns a DiffOption that sets the given options.
+func WithDiffOptions(o DiffOptions) DiffOption {
+	return func(opts *DiffOptions) {
+		*opts = o
+	}
+}
+
 type (
 	// Differ is the interface implemented by the different
 	// drivers for comparing and diffing schema top elements.
@@ -214,19 +228,43 @@ type (
 		// RealmDiff returns a diff report for migrating a realm
 		// (or a database) from state "from" to state "to". An error
 		// is returned if such step is not possible.
-		RealmDiff(from, to *Realm) ([]Change, error)
+		RealmDiff(from, to *Realm, opts ...DiffOption) ([]Change, error)
 
 		// SchemaDiff returns a diff report for migrating a schema
 		// from state "from" to state "to". An error is returned
 		// if such step is not possible.
-		SchemaDiff(from, to *Schema) ([]Change, error)
+		SchemaDiff(from, to *Schema, opts ...DiffOption) ([]Change, error)
 
 		// TableDiff returns a diff report for migrating a table
 		// from state "from" to state "to". An error is returned
 		// if such step is not possible.
-		TableDiff(from, to *Table) ([]Change, error)
+		TableDiff(from, to *Table, opts ...DiffOption) ([]Change, error)
 	}
 
+	// DiffOptions describes options for Differ. The zero value reports all changes.
+	DiffOptions struct {
+		// SkipDrops skips changes that drop schemas, tables,
+		// columns, indexes and foreign keys.
+		SkipDrops bool
+
+		// SkipComments skips comment changes of all elements.
+		SkipComments bool
+
+		// SkipCharsets skips charset and collation changes of all elements.
+		SkipCharsets bool
+
+		// SkipIndexAttrs skips changes to index and index-part attributes.
+		// For example, the index type or the collation of its parts.
+		SkipIndexAttrs bool
+
+		// SkipGrants skips grant and revoke changes of existing schemas
+		// and tables. Privileges of added elements are still granted.
+		SkipGrants bool
+	}
+
+	// DiffOption configures the DiffOptions of a diff.
+	DiffOption func(*DiffOptions)
+
 	// Execer is the interface implemented by the different
 	// drivers for executing schema changes.
 	Execer interface {
@@ -260,3 +298,4 @@ func (*ModifyForeignKey) change() {}
 // clauses.
 func (*IfExists) clause()    {}
 func (*IfNotExists) clause() {}
+func (*Empty) clause()       {}
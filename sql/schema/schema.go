@@ -75,6 +75,7 @@ type (
 		RefColumns []*Column
 		OnUpdate   ReferenceOption
 		OnDelete   ReferenceOption
+		Attrs      []Attr
 	}
 )
 
@@ -182,6 +183,7 @@ type (
 	EnumType struct {
 		T      string   // Optional type.
 		Values []string // Enum values.
+		Schema *Schema  // Optional schema.
 	}
 
 	// BinaryType represents a type that stores a binary data.
@@ -279,6 +281,28 @@ type (
 	Collation struct {
 		V string
 	}
+
+	// GeneratedExpr describes the expression used for generating
+	// the value of a generated/virtual column.
+	GeneratedExpr struct {
+		Expr string
+		Type string // Optional type. e.g. STORED or VIRTUAL.
+	}
+
+	// Grant describes a set of privileges that were granted on a schema or a
+	// table to a role (or a user). If Columns is not empty, the privileges are
+	// granted only on these columns of the table.
+	Grant struct {
+		Grantee     string
+		Privileges  []string
+		Columns     []*Column
+		GrantOption bool // WITH GRANT OPTION.
+	}
+
+	// Role describes a database role (or a user) that privileges can be granted to.
+	Role struct {
+		Name string
+	}
 )
 
 // expressions.
@@ -299,6 +323,9 @@ func (*DecimalType) typ()     {}
 func (*UnsupportedType) typ() {}
 
 // attributes.
-func (*Comment) attr()   {}
-func (*Charset) attr()   {}
-func (*Collation) attr() {}
+func (*Comment) attr()       {}
+func (*Charset) attr()       {}
+func (*Collation) attr()     {}
+func (*GeneratedExpr) attr() {}
+func (*Grant) attr()         {}
+func (*Role) attr()          {}
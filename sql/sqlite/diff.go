@@ -54,6 +54,7 @@ func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error)
 	if changed := d.defaultChanged(from, to); changed {
 		change |= schema.ChangeDefault
 	}
+	change |= sqlx.GeneratedChange(from.Attrs, to.Attrs, "VIRTUAL")
 	return change, nil
 }
 
@@ -48,6 +48,13 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 		s.Realm = realm
 	}
 	sqlx.LinkSchemaTables(realm.Schemas)
+	if opts != nil {
+		for _, s := range schemas {
+			if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return realm, nil
 }
 
@@ -78,6 +85,11 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		s.Tables = append(s.Tables, t)
 	}
 	sqlx.LinkSchemaTables(schemas)
+	if opts != nil {
+		if err := schema.FilterSchema(s, opts.Include, opts.Exclude); err != nil {
+			return nil, err
+		}
+	}
 	s.Realm = &schema.Realm{Schemas: schemas}
 	return s, nil
 }
@@ -127,20 +139,32 @@ func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
 			return fmt.Errorf("sqlite: %w", err)
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 	autoinc(t)
-	return rows.Err()
+	return fillGenExpr(t)
 }
 
+// genAlways is the suffix of the type of generated columns.
+const genAlways = "GENERATED ALWAYS"
+
 // addColumn scans the current row and adds a new column from it to the table.
 func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 	var (
 		nullable, primary   bool
+		hidden              sql.NullInt64
 		name, typ, defaults sql.NullString
 		err                 error
 	)
-	if err = rows.Scan(&name, &typ, &nullable, &defaults, &primary); err != nil {
+	if err = rows.Scan(&name, &typ, &nullable, &defaults, &primary, &hidden); err != nil {
 		return err
 	}
+	// The declared type of generated columns that were defined using the "GENERATED ALWAYS"
+	// keywords holds them as a suffix (e.g. "INT GENERATED ALWAYS"). See sqlite/build.c.
+	if n := len(typ.String) - len(genAlways); n >= 0 && strings.EqualFold(typ.String[n:], genAlways) {
+		typ.String = strings.TrimSpace(typ.String[:n])
+	}
 	c := &schema.Column{
 		Name: name.String,
 		Type: &schema.ColumnType{
@@ -157,6 +181,15 @@ func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 			X: defaults.String,
 		}
 	}
+	// The "hidden" column reports if the column is a generated column. See
+	// sqlite/pragma.c#sqlite3Pragma. The expression is extracted from the
+	// 'CREATE TABLE' statement in fillGenExpr.
+	switch hidden.Int64 {
+	case 2:
+		c.Attrs = append(c.Attrs, &schema.GeneratedExpr{Type: "VIRTUAL"})
+	case 3:
+		c.Attrs = append(c.Attrs, &schema.GeneratedExpr{Type: "STORED"})
+	}
 	// TODO(a8m): extract collation from 'CREATE TABLE' statement.
 	t.Columns = append(t.Columns, c)
 	if primary {
@@ -587,6 +620,45 @@ func fillConstName(t *schema.Table) error {
 	return nil
 }
 
+// fillGenExpr extracts the generation expressions of the
+// generated columns from the 'CREATE TABLE' statement.
+func fillGenExpr(t *schema.Table) error {
+	for _, c := range t.Columns {
+		for _, a := range c.Attrs {
+			x, ok := a.(*schema.GeneratedExpr)
+			if !ok {
+				continue
+			}
+			var stmt CreateStmt
+			if !sqlx.Has(t.Attrs, &stmt) {
+				return fmt.Errorf("sqlite: missing CREATE statement for table: %q", t.Name)
+			}
+			re, err := regexp.Compile(fmt.Sprintf("(?i)(?:[(,]\\s*)[\"`\\[]?%s[\"`\\]]?\\s+[^,]*?\\bAS\\s*\\(", regexp.QuoteMeta(c.Name)))
+			if err != nil {
+				return err
+			}
+			loc := re.FindStringIndex(stmt.S)
+			if loc == nil {
+				return fmt.Errorf("sqlite: missing generation expression for column %q", c.Name)
+			}
+			// Scan the expression until its wrapping parenthesis is closed.
+			for i, depth := loc[1], 1; i < len(stmt.S); i++ {
+				switch stmt.S[i] {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+				if depth == 0 {
+					x.Expr = stmt.S[loc[1]:i]
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // columns from the matched regex above.
 func columns(s string) []string {
 	names := strings.Split(s, ",")
@@ -620,7 +692,7 @@ const (
 	// Query to list database tables.
 	tablesQuery = "SELECT `name`, `sql` FROM sqlite_master WHERE `type`='table' AND `name` NOT LIKE 'sqlite_%'"
 	// Query to list table information.
-	columnsQuery = "SELECT `name`, `type`, (not `notnull`) AS `nullable`, `dflt_value`, (`pk` <> 0) AS `pk`  FROM pragma_table_info('%s') ORDER BY `pk`, `cid`"
+	columnsQuery = "SELECT `name`, `type`, (not `notnull`) AS `nullable`, `dflt_value`, (`pk` <> 0) AS `pk`, `hidden` FROM pragma_table_xinfo('%s') ORDER BY `pk`, `cid`"
 	// Query to list table indexes.
 	indexesQuery = "SELECT `il`.`name`, `il`.`unique`, `il`.`origin`, `il`.`partial`, `m`.`sql` FROM pragma_index_list('%s') AS il JOIN sqlite_master AS m ON il.name = m.name"
 	// Query to list index columns.
@@ -42,19 +42,19 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.tableExists("users", true, "CREATE TABLE users(id INTEGER PRIMARY KEY AUTOINCREMENT)")
 				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
 					WillReturnRows(sqltest.Rows(`
- name |   type       | nullable | dflt_value  | primary 
-------+--------------+----------+ ------------+----------
- c1   | int           |  1      |             |  0
- c2   | integer       |  0      |             |  0
- c3   | varchar(100)  |  1      |             |  0
- c4   | boolean       |  0      |             |  0
- c5   | json          |  0      |             |  0
- c6   | datetime      |  0      |             |  0
- c7   | blob          |  0      |             |  0
- c8   | text          |  0      |             |  0
- c9   | numeric(10,2) |  0      |             |  0
- c10  | real          |  0      |             |  0
- id   | integer       |  0      |             |  1
+ name |   type       | nullable | dflt_value  | primary | hidden
+------+--------------+----------+ ------------+----------+--------
+ c1   | int           |  1      |             |  0      |  0
+ c2   | integer       |  0      |             |  0      |  0
+ c3   | varchar(100)  |  1      |             |  0      |  0
+ c4   | boolean       |  0      |             |  0      |  0
+ c5   | json          |  0      |             |  0      |  0
+ c6   | datetime      |  0      |             |  0      |  0
+ c7   | blob          |  0      |             |  0      |  0
+ c8   | text          |  0      |             |  0      |  0
+ c9   | numeric(10,2) |  0      |             |  0      |  0
+ c10  | real          |  0      |             |  0      |  0
+ id   | integer       |  0      |             |  1      |  0
 `))
 				m.noIndexes("users")
 				m.noFKs("users")
@@ -84,6 +84,31 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.PrimaryKey)
 			},
 		},
+		{
+			name: "generated columns",
+			before: func(m mock) {
+				m.systemVars("3.36.0")
+				m.tableExists("users", true, "CREATE TABLE users(c1 int, c2 int GENERATED ALWAYS AS (c1 * (c1 + 1)) VIRTUAL, `c3` int AS (abs(c1)) STORED)")
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
+					WillReturnRows(sqltest.Rows(`
+ name |   type       | nullable | dflt_value  | primary | hidden
+------+--------------+----------+ ------------+---------+--------
+ c1   | int           |  1      |             |  0      |  0
+ c2   | int GENERATED ALWAYS |  1      |             |  0      |  2
+ c3   | int           |  1      |             |  0      |  3
+`))
+				m.noIndexes("users")
+				m.noFKs("users")
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]*schema.Column{
+					{Name: "c1", Type: &schema.ColumnType{Null: true, Type: &schema.IntegerType{T: "int"}, Raw: "int"}},
+					{Name: "c2", Type: &schema.ColumnType{Null: true, Type: &schema.IntegerType{T: "int"}, Raw: "int"}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "c1 * (c1 + 1)", Type: "VIRTUAL"}}},
+					{Name: "c3", Type: &schema.ColumnType{Null: true, Type: &schema.IntegerType{T: "int"}, Raw: "int"}, Attrs: []schema.Attr{&schema.GeneratedExpr{Expr: "abs(c1)", Type: "STORED"}}},
+				}, t.Columns)
+			},
+		},
 		{
 			name: "table indexes",
 			before: func(m mock) {
@@ -91,10 +116,10 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.tableExists("users", true, "CREATE TABLE users(id INTEGER PRIMARY KEY)")
 				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
 					WillReturnRows(sqltest.Rows(`
- name |   type       | nullable | dflt_value  | primary 
-------+--------------+----------+ ------------+----------
- c1   | int           |  1      |             |  0
- c2   | integer       |  0      |             |  0
+ name |   type       | nullable | dflt_value  | primary | hidden
+------+--------------+----------+ ------------+----------+--------
+ c1   | int           |  1      |             |  0      |  0
+ c2   | integer       |  0      |             |  0      |  0
 `))
 				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "users"))).
 					WillReturnRows(sqltest.Rows(`
@@ -172,11 +197,11 @@ CREATE TABLE users(
 `)
 				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
 					WillReturnRows(sqltest.Rows(`
- name |   type       | nullable | dflt_value  | primary 
-------+--------------+----------+ ------------+----------
- c1   | int           |  1      |             |  0
- c2   | integer       |  0      |             |  0
- c3   | integer       |  0      |             |  0
+ name |   type       | nullable | dflt_value  | primary | hidden
+------+--------------+----------+ ------------+----------+--------
+ c1   | int           |  1      |             |  0      |  0
+ c2   | integer       |  0      |             |  0      |  0
+ c3   | integer       |  0      |             |  0      |  0
 `))
 				m.noIndexes("users")
 				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "users"))).
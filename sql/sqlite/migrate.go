@@ -21,6 +21,12 @@ type migrate struct{ conn }
 func (m *migrate) Exec(ctx context.Context, changes []schema.Change) (err error) {
 	for _, c := range changes {
 		switch c := c.(type) {
+		case *schema.AddSchema:
+			// The main database always exists, and attaching
+			// new databases is not supported by this driver.
+			if c.S.Name != "main" || !sqlx.Has(c.Extra, &schema.IfNotExists{}) {
+				err = fmt.Errorf("adding schema %q is not supported", c.S.Name)
+			}
 		case *schema.AddTable:
 			err = m.addTable(ctx, c)
 		case *schema.DropTable:
@@ -39,10 +45,11 @@ func (m *migrate) Exec(ctx context.Context, changes []schema.Change) (err error)
 
 // addTable builds and executes the query for creating a table in a schema.
 func (m *migrate) addTable(ctx context.Context, add *schema.AddTable) error {
-	b := Build("CREATE TABLE").Ident(add.T.Name)
+	b := Build("CREATE TABLE")
 	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
 		b.P("IF NOT EXISTS")
 	}
+	b.Ident(add.T.Name)
 	b.Wrap(func(b *sqlx.Builder) {
 		b.MapComma(add.T.Columns, func(i int, b *sqlx.Builder) {
 			m.column(b, add.T.Columns[i])
@@ -123,8 +130,14 @@ func (m *migrate) column(b *sqlx.Builder, c *schema.Column) {
 		b.P("NOT")
 	}
 	b.P("NULL")
-	if x, ok := c.Default.(*schema.RawExpr); ok {
-		b.P("DEFAULT", x.X)
+	var x schema.GeneratedExpr
+	generated := sqlx.Has(c.Attrs, &x)
+	// Generated columns cannot have a DEFAULT value.
+	if x, ok := sqlx.DefaultValue(c.Default); ok && !generated {
+		b.P("DEFAULT", x)
+	}
+	if generated {
+		b.P("GENERATED ALWAYS AS", sqlx.MayWrap(x.Expr), x.Type)
 	}
 	if sqlx.Has(c.Attrs, &AutoIncrement{}) {
 		b.P("PRIMARY KEY AUTOINCREMENT")
@@ -240,6 +253,10 @@ func (m *migrate) copyRows(ctx context.Context, from *schema.Table, to *schema.T
 		fromC, toC []string
 	)
 	for _, column := range to.Columns {
+		// Values of generated columns are computed by the database.
+		if sqlx.Has(column.Attrs, &schema.GeneratedExpr{}) {
+			continue
+		}
 		// Find a change that associated with this column, if exists.
 		var change schema.Change
 		for i := range changes {
@@ -327,6 +344,10 @@ func alterable(modify *schema.ModifyTable) bool {
 			if len(change.C.Indexes) > 0 || len(change.C.ForeignKeys) > 0 || change.C.Default != nil {
 				return false
 			}
+			// Only VIRTUAL generated columns can be added using ALTER TABLE.
+			if x := (schema.GeneratedExpr{}); sqlx.Has(change.C.Attrs, &x) && strings.EqualFold(x.Type, "STORED") {
+				return false
+			}
 		default:
 			return false
 		}
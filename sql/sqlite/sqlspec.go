@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"ariga.io/atlas/schema/schemaspec"
 	"ariga.io/atlas/sql/internal/specutil"
@@ -39,6 +38,35 @@ func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{
 	return nil
 }
 
+// ValidateSpec validates an Atlas DDL document without a database connection. Unlike
+// UnmarshalSpec, it does not stop on the first error, and reports all errors found in
+// the document.
+func ValidateSpec(data []byte, unmarshaler schemaspec.Unmarshaler) error {
+	var d doc
+	// Evaluation diagnostics do not stop the validation, as the document
+	// is still decoded from the resources that were evaluated.
+	evalErr := unmarshaler.UnmarshalSpec(data, &d)
+	if evalErr != nil && !specutil.Partial(evalErr, d.Schemas) {
+		return evalErr
+	}
+	if len(d.Schemas) != 1 {
+		return fmt.Errorf("sqlite: expecting document to contain a single schema, got %d", len(d.Schemas))
+	}
+	err := specutil.Validate(d.Schemas, d.Tables, specutil.ValidateOptions{
+		EvalErr:       evalErr,
+		ConvertColumn: convertColumn,
+	})
+	if err != nil {
+		return err
+	}
+	// Run the full conversion to catch errors that are not covered by the validation.
+	var s schema.Schema
+	return UnmarshalSpec(nil, schemaspec.UnmarshalerFunc(func(_ []byte, v interface{}) error {
+		*v.(*doc) = d
+		return nil
+	}), &s)
+}
+
 // MarshalSpec marshals v into an Atlas DDL document using a schemaspec.Marshaler.
 func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error) {
 	s, ok := v.(*schema.Schema)
@@ -99,19 +127,22 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 	case sqlspec.TypeTime:
 		return convertTime(spec)
 	default:
-		return parseRawType(spec.Type)
+		t, err := parseRawType(spec.Type)
+		if err != nil {
+			if s, ok := TypeRegistry.Suggest(columnParts(spec.Type)[0]); ok {
+				err = fmt.Errorf("%w, did you mean %q?", err, s)
+			}
+			return nil, err
+		}
+		return t, nil
 	}
 }
 
-func convertInteger(spec *sqlspec.Column) (schema.Type, error) {
-	if strings.HasPrefix(spec.Type, "u") {
-		// todo(rotemtam): support his once we can express CHECK(col >= 0)
-		return nil, fmt.Errorf("sqlite: unsigned integers currently not supported")
-	}
-	typ := &schema.IntegerType{
-		T: tInteger,
-	}
-	return typ, nil
+// convertInteger converts a portable integer type into an SQLite integer type. SQLite stores
+// all integers in up to 8 bytes, and does not support unsigned integers. Therefore, all integer
+// types are mapped to INTEGER, and their sign is not enforced.
+func convertInteger(_ *sqlspec.Column) (schema.Type, error) {
+	return &schema.IntegerType{T: tInteger}, nil
 }
 
 func convertBinary(spec *sqlspec.Column) (schema.Type, error) {
@@ -203,6 +234,7 @@ func columnSpec(col *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 		Name: col.Name,
 		Type: ct.Type,
 		Null: col.Type.Null,
+		As:   specutil.FromGeneratedExpr(col, "VIRTUAL"),
 		DefaultExtension: schemaspec.DefaultExtension{
 			Extra: schemaspec.Resource{Attrs: ct.DefaultExtension.Extra.Attrs},
 		},
@@ -252,6 +284,10 @@ func stringSpec(t *schema.StringType) (*sqlspec.Column, error) {
 	if t.T != tText {
 		return nil, fmt.Errorf("sqlite/spec/string: failed to convert type %q", t.T)
 	}
+	// Strings without a size are written as TEXT columns.
+	if t.Size == 0 {
+		return &sqlspec.Column{Type: tText}, nil
+	}
 	s := strconv.Itoa(t.Size)
 	return specutil.NewCol("", "string", specutil.LitAttr("size", s)), nil
 }
@@ -184,6 +184,14 @@ func TestUnmarshalSpecColumnTypes(t *testing.T) {
 				Unsigned: false,
 			},
 		},
+		{
+			spec:     specutil.NewCol("uint", "uint"),
+			expected: &schema.IntegerType{T: tInteger},
+		},
+		{
+			spec:     specutil.NewCol("uint64", "uint64"),
+			expected: &schema.IntegerType{T: tInteger},
+		},
 		{
 			spec: specutil.NewCol("string_varchar", "string", specutil.LitAttr("size", "255")),
 			expected: &schema.StringType{
@@ -278,12 +286,6 @@ func TestUnmarshalSpecColumnTypes(t *testing.T) {
 	}
 }
 
-func TestNotSupportedUnmarshalSpecColumnTypes(t *testing.T) {
-	var s schema.Schema
-	err := UnmarshalSpec(hcl(t, specutil.NewCol("uint64", "uint64")), schemahcl.Unmarshal, &s)
-	require.Error(t, err)
-}
-
 // hcl returns an Atlas HCL document containing the column spec.
 func hcl(t *testing.T, c *sqlspec.Column) []byte {
 	buf, err := schemahcl.Marshal(c)
@@ -302,6 +304,20 @@ table "table" {
 	return []byte(body)
 }
 
+func TestUnmarshalSpec_UnknownType(t *testing.T) {
+	var s schema.Schema
+	err := UnmarshalSpec([]byte(`
+schema "main" {}
+table "users" {
+  schema = schema.main
+  column "name" {
+    type = "varchr(255)"
+  }
+}
+`), hclState, &s)
+	require.EqualError(t, err, `sqlite: failed converting to *schema.Schema: 6:12: unknown column type "varchr", did you mean "varchar"?`)
+}
+
 func TestMarshalSpecColumnType(t *testing.T) {
 	for _, tt := range []struct {
 		schem    schema.Type
@@ -315,6 +331,10 @@ func TestMarshalSpecColumnType(t *testing.T) {
 			schem:    &schema.StringType{T: tText, Size: 17_000_000},
 			expected: specutil.NewCol("column", "string", specutil.LitAttr("size", "17000000")),
 		},
+		{
+			schem:    &schema.StringType{T: tText},
+			expected: specutil.NewCol("column", "text"),
+		},
 		{
 			schem:    &schema.DecimalType{T: "decimal", Precision: 10, Scale: 2},
 			expected: specutil.NewCol("column", "decimal", specutil.LitAttr("precision", "10"), specutil.LitAttr("scale", "2")),
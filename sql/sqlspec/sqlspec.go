@@ -30,9 +30,16 @@ type (
 		Null    bool                     `spec:"null" override:"null"`
 		Type    string                   `spec:"type" override:"type"`
 		Default *schemaspec.LiteralValue `spec:"default" override:"default"`
+		As      *GeneratedExpr           `spec:"as"`
 		schemaspec.DefaultExtension
 	}
 
+	// GeneratedExpr holds a specification for the expression of a generated column.
+	GeneratedExpr struct {
+		Expr string `spec:"expr"`
+		Type string `spec:"type"`
+	}
+
 	// PrimaryKey holds a specification for the primary key of a table.
 	PrimaryKey struct {
 		Columns []*schemaspec.Ref `spec:"columns"`
@@ -57,6 +64,21 @@ type (
 		schemaspec.DefaultExtension
 	}
 
+	// Grant holds a specification for privileges granted on a schema or a table.
+	// Columns can be set only for table grants.
+	Grant struct {
+		Grantee     string            `spec:",name"`
+		Privileges  []string          `spec:"privileges"`
+		Columns     []*schemaspec.Ref `spec:"columns"`
+		GrantOption bool              `spec:"grant_option"`
+	}
+
+	// Role holds a specification for a database role.
+	Role struct {
+		Name string `spec:",name"`
+		schemaspec.DefaultExtension
+	}
+
 	// Type represents a database agnostic column type.
 	Type string
 )